@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/Abraxas-365/relay/internal/ai/embeddings"
+	"github.com/Abraxas-365/relay/internal/ai/queryparser"
+	"github.com/Abraxas-365/relay/internal/ai/reranker"
 	"github.com/Abraxas-365/relay/internal/ai/resumeparser"
 	"github.com/Abraxas-365/relay/pkg/fsx"
 	"github.com/Abraxas-365/relay/pkg/fsx/fsxlocal"
@@ -27,14 +29,24 @@ import (
 	"github.com/Abraxas-365/relay/pkg/iam/user/userinfra"
 	"github.com/Abraxas-365/relay/pkg/iam/user/usersrv"
 	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume"
 	"github.com/Abraxas-365/relay/recruitment/resume/resumeapi"
 	"github.com/Abraxas-365/relay/recruitment/resume/resumeinfra"
 	"github.com/Abraxas-365/relay/recruitment/resume/resumesrv"
 	"github.com/Abraxas-365/relay/recruitment/resume/worker"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch/savedsearchapi"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch/savedsearchinfra"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch/savedsearchsrv"
+	"github.com/Abraxas-365/relay/recruitment/skill/skillapi"
+	"github.com/Abraxas-365/relay/recruitment/skill/skillinfra"
+	"github.com/Abraxas-365/relay/recruitment/skill/skillsrv"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -51,7 +63,9 @@ type Container struct {
 
 	// AI Services
 	ResumeParser *resumeparser.ResumeParser
-	EmbedGen     *embeddings.EmbeddingsGenerator
+	QueryParser  *queryparser.QueryParser
+	Embedders    *embeddings.Registry
+	Reranker     reranker.Reranker
 
 	// Core IAM Services
 	AuthService       *auth.AuthHandlers
@@ -63,13 +77,18 @@ type Container struct {
 	OTPService        *otpsrv.OTPService
 
 	// Recruitment Services
-	ResumeService *resumesrv.Service
-	ResumeWorker  *worker.ResumeWorker
+	ResumeService      *resumesrv.Service
+	SkillService       *skillsrv.Service
+	SavedSearchService *savedsearchsrv.Service
+	ResumeWorker       *worker.ResumeWorker
+	ReindexWorker      *worker.ReindexWorker
 
 	// API Handlers
-	APIKeyHandlers     *apikeyapi.APIKeyHandlers
-	InvitationHandlers *invitationapi.InvitationHandlers
-	ResumeHandlers     *resumeapi.ResumeHandlers
+	APIKeyHandlers      *apikeyapi.APIKeyHandlers
+	InvitationHandlers  *invitationapi.InvitationHandlers
+	ResumeHandlers      *resumeapi.ResumeHandlers
+	SkillHandlers       *skillapi.SkillHandlers
+	SavedSearchHandlers *savedsearchapi.SavedSearchHandlers
 
 	// Middleware
 	UnifiedAuthMiddleware *auth.UnifiedAuthMiddleware
@@ -182,10 +201,111 @@ func (c *Container) initAIServices() {
 	if openAIKey == "" {
 		logx.Warn("⚠️  OPENAI_API_KEY not set - Resume parsing will be disabled")
 	} else {
-		c.ResumeParser = resumeparser.NewResumeParser(openAIKey)
-		c.EmbedGen = embeddings.NewEmbeddingsGenerator(openAIKey)
-		logx.Info("✅ AI services initialized (GPT-4o + Embeddings)")
+		// Resume and query parsing share one client, and with it its connection pool and retries
+		client := openai.NewClient(option.WithAPIKey(openAIKey))
+		c.ResumeParser = resumeparser.NewResumeParser(&client)
+		c.QueryParser = queryparser.NewQueryParser(&client, getEnv("QUERY_PARSER_MODEL", queryparser.DefaultModel))
+		logx.Info("✅ AI services initialized (GPT-4o)")
 	}
+
+	c.initEmbedder(openAIKey)
+	c.initReranker(openAIKey)
+}
+
+// initReranker selects the search re-ranking provider from RERANK_PROVIDER (openai, openai-compatible).
+// Tenants still have to enable re-ranking in their search settings.
+func (c *Container) initReranker(openAIKey string) {
+	provider := reranker.Provider(getEnv("RERANK_PROVIDER", ""))
+	if provider == "" {
+		if openAIKey == "" {
+			logx.Warn("⚠️  No reranker configured - search re-ranking will be disabled")
+			return
+		}
+		provider = reranker.ProviderOpenAI
+	}
+
+	r, err := reranker.NewReranker(reranker.Config{
+		Provider:          provider,
+		APIKey:            getEnv("RERANK_API_KEY", openAIKey),
+		BaseURL:           getEnv("RERANK_BASE_URL", ""),
+		Model:             getEnv("RERANK_MODEL", ""),
+		InputCostPerMTok:  getEnvFloat("RERANK_INPUT_COST_PER_MTOK", 0),
+		OutputCostPerMTok: getEnvFloat("RERANK_OUTPUT_COST_PER_MTOK", 0),
+	})
+	if err != nil {
+		logx.Fatalf("Failed to initialize reranker: %v", err)
+	}
+
+	c.Reranker = r
+	logx.Infof("✅ Reranker initialized (%s)", r.Name())
+}
+
+// initEmbedder selects the embeddings provider from EMBEDDING_PROVIDER (openai, openai-compatible, local).
+// An additional model for embedding migrations can be configured with the same EMBEDDING_NEXT_* variables.
+func (c *Container) initEmbedder(openAIKey string) {
+	provider := embeddings.Provider(getEnv("EMBEDDING_PROVIDER", ""))
+	if provider == "" {
+		if openAIKey == "" {
+			logx.Warn("⚠️  No embeddings provider configured - semantic search will be disabled")
+			c.Embedders = embeddings.NewRegistry(nil)
+			return
+		}
+		provider = embeddings.ProviderOpenAI
+	}
+
+	// Only vectors up to the size of the configured storage can be indexed
+	settings, err := resumeinfra.NewPostgresEmbeddingMigrationRepository(c.DB).GetVectorIndexSettings(context.Background())
+	if err != nil {
+		logx.Fatalf("Failed to read vector index settings: %v", err)
+	}
+
+	embedder := newEmbedderFromEnv("EMBEDDING", provider, getEnv("EMBEDDING_API_KEY", openAIKey), settings.Storage)
+	logx.Infof("✅ Embeddings initialized (%s: %s, %d dims)", provider, embedder.Model(), embedder.Dimension())
+
+	others := []embeddings.Embedder{}
+	if nextProvider := embeddings.Provider(getEnv("EMBEDDING_NEXT_PROVIDER", "")); nextProvider != "" {
+		next := newEmbedderFromEnv("EMBEDDING_NEXT", nextProvider, getEnv("EMBEDDING_NEXT_API_KEY", openAIKey), settings.Storage)
+		if next.Model() == embedder.Model() {
+			// Vectors are stored by model name, so the two would be indistinguishable
+			logx.Warnf("⚠️  EMBEDDING_NEXT has the same model name as EMBEDDING (%s) and is ignored; set EMBEDDING_NEXT_NAME to tell them apart, e.g. %s-%d",
+				next.Model(), next.Model(), next.Dimension())
+		} else {
+			others = append(others, next)
+			logx.Infof("✅ Migration embeddings initialized (%s: %s, %d dims)", nextProvider, next.Model(), next.Dimension())
+		}
+	}
+
+	// Identical section texts are embedded once per model, across resumes and tenants
+	cache := embeddings.NewRedisCache(c.Redis, time.Duration(getEnvInt("EMBEDDING_CACHE_TTL_HOURS", 720))*time.Hour)
+	embedder = embeddings.NewCachedEmbedder(embedder, cache)
+	for i, other := range others {
+		others[i] = embeddings.NewCachedEmbedder(other, cache)
+	}
+
+	c.Embedders = embeddings.NewRegistry(embedder, others...)
+}
+
+// newEmbedderFromEnv creates an embedder from the <prefix>_BASE_URL, _MODEL, _DIMENSION and _NAME variables
+func newEmbedderFromEnv(prefix string, provider embeddings.Provider, apiKey string, storage resume.VectorStorage) embeddings.Embedder {
+	embedder, err := embeddings.NewEmbedder(embeddings.Config{
+		Provider:  provider,
+		APIKey:    apiKey,
+		BaseURL:   getEnv(prefix+"_BASE_URL", ""),
+		Model:     getEnv(prefix+"_MODEL", ""),
+		Dimension: getEnvInt(prefix+"_DIMENSION", embeddings.DefaultDimension),
+		Name:      getEnv(prefix+"_NAME", ""),
+	})
+	if err != nil {
+		logx.Fatalf("Failed to initialize embeddings provider (%s): %v", prefix, err)
+	}
+
+	// Fail fast instead of on every write
+	if embedder.Dimension() > storage.MaxDimension() {
+		logx.Fatalf("Embedding dimension %d of model %s exceeds the indexable maximum of %s storage (%d); see configure_resume_vector_indexes",
+			embedder.Dimension(), embedder.Model(), storage, storage.MaxDimension())
+	}
+
+	return embedder
 }
 
 func (c *Container) initRepositories() {
@@ -204,6 +324,11 @@ func (c *Container) initRepositories() {
 	// --- Recruitment Repositories ---
 	resumeRepo := resumeinfra.NewPostgresResumeRepository(c.DB)
 	jobRepo := resumeinfra.NewPostgresJobRepository(c.DB)
+	migrationRepo := resumeinfra.NewPostgresEmbeddingMigrationRepository(c.DB)
+	duplicateRepo := resumeinfra.NewPostgresDuplicateRepository(c.DB)
+	skillRepo := skillinfra.NewPostgresSkillRepository(c.DB)
+	savedSearchRepo := savedsearchinfra.NewPostgresSavedSearchRepository(c.DB)
+	notificationRepo := savedsearchinfra.NewPostgresNotificationRepository(c.DB)
 
 	// --- Queue Infrastructure ---
 	queueName := getEnv("RESUME_QUEUE_NAME", "resume:processing")
@@ -247,19 +372,38 @@ func (c *Container) initRepositories() {
 	)
 
 	// --- Recruitment Services ---
+	c.SkillService = skillsrv.NewService(skillRepo)
 	c.ResumeService = resumesrv.NewService(
 		resumeRepo,
 		c.ResumeParser,
-		c.EmbedGen,
+		c.Embedders,
 		jobRepo,
 		c.FileSystem,
 		resumeQueue,
+		tenantConfigRepo,
+		migrationRepo,
+		c.SkillService,
+		duplicateRepo,
+		c.QueryParser,
+		c.Reranker,
+		resumeinfra.NewRedisSearchCache(c.Redis, time.Duration(getEnvInt("SEARCH_RESULTS_TTL_MINUTES", 30))*time.Minute),
+	)
+	c.SavedSearchService = savedsearchsrv.NewService(
+		savedSearchRepo,
+		notificationRepo,
+		c.ResumeService,
+		userRepo,
+		newSavedSearchNotifier(),
 	)
+	// Score every newly processed resume against the tenant's saved searches
+	c.ResumeService.AddProcessedListener(c.SavedSearchService)
 
 	// --- API Handlers ---
 	c.APIKeyHandlers = apikeyapi.NewAPIKeyHandlers(c.APIKeyService)
 	c.InvitationHandlers = invitationapi.NewInvitationHandlers(c.InvitationService)
 	c.ResumeHandlers = resumeapi.NewResumeHandlers(c.ResumeService, c.FileSystem)
+	c.SkillHandlers = skillapi.NewSkillHandlers(c.SkillService)
+	c.SavedSearchHandlers = savedsearchapi.NewSavedSearchHandlers(c.SavedSearchService)
 
 	// --- Middleware ---
 	c.AuthMiddleware = auth.NewAuthMiddleware(c.TokenService)
@@ -268,6 +412,26 @@ func (c *Container) initRepositories() {
 	logx.Info("✅ All services and handlers initialized")
 }
 
+// newSavedSearchNotifier selects how saved search alerts are emailed: through SMTP when
+// SMTP_HOST is set, otherwise they are only logged. The inbox is filled either way.
+func newSavedSearchNotifier() savedsearch.Notifier {
+	host := getEnv("SMTP_HOST", "")
+	if host == "" {
+		logx.Warn("⚠️  No SMTP server configured - saved search email alerts will only be logged")
+		return savedsearchinfra.NewLogNotifier()
+	}
+
+	logx.Infof("✅ Saved search email alerts via SMTP (%s)", host)
+	return savedsearchinfra.NewSMTPNotifier(savedsearchinfra.SMTPConfig{
+		Host:     host,
+		Port:     getEnv("SMTP_PORT", "587"),
+		Username: getEnv("SMTP_USERNAME", ""),
+		Password: getEnv("SMTP_PASSWORD", ""),
+		From:     getEnv("SMTP_FROM", "alerts@relay.local"),
+		AppURL:   getEnv("APP_URL", ""),
+	})
+}
+
 func (c *Container) initWorkers() {
 	logx.Info("👷 Initializing background workers...")
 
@@ -288,8 +452,16 @@ func (c *Container) initWorkers() {
 		workerCount,
 	)
 
+	// Initialize embedding re-index worker
+	c.ReindexWorker = worker.NewReindexWorker(
+		c.ResumeService,
+		time.Duration(getEnvInt("REINDEX_POLL_SECONDS", 15))*time.Second,
+		time.Duration(getEnvInt("REINDEX_STALE_SECONDS", 300))*time.Second,
+	)
+
 	// Start workers
 	c.ResumeWorker.Start(c.workerCtx)
+	c.ReindexWorker.Start(c.workerCtx)
 
 	logx.Infof("✅ Started %d resume processing workers", workerCount)
 }
@@ -307,6 +479,15 @@ func (c *Container) Cleanup() {
 		logx.Info("✅ Workers stopped")
 	}
 
+	// Let the listeners of the last processed resumes finish before closing connections
+	if c.ResumeService != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(getEnvInt("LISTENER_SHUTDOWN_SECONDS", 30))*time.Second)
+		if err := c.ResumeService.WaitForListeners(ctx); err != nil {
+			logx.Warnf("Stopped waiting for resume listeners: %v", err)
+		}
+		cancel()
+	}
+
 	// Close database connection
 	if c.DB != nil {
 		if err := c.DB.Close(); err != nil {
@@ -356,6 +537,21 @@ func getEnvInt(key string, defaultValue int) int {
 	return intValue
 }
 
+// getEnvFloat gets an environment variable as float64 with a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var floatValue float64
+	if _, err := fmt.Sscanf(value, "%g", &floatValue); err != nil {
+		logx.Warnf("Invalid float value for %s: %s, using default: %g", key, value, defaultValue)
+		return defaultValue
+	}
+	return floatValue
+}
+
 // ============================================================================
 // Console Notifier for OTP (Development)
 // ============================================================================
@@ -102,6 +102,14 @@ func main() {
 	container.ResumeHandlers.RegisterRoutes(app, container.UnifiedAuthMiddleware)
 	logx.Info("✓ Resume routes registered")
 
+	// Skill Taxonomy: /api/v1/skills/*
+	container.SkillHandlers.RegisterRoutes(app, container.UnifiedAuthMiddleware)
+	logx.Info("✓ Skill taxonomy routes registered")
+
+	// Saved Searches & Notifications: /api/v1/saved-searches/*, /api/v1/notifications/*
+	container.SavedSearchHandlers.RegisterRoutes(app, container.UnifiedAuthMiddleware)
+	logx.Info("✓ Saved search routes registered")
+
 	// ========================================================================
 	// Future Routes (Placeholder)
 	// ========================================================================
@@ -213,11 +221,15 @@ func apiDocsHandler(c *fiber.Ctx) error {
 				"resumes": fiber.Map{
 					"parse":      "POST /api/v1/resumes/parse (multipart/form-data)",
 					"create":     "POST /api/v1/resumes",
-					"list":       "GET /api/v1/resumes",
-					"get":        "GET /api/v1/resumes/:id",
+					"list":       "GET /api/v1/resumes?page_size=20&cursor=...&direction=desc&needs_review=true&facets=skills,cities,experience,languages",
+					"get":        "GET /api/v1/resumes/:id?include=duplicates",
 					"update":     "PUT /api/v1/resumes/:id",
 					"delete":     "DELETE /api/v1/resumes/:id",
 					"search":     "POST /api/v1/resumes/search",
+					"interpret":  "POST /api/v1/resumes/search/interpret",
+					"weights":    "GET|PUT|DELETE /api/v1/resumes/search/weights",
+					"rerank":     "GET|PUT|DELETE /api/v1/resumes/search/rerank",
+					"similar":    "GET /api/v1/resumes/:id/similar",
 					"stats":      "GET /api/v1/resumes/stats",
 					"default":    "PUT /api/v1/resumes/:id/default",
 					"activate":   "PUT /api/v1/resumes/:id/activate",
@@ -225,6 +237,46 @@ func apiDocsHandler(c *fiber.Ctx) error {
 					"embeddings": "PUT /api/v1/resumes/:id/embeddings",
 					"bulk_embed": "POST /api/v1/resumes/embeddings/bulk",
 				},
+				"parse_review": fiber.Map{
+					"settings": "GET|PUT|DELETE /api/v1/resumes/parse/review",
+					"draft":    "PUT /api/v1/resumes/jobs/:job_id/draft",
+					"approve":  "POST /api/v1/resumes/jobs/:job_id/approve",
+					"reject":   "POST /api/v1/resumes/jobs/:job_id/reject",
+				},
+				"duplicates": fiber.Map{
+					"scan":  "POST /api/v1/resumes/duplicates/scan",
+					"list":  "GET /api/v1/resumes/:id/duplicates",
+					"merge": "POST /api/v1/resumes/:id/merge",
+					"log":   "GET /api/v1/resumes/:id/merges",
+				},
+				"embedding_migrations": fiber.Map{
+					"start":   "POST /api/v1/resumes/embeddings/migrations",
+					"list":    "GET /api/v1/resumes/embeddings/migrations",
+					"get":     "GET /api/v1/resumes/embeddings/migrations/:migration_id",
+					"cutover": "POST /api/v1/resumes/embeddings/migrations/:migration_id/cutover",
+					"cleanup": "POST /api/v1/resumes/embeddings/migrations/:migration_id/cleanup",
+				},
+				"skills": fiber.Map{
+					"list":    "GET /api/v1/skills",
+					"resolve": "GET /api/v1/skills/resolve?names=JS,reactjs",
+					"create":  "POST /api/v1/skills",
+					"update":  "PUT /api/v1/skills/:id",
+					"delete":  "DELETE /api/v1/skills/:id",
+				},
+				"saved_searches": fiber.Map{
+					"list":   "GET /api/v1/saved-searches",
+					"create": "POST /api/v1/saved-searches",
+					"get":    "GET /api/v1/saved-searches/:id",
+					"update": "PUT /api/v1/saved-searches/:id",
+					"delete": "DELETE /api/v1/saved-searches/:id",
+					"run":    "POST /api/v1/saved-searches/:id/run",
+				},
+				"notifications": fiber.Map{
+					"list":         "GET /api/v1/notifications?unread=true",
+					"unread_count": "GET /api/v1/notifications/unread-count",
+					"read":         "POST /api/v1/notifications/:id/read",
+					"read_all":     "POST /api/v1/notifications/read-all",
+				},
 			},
 		},
 		"authentication": fiber.Map{
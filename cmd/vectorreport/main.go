@@ -0,0 +1,134 @@
+// Command vectorreport measures the recall and latency of the HNSW vector indexes
+// against exact search, using stored vectors of one embedding model as queries:
+//
+//	go run ./cmd/vectorreport -k 10 -samples 100 -ef 40,100,200
+//
+// It connects with the same DB_* variables as the API server. Exact search scans
+// every vector of the model, so prefer a replica on large installations.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume/resumeinfra"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	model := flag.String("model", "", "embedding model to measure (default: the model with the most vectors)")
+	sections := flag.String("sections", "", "comma-separated sections to measure (default: all, plus entries)")
+	k := flag.Int("k", 10, "nearest neighbours compared per query")
+	samples := flag.Int("samples", 50, "stored vectors used as queries")
+	efSearch := flag.String("ef", "40,100,200", "comma-separated ef_search values to measure")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	cfg := resumeinfra.VectorBenchmarkConfig{
+		Model:   *model,
+		K:       *k,
+		Samples: *samples,
+	}
+	for _, section := range strings.Split(*sections, ",") {
+		if section = strings.TrimSpace(section); section != "" {
+			cfg.Sections = append(cfg.Sections, section)
+		}
+	}
+	for _, value := range strings.Split(*efSearch, ",") {
+		ef, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			logx.Fatalf("Invalid ef_search value %q", value)
+		}
+		cfg.EfSearch = append(cfg.EfSearch, ef)
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		getEnv("DB_HOST", "localhost"),
+		getEnv("DB_PORT", "5432"),
+		getEnv("DB_USER", "postgres"),
+		getEnv("DB_PASS", "postgres"),
+		getEnv("DB_NAME", "relay"),
+	)
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		logx.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	report, err := resumeinfra.NewVectorBenchmark(db).Run(context.Background(), cfg)
+	if err != nil {
+		logx.Fatalf("Vector benchmark failed: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			logx.Fatalf("Failed to encode report: %v", err)
+		}
+		return
+	}
+	printReport(report)
+}
+
+// printReport writes the report as a table per section
+func printReport(report *resumeinfra.VectorBenchmarkReport) {
+	fmt.Printf("Model %s (%d dims), %s storage, m=%d, ef_construction=%d, default ef_search=%d\n",
+		report.Model, report.Dim, report.Settings.Storage, report.Settings.M,
+		report.Settings.EfConstruction, report.Settings.EfSearch)
+	fmt.Printf("Recall@%d over %d sampled queries\n", report.K, report.Samples)
+
+	for _, section := range report.Sections {
+		fmt.Printf("\n%s: %d vectors, %.0f bytes per vector, index %s (%s)\n",
+			section.Section, section.Vectors, section.AvgVectorBytes, section.IndexName, formatBytes(section.IndexBytes))
+		if section.Vectors == 0 {
+			continue
+		}
+		if !section.IndexUsed {
+			fmt.Println("  warning: the planner does not use the index for this query; results below are exact scans")
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  search\trecall\tmean\tp50\tp95")
+		fmt.Fprintf(w, "  exact\t%.3f\t%s\t%s\t%s\n", 1.0,
+			formatDuration(section.Exact.Mean), formatDuration(section.Exact.P50), formatDuration(section.Exact.P95))
+		for _, result := range section.Results {
+			fmt.Fprintf(w, "  ef_search=%d\t%.3f\t%s\t%s\t%s\n", result.EfSearch, result.Recall,
+				formatDuration(result.Latency.Mean), formatDuration(result.Latency.P50), formatDuration(result.Latency.P95))
+		}
+		w.Flush()
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,138 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Abraxas-365/relay/pkg/logx"
+)
+
+// Cache stores embeddings by model and content hash, shared across resumes and processes
+type Cache interface {
+	// GetMany returns the cached vectors found for the hashes, keyed by hash
+	GetMany(ctx context.Context, model string, hashes []string) (map[string][]float32, error)
+
+	// SetMany stores vectors keyed by hash
+	SetMany(ctx context.Context, model string, vectors map[string][]float32) error
+}
+
+// ContentHash returns the cache key of a text
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// CachedEmbedder serves identical texts from a cache so each one is embedded once per model
+type CachedEmbedder struct {
+	Embedder
+	cache   Cache
+	refresh bool // Skip cache reads, so every text is embedded again
+}
+
+// NewCachedEmbedder wraps an embedder with a cache
+func NewCachedEmbedder(embedder Embedder, cache Cache) *CachedEmbedder {
+	return &CachedEmbedder{Embedder: embedder, cache: cache}
+}
+
+// Refreshing returns an embedder that calls the provider for every text and overwrites
+// the cached vectors, to replace bad ones. Embedders without a cache are returned as is.
+func Refreshing(embedder Embedder) Embedder {
+	cached, ok := embedder.(*CachedEmbedder)
+	if !ok {
+		return embedder
+	}
+	refreshing := *cached
+	refreshing.refresh = true
+	return &refreshing
+}
+
+// GenerateEmbedding creates an embedding vector for text, using the cache when possible
+func (e *CachedEmbedder) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+
+	embeddings, err := e.GenerateBatchEmbeddings(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// GenerateBatchEmbeddings creates embeddings for multiple texts, calling the provider
+// only for texts missing from the cache. Empty texts get a nil vector.
+func (e *CachedEmbedder) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	validTexts := make([]string, 0, len(texts))
+	positions := make([]int, 0, len(texts))
+	for i, text := range texts {
+		if text != "" {
+			validTexts = append(validTexts, text)
+			positions = append(positions, i)
+		}
+	}
+	if len(validTexts) == 0 {
+		return e.Embedder.GenerateBatchEmbeddings(ctx, texts)
+	}
+
+	model := e.Model()
+	hashes := make([]string, len(validTexts))
+	for i, text := range validTexts {
+		hashes[i] = ContentHash(text)
+	}
+
+	cached := map[string][]float32{}
+	if !e.refresh {
+		found, err := e.cache.GetMany(ctx, model, hashes)
+		if err != nil {
+			// The cache is an optimization; fall back to the provider
+			logx.Warnf("Embedding cache read failed for model %s: %v", model, err)
+		} else {
+			cached = found
+		}
+	}
+
+	// Embed each missing text once, even if it appears several times in the batch
+	missing := []string{}
+	missingHashes := []string{}
+	queued := make(map[string]bool)
+	for i, hash := range hashes {
+		if vector, ok := cached[hash]; ok && len(vector) == e.Dimension() {
+			continue
+		}
+		if !queued[hash] {
+			queued[hash] = true
+			missing = append(missing, validTexts[i])
+			missingHashes = append(missingHashes, hash)
+		}
+	}
+
+	if len(missing) > 0 {
+		generated, err := e.Embedder.GenerateBatchEmbeddings(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(generated) != len(missing) {
+			return nil, fmt.Errorf("embedding count mismatch: expected %d, got %d", len(missing), len(generated))
+		}
+
+		fresh := make(map[string][]float32, len(generated))
+		for i, vector := range generated {
+			fresh[missingHashes[i]] = vector
+			cached[missingHashes[i]] = vector
+		}
+
+		if err := e.cache.SetMany(ctx, model, fresh); err != nil {
+			logx.Warnf("Embedding cache write failed for model %s: %v", model, err)
+		}
+	}
+
+	logx.Debugf("Embedding cache for model %s: %d of %d texts cached", model, len(validTexts)-len(missing), len(validTexts))
+
+	embeddings := make([][]float32, len(texts))
+	for i, hash := range hashes {
+		embeddings[positions[i]] = cached[hash]
+	}
+	return embeddings, nil
+}
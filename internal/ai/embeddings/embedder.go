@@ -0,0 +1,110 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+)
+
+// Embedder turns text into embedding vectors
+type Embedder interface {
+	// GenerateEmbedding creates an embedding vector for text
+	GenerateEmbedding(ctx context.Context, text string) ([]float32, error)
+
+	// GenerateBatchEmbeddings creates embeddings for multiple texts, in order: vector i
+	// belongs to text i, and is nil when text i is empty
+	GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Model returns the model name recorded alongside generated vectors
+	Model() string
+
+	// Dimension returns the length of the generated vectors
+	Dimension() int
+}
+
+// Provider identifies an embeddings backend
+type Provider string
+
+const (
+	ProviderOpenAI           Provider = "openai"            // OpenAI API
+	ProviderOpenAICompatible Provider = "openai-compatible" // Any server exposing the OpenAI embeddings API
+	ProviderLocal            Provider = "local"             // Deterministic hashing embedder, no network
+)
+
+const (
+	DefaultModel     = "text-embedding-3-small"
+	DefaultDimension = 1536
+)
+
+// Config selects and configures an embeddings provider
+type Config struct {
+	Provider  Provider
+	APIKey    string
+	BaseURL   string // Required for openai-compatible
+	Model     string
+	Dimension int
+
+	// Name is recorded with the vectors instead of Model. Set it to run a model at a
+	// reduced dimension next to its full-size vectors, e.g. text-embedding-3-small-512.
+	Name string
+}
+
+// NewEmbedder creates the embedder selected by the config
+func NewEmbedder(cfg Config) (Embedder, error) {
+	embedder, err := newProviderEmbedder(cfg)
+	if err != nil || cfg.Name == "" {
+		return embedder, err
+	}
+	return &namedEmbedder{Embedder: embedder, name: cfg.Name}, nil
+}
+
+func newProviderEmbedder(cfg Config) (Embedder, error) {
+	if cfg.Dimension <= 0 {
+		cfg.Dimension = DefaultDimension
+	}
+
+	switch cfg.Provider {
+	case ProviderOpenAI, "":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("openai embeddings provider requires an API key")
+		}
+		if cfg.Model == "" {
+			cfg.Model = DefaultModel
+		}
+		return newOpenAIGenerator(cfg.APIKey, "", cfg.Model, cfg.Dimension, true), nil
+
+	case ProviderOpenAICompatible:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("openai-compatible embeddings provider requires a base URL")
+		}
+		if cfg.Model == "" {
+			return nil, fmt.Errorf("openai-compatible embeddings provider requires a model")
+		}
+		// Self-hosted models have a fixed size; the dimension is only checked, never requested
+		return newOpenAIGenerator(cfg.APIKey, cfg.BaseURL, cfg.Model, cfg.Dimension, false), nil
+
+	case ProviderLocal:
+		return NewLocalEmbedder(cfg.Dimension), nil
+
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider: %s", cfg.Provider)
+	}
+}
+
+// namedEmbedder records its vectors under another name than the provider model
+type namedEmbedder struct {
+	Embedder
+	name string
+}
+
+// Model returns the name recorded alongside generated vectors
+func (e *namedEmbedder) Model() string {
+	return e.name
+}
+
+// checkDimension verifies a generated vector has the expected length
+func checkDimension(model string, expected int, embedding []float32) error {
+	if len(embedding) != expected {
+		return fmt.Errorf("embedding dimension mismatch for model %s: expected %d, got %d", model, expected, len(embedding))
+	}
+	return nil
+}
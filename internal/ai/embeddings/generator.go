@@ -3,27 +3,58 @@ package embeddings
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/openai/openai-go/v3"
 	"github.com/openai/openai-go/v3/option"
 )
 
-// EmbeddingsGenerator handles creating embeddings for semantic search
+// EmbeddingsGenerator creates embeddings through the OpenAI embeddings API,
+// either from OpenAI itself or from any OpenAI-compatible server
 type EmbeddingsGenerator struct {
-	client *openai.Client
+	client    *openai.Client
+	model     string
+	dimension int
+
+	// requestDimension asks the API for vectors of the configured size
+	// (only supported by OpenAI text-embedding-3 models)
+	requestDimension bool
 }
 
-// NewEmbeddingsGenerator creates a new embeddings generator
+// NewEmbeddingsGenerator creates an OpenAI embeddings generator with the default model
 func NewEmbeddingsGenerator(apiKey string) *EmbeddingsGenerator {
-	client := openai.NewClient(
-		option.WithAPIKey(apiKey),
-	)
+	return newOpenAIGenerator(apiKey, "", DefaultModel, DefaultDimension, true)
+}
+
+func newOpenAIGenerator(apiKey, baseURL, model string, dimension int, isOpenAI bool) *EmbeddingsGenerator {
+	opts := []option.RequestOption{}
+	if apiKey != "" {
+		opts = append(opts, option.WithAPIKey(apiKey))
+	}
+	if baseURL != "" {
+		opts = append(opts, option.WithBaseURL(baseURL))
+	}
+
+	client := openai.NewClient(opts...)
 
 	return &EmbeddingsGenerator{
-		client: &client,
+		client:           &client,
+		model:            model,
+		dimension:        dimension,
+		requestDimension: isOpenAI && strings.HasPrefix(model, "text-embedding-3"),
 	}
 }
 
+// Model returns the embedding model name
+func (g *EmbeddingsGenerator) Model() string {
+	return g.model
+}
+
+// Dimension returns the embedding vector length
+func (g *EmbeddingsGenerator) Dimension() int {
+	return g.dimension
+}
+
 // GenerateEmbedding creates an embedding vector for text
 func (g *EmbeddingsGenerator) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
 	if text == "" {
@@ -31,71 +62,89 @@ func (g *EmbeddingsGenerator) GenerateEmbedding(ctx context.Context, text string
 	}
 
 	// Send as array with single element (works consistently)
-	resp, err := g.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
-		Input: openai.EmbeddingNewParamsInputUnion{
-			OfArrayOfStrings: []string{text},
-		},
-		Model: openai.EmbeddingModelTextEmbedding3Small,
-	})
-
+	embeddings, err := g.create(ctx, []string{text})
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
-	if len(resp.Data) == 0 {
-		return nil, fmt.Errorf("no embedding data returned")
-	}
-
-	// Convert []float64 to []float32
-	embedding64 := resp.Data[0].Embedding
-	embedding32 := make([]float32, len(embedding64))
-	for i, v := range embedding64 {
-		embedding32[i] = float32(v)
-	}
-
-	return embedding32, nil
+	return embeddings[0], nil
 }
 
-// GenerateBatchEmbeddings creates embeddings for multiple texts
+// GenerateBatchEmbeddings creates embeddings for multiple texts, leaving a nil vector
+// at the position of each empty text
 func (g *EmbeddingsGenerator) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
 	if len(texts) == 0 {
 		return nil, fmt.Errorf("no texts provided")
 	}
 
-	// Filter out empty strings
+	// Empty texts are not sent, but keep their positions
 	validTexts := make([]string, 0, len(texts))
-	for _, text := range texts {
+	positions := make([]int, 0, len(texts))
+	for i, text := range texts {
 		if text != "" {
 			validTexts = append(validTexts, text)
+			positions = append(positions, i)
 		}
 	}
 
+	embeddings := make([][]float32, len(texts))
 	if len(validTexts) == 0 {
-		return nil, fmt.Errorf("all texts are empty")
+		return embeddings, nil
+	}
+
+	generated, err := g.create(ctx, validTexts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embeddings: %w", err)
 	}
 
-	resp, err := g.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+	for i, position := range positions {
+		embeddings[position] = generated[i]
+	}
+	return embeddings, nil
+}
+
+// create calls the embeddings endpoint and checks the returned vectors
+func (g *EmbeddingsGenerator) create(ctx context.Context, texts []string) ([][]float32, error) {
+	params := openai.EmbeddingNewParams{
 		Input: openai.EmbeddingNewParamsInputUnion{
-			OfArrayOfStrings: validTexts,
+			OfArrayOfStrings: texts,
 		},
-		Model: openai.EmbeddingModelTextEmbedding3Small,
-	})
+		Model: openai.EmbeddingModel(g.model),
+	}
+	if g.requestDimension {
+		params.Dimensions = openai.Int(int64(g.dimension))
+	}
 
+	resp, err := g.client.Embeddings.New(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate embeddings: %w", err)
+		return nil, err
 	}
 
 	if len(resp.Data) == 0 {
 		return nil, fmt.Errorf("no embedding data returned")
 	}
 
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding count mismatch: expected %d, got %d", len(texts), len(resp.Data))
+	}
+
+	// Convert []float64 to []float32, keeping the input order
 	embeddings := make([][]float32, len(resp.Data))
 	for i, data := range resp.Data {
 		embedding32 := make([]float32, len(data.Embedding))
 		for j, v := range data.Embedding {
 			embedding32[j] = float32(v)
 		}
-		embeddings[i] = embedding32
+
+		if err := checkDimension(g.model, g.dimension, embedding32); err != nil {
+			return nil, err
+		}
+
+		index := int(data.Index)
+		if index < 0 || index >= len(embeddings) {
+			index = i
+		}
+		embeddings[index] = embedding32
 	}
 
 	return embeddings, nil
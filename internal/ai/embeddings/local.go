@@ -0,0 +1,104 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// LocalModel is the model name recorded for vectors from the local embedder
+const LocalModel = "local-hash-v1"
+
+// LocalEmbedder is a deterministic feature-hashing embedder for offline development and tests.
+// Texts sharing words and word pairs get similar vectors; it has no semantic understanding.
+type LocalEmbedder struct {
+	dimension int
+}
+
+// NewLocalEmbedder creates a local hashing embedder
+func NewLocalEmbedder(dimension int) *LocalEmbedder {
+	if dimension <= 0 {
+		dimension = DefaultDimension
+	}
+	return &LocalEmbedder{dimension: dimension}
+}
+
+// Model returns the embedding model name
+func (e *LocalEmbedder) Model() string {
+	return LocalModel
+}
+
+// Dimension returns the embedding vector length
+func (e *LocalEmbedder) Dimension() int {
+	return e.dimension
+}
+
+// GenerateEmbedding creates an embedding vector for text
+func (e *LocalEmbedder) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+	return e.embed(text), nil
+}
+
+// GenerateBatchEmbeddings creates embeddings for multiple texts, leaving a nil vector
+// at the position of each empty text
+func (e *LocalEmbedder) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("no texts provided")
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		if text != "" {
+			embeddings[i] = e.embed(text)
+		}
+	}
+
+	return embeddings, nil
+}
+
+// embed hashes words and adjacent word pairs into a signed bag-of-features vector, L2-normalized
+func (e *LocalEmbedder) embed(text string) []float32 {
+	vector := make([]float32, e.dimension)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+
+	for i, word := range words {
+		e.addFeature(vector, word, 1)
+		if i > 0 {
+			e.addFeature(vector, words[i-1]+" "+word, 0.5)
+		}
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vector
+	}
+
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+	return vector
+}
+
+func (e *LocalEmbedder) addFeature(vector []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	index := int(sum % uint64(e.dimension))
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[index] += weight
+}
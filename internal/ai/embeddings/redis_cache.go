@@ -0,0 +1,92 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache stores embeddings in Redis as little-endian float32 blobs
+type RedisCache struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisCache creates a Redis-backed embedding cache; entries expire after ttl (0 keeps them)
+func NewRedisCache(client *redis.Client, ttl time.Duration) *RedisCache {
+	return &RedisCache{
+		client: client,
+		prefix: "embedding:cache",
+		ttl:    ttl,
+	}
+}
+
+func (c *RedisCache) key(model, hash string) string {
+	return fmt.Sprintf("%s:%s:%s", c.prefix, model, hash)
+}
+
+// GetMany returns the cached vectors found for the hashes, keyed by hash
+func (c *RedisCache) GetMany(ctx context.Context, model string, hashes []string) (map[string][]float32, error) {
+	result := make(map[string][]float32)
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, len(hashes))
+	for i, hash := range hashes {
+		keys[i] = c.key(model, hash)
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read embedding cache: %w", err)
+	}
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok || len(data)%4 != 0 {
+			continue
+		}
+		result[hashes[i]] = decodeVector([]byte(data))
+	}
+
+	return result, nil
+}
+
+// SetMany stores vectors keyed by hash
+func (c *RedisCache) SetMany(ctx context.Context, model string, vectors map[string][]float32) error {
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for hash, vector := range vectors {
+		pipe.Set(ctx, c.key(model, hash), encodeVector(vector), c.ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("write embedding cache: %w", err)
+	}
+	return nil
+}
+
+func encodeVector(vector []float32) []byte {
+	data := make([]byte, len(vector)*4)
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(v))
+	}
+	return data
+}
+
+func decodeVector(data []byte) []float32 {
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return vector
+}
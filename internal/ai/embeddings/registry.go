@@ -0,0 +1,57 @@
+package embeddings
+
+import "sort"
+
+// Registry holds the configured embedders by model name.
+// The default embedder serves tenants that have not been migrated to another model.
+type Registry struct {
+	defaultModel string
+	embedders    map[string]Embedder
+}
+
+// NewRegistry creates a registry with a default embedder and optional additional ones
+func NewRegistry(defaultEmbedder Embedder, others ...Embedder) *Registry {
+	r := &Registry{embedders: make(map[string]Embedder)}
+	if defaultEmbedder != nil {
+		r.defaultModel = defaultEmbedder.Model()
+		r.embedders[r.defaultModel] = defaultEmbedder
+	}
+	for _, e := range others {
+		if e != nil {
+			if _, exists := r.embedders[e.Model()]; !exists {
+				r.embedders[e.Model()] = e
+			}
+		}
+	}
+	return r
+}
+
+// Default returns the default embedder, or nil when none is configured
+func (r *Registry) Default() Embedder {
+	if r == nil {
+		return nil
+	}
+	return r.embedders[r.defaultModel]
+}
+
+// Get returns the embedder for a model
+func (r *Registry) Get(model string) (Embedder, bool) {
+	if r == nil {
+		return nil, false
+	}
+	e, ok := r.embedders[model]
+	return e, ok
+}
+
+// Models returns the names of all configured models, sorted
+func (r *Registry) Models() []string {
+	if r == nil {
+		return nil
+	}
+	models := make([]string, 0, len(r.embedders))
+	for model := range r.embedders {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+	return models
+}
@@ -0,0 +1,137 @@
+package queryparser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// DefaultModel is the chat model used to interpret search queries when none is configured
+const DefaultModel = "gpt-4o-mini"
+
+// QueryParser turns a recruiter's free-text search into structured filters using OpenAI
+type QueryParser struct {
+	client *openai.Client
+	model  string
+}
+
+// NewQueryParser creates a query parser on a shared OpenAI client
+func NewQueryParser(client *openai.Client, model string) *QueryParser {
+	if model == "" {
+		model = DefaultModel
+	}
+
+	return &QueryParser{
+		client: client,
+		model:  model,
+	}
+}
+
+// ParsedQuery represents the filters extracted from a search query
+type ParsedQuery struct {
+	SemanticQuery      string         `json:"semantic_query"`
+	MinYearsExperience *float64       `json:"min_years_experience"`
+	Locations          []string       `json:"locations"`
+	Languages          []LanguageInfo `json:"languages"`
+	RequiredSkills     []string       `json:"required_skills"`
+}
+
+type LanguageInfo struct {
+	Language       string `json:"language"`
+	MinProficiency string `json:"min_proficiency"` // Basic, Intermediate, Professional, Fluent, Native
+}
+
+// querySchema is the strict schema of ParsedQuery. Every property is required, so a
+// filter the query does not mention comes back empty or null rather than missing.
+var querySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"semantic_query":       map[string]any{"type": "string"},
+		"min_years_experience": map[string]any{"type": []any{"number", "null"}},
+		"locations":            map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		"languages": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"language": map[string]any{"type": "string"},
+					"min_proficiency": map[string]any{
+						"type": "string",
+						"enum": []string{"Basic", "Intermediate", "Professional", "Fluent", "Native", ""},
+					},
+				},
+				"required":             []string{"language", "min_proficiency"},
+				"additionalProperties": false,
+			},
+		},
+		"required_skills": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+	},
+	"required":             []string{"semantic_query", "min_years_experience", "locations", "languages", "required_skills"},
+	"additionalProperties": false,
+}
+
+// ParseQuery extracts the filters of a search query
+func (p *QueryParser) ParseQuery(ctx context.Context, query string) (*ParsedQuery, error) {
+	systemPrompt := `You turn recruiter search queries into structured candidate filters. Return ONLY valid JSON.`
+
+	userPrompt := `Extract the filters of this candidate search query in the following JSON structure:
+
+{
+  "semantic_query": string (what is left of the query once locations, years of experience and languages are removed; keep role, seniority and skills),
+  "min_years_experience": number or null,
+  "locations": string[] (cities or countries the candidate must be in),
+  "languages": [{
+    "language": string (in English, e.g. "English", "Spanish"),
+    "min_proficiency": string ("Basic", "Intermediate", "Professional", "Fluent", "Native" or empty)
+  }],
+  "required_skills": string[] (technologies, tools and skills the candidate must have)
+}
+
+IMPORTANT INSTRUCTIONS:
+- Only extract what the query states; never guess values that are not there
+- "5+ years", "at least 5 years" and "more than 5 years" all mean min_years_experience 5
+- Do not infer years of experience from seniority words like "senior" or "junior"
+- Spoken languages go in languages, programming languages go in required_skills
+- Use empty arrays and null for anything not mentioned
+
+Query: ` + query
+
+	completion, err := p.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(systemPrompt),
+			openai.UserMessage(userPrompt),
+		},
+		Model: p.model,
+		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &openai.ResponseFormatJSONSchemaParam{
+				JSONSchema: openai.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   "search_query",
+					Schema: querySchema,
+					Strict: openai.Bool(true),
+				},
+			},
+		},
+		Temperature: openai.Float(0), // Same query, same filters
+		MaxTokens:   openai.Int(500),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("openai chat api error: %w", err)
+	}
+
+	if len(completion.Choices) == 0 {
+		return nil, errors.New("no response from openai")
+	}
+	if refusal := completion.Choices[0].Message.Refusal; refusal != "" {
+		return nil, fmt.Errorf("query refused: %s", refusal)
+	}
+
+	var parsed ParsedQuery
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse query JSON: %w", err)
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,146 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/shared/constant"
+)
+
+// outputTokensPerCandidate is the expected answer size for one candidate (id, score and one line)
+const outputTokensPerCandidate = 60
+
+const systemPrompt = `You are an expert technical recruiter. You rate how well each candidate fits a search. Return ONLY valid JSON.`
+
+// LLMReranker reranks candidates with a chat model through the OpenAI API,
+// either from OpenAI itself or from any OpenAI-compatible server
+type LLMReranker struct {
+	client            *openai.Client
+	model             string
+	inputCostPerMTok  float64
+	outputCostPerMTok float64
+}
+
+func newLLMReranker(apiKey, baseURL, model string, inputCost, outputCost float64) *LLMReranker {
+	opts := []option.RequestOption{}
+	if apiKey != "" {
+		opts = append(opts, option.WithAPIKey(apiKey))
+	}
+	if baseURL != "" {
+		opts = append(opts, option.WithBaseURL(baseURL))
+	}
+
+	client := openai.NewClient(opts...)
+
+	return &LLMReranker{
+		client:            &client,
+		model:             model,
+		inputCostPerMTok:  inputCost,
+		outputCostPerMTok: outputCost,
+	}
+}
+
+// Name identifies the reranker and its model
+func (r *LLMReranker) Name() string {
+	return "llm:" + r.model
+}
+
+// EstimateCost returns the approximate cost in USD of reranking the candidates
+func (r *LLMReranker) EstimateCost(query string, candidates []Candidate) float64 {
+	input := estimateTokens(systemPrompt) + estimateTokens(buildPrompt(query, candidates))
+	output := outputTokensPerCandidate * len(candidates)
+	return (float64(input)*r.inputCostPerMTok + float64(output)*r.outputCostPerMTok) / 1_000_000
+}
+
+// Rerank scores each candidate's relevance to the query
+func (r *LLMReranker) Rerank(ctx context.Context, query string, candidates []Candidate) ([]Result, error) {
+	if len(candidates) == 0 {
+		return []Result{}, nil
+	}
+
+	completion, err := r.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(systemPrompt),
+			openai.UserMessage(buildPrompt(query, candidates)),
+		},
+		Model: r.model,
+		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONObject: &openai.ResponseFormatJSONObjectParam{
+				Type: constant.JSONObject("json_object"),
+			},
+		},
+		Temperature: openai.Float(0), // Same candidates, same ranking
+		MaxTokens:   openai.Int(int64(outputTokensPerCandidate*len(candidates) + 100)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("openai chat api error: %w", err)
+	}
+
+	if len(completion.Choices) == 0 {
+		return nil, errors.New("no response from openai")
+	}
+
+	var answer struct {
+		Results []struct {
+			ID            string  `json:"id"`
+			Score         float64 `json:"score"`
+			Justification string  `json:"justification"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &answer); err != nil {
+		return nil, fmt.Errorf("failed to parse rerank JSON: %w", err)
+	}
+
+	// Only keep scores for candidates that were sent, once each
+	known := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		known[c.ID] = true
+	}
+
+	results := make([]Result, 0, len(answer.Results))
+	for _, a := range answer.Results {
+		if !known[a.ID] {
+			continue
+		}
+		known[a.ID] = false
+		results = append(results, Result{
+			ID:            a.ID,
+			Score:         min(max(a.Score, 0), 100) / 100,
+			Justification: strings.TrimSpace(a.Justification),
+		})
+	}
+
+	return results, nil
+}
+
+// buildPrompt lists the candidates under the search they are rated against
+func buildPrompt(query string, candidates []Candidate) string {
+	var b strings.Builder
+	b.WriteString("Rate how relevant each candidate is to this search:\n\n")
+	b.WriteString(query)
+	b.WriteString("\n\nCandidates:\n")
+	for _, c := range candidates {
+		fmt.Fprintf(&b, "\n[id: %s]\n%s\n", c.ID, c.Text)
+	}
+	b.WriteString(`
+Return JSON in this structure:
+
+{
+  "results": [{
+    "id": string (the candidate id),
+    "score": number (0-100, how well the candidate fits the search),
+    "justification": string (one short sentence explaining the score)
+  }]
+}
+
+IMPORTANT INSTRUCTIONS:
+- Rate every candidate exactly once
+- Judge only the skills, experience, education and languages shown; ignore anything else
+- Keep each justification to one line`)
+	return b.String()
+}
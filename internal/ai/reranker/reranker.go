@@ -0,0 +1,93 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Reranker scores search candidates against a query with a stronger model than the first-pass retrieval
+type Reranker interface {
+	// Rerank scores each candidate's relevance to the query. Candidates the model
+	// did not score are left out of the results.
+	Rerank(ctx context.Context, query string, candidates []Candidate) ([]Result, error)
+
+	// EstimateCost returns the approximate cost in USD of reranking the candidates
+	EstimateCost(query string, candidates []Candidate) float64
+
+	// Name identifies the reranker and its model
+	Name() string
+}
+
+// Candidate is a search hit to rerank
+type Candidate struct {
+	ID   string
+	Text string
+}
+
+// Result is the relevance of a candidate
+type Result struct {
+	ID            string
+	Score         float64 // 0-1
+	Justification string  // One line
+}
+
+// Provider identifies a reranking backend
+type Provider string
+
+const (
+	ProviderOpenAI           Provider = "openai"            // OpenAI chat completions
+	ProviderOpenAICompatible Provider = "openai-compatible" // Any server exposing the OpenAI chat API
+)
+
+const (
+	DefaultModel = "gpt-4o-mini"
+
+	// Prices of DefaultModel in USD per million tokens
+	DefaultInputCostPerMTok  = 0.15
+	DefaultOutputCostPerMTok = 0.60
+)
+
+// Config selects and configures a reranker
+type Config struct {
+	Provider          Provider
+	APIKey            string
+	BaseURL           string // Required for openai-compatible
+	Model             string
+	InputCostPerMTok  float64
+	OutputCostPerMTok float64
+}
+
+// NewReranker creates the reranker selected by the config
+func NewReranker(cfg Config) (Reranker, error) {
+	switch cfg.Provider {
+	case ProviderOpenAI, "":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("openai reranker requires an API key")
+		}
+		if cfg.Model == "" {
+			cfg.Model = DefaultModel
+			if cfg.InputCostPerMTok == 0 && cfg.OutputCostPerMTok == 0 {
+				cfg.InputCostPerMTok = DefaultInputCostPerMTok
+				cfg.OutputCostPerMTok = DefaultOutputCostPerMTok
+			}
+		}
+		return newLLMReranker(cfg.APIKey, "", cfg.Model, cfg.InputCostPerMTok, cfg.OutputCostPerMTok), nil
+
+	case ProviderOpenAICompatible:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("openai-compatible reranker requires a base URL")
+		}
+		if cfg.Model == "" {
+			return nil, fmt.Errorf("openai-compatible reranker requires a model")
+		}
+		return newLLMReranker(cfg.APIKey, cfg.BaseURL, cfg.Model, cfg.InputCostPerMTok, cfg.OutputCostPerMTok), nil
+
+	default:
+		return nil, fmt.Errorf("unknown reranker provider: %s", cfg.Provider)
+	}
+}
+
+// estimateTokens approximates the token count of text (about 4 characters per token)
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,71 @@
+package resumeparser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// FailureReason says why a resume could not be parsed
+type FailureReason string
+
+const (
+	FailureUnavailable     FailureReason = "api_unavailable"    // Network errors, timeouts and server errors
+	FailureRateLimited     FailureReason = "rate_limited"       // Too many requests or tokens for the API key
+	FailureRejected        FailureReason = "request_rejected"   // The API refused the request itself: invalid image, too large, bad credentials
+	FailureRefused         FailureReason = "refused"            // The model declined to extract the resume
+	FailureContentFiltered FailureReason = "content_filtered"   // The response was stopped by the content filter
+	FailureTruncated       FailureReason = "response_truncated" // The resume did not fit in the response tokens
+	FailureInvalidResponse FailureReason = "invalid_response"   // No choices, or JSON that does not decode despite the schema
+	FailureNoPages         FailureReason = "no_pages"
+)
+
+// Transient reports whether the same request may succeed if sent again later
+func (r FailureReason) Transient() bool {
+	switch r {
+	case FailureUnavailable, FailureRateLimited, FailureInvalidResponse:
+		return true
+	}
+	return false
+}
+
+// ParseError is returned by the parser for every failed parse, with the reason it failed
+type ParseError struct {
+	Reason FailureReason
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Transient reports whether retrying the parse may succeed
+func (e *ParseError) Transient() bool {
+	return e.Reason.Transient()
+}
+
+// classifyAPIError wraps an error of the OpenAI client with its failure reason
+func classifyAPIError(err error) *ParseError {
+	wrapped := fmt.Errorf("openai api error: %w", err)
+
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return &ParseError{Reason: FailureRateLimited, Err: wrapped}
+		case apiErr.StatusCode == http.StatusRequestTimeout, apiErr.StatusCode == http.StatusConflict, apiErr.StatusCode >= 500:
+			return &ParseError{Reason: FailureUnavailable, Err: wrapped}
+		default:
+			return &ParseError{Reason: FailureRejected, Err: wrapped}
+		}
+	}
+
+	// Anything that never got an answer from the API: connection errors, timeouts, cancellation
+	return &ParseError{Reason: FailureUnavailable, Err: wrapped}
+}
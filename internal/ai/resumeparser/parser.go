@@ -6,9 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/openai/openai-go/v3"
-	"github.com/openai/openai-go/v3/option"
 	"github.com/openai/openai-go/v3/shared/constant"
 )
 
@@ -17,46 +18,121 @@ type ResumeParser struct {
 	client *openai.Client
 }
 
-// NewResumeParser creates a new resume parser
-func NewResumeParser(apiKey string) *ResumeParser {
-	client := openai.NewClient(
-		option.WithAPIKey(apiKey),
-	)
-
+// NewResumeParser creates a resume parser on a shared OpenAI client
+func NewResumeParser(client *openai.Client) *ResumeParser {
 	return &ResumeParser{
-		client: &client,
+		client: client,
 	}
 }
 
 // ResumeData represents structured resume information
 type ResumeData struct {
-	PersonalInfo      PersonalInfo      `json:"personal_info"`
-	Summary           string            `json:"summary"`
-	HardSkills        []SkillDetail     `json:"hard_skills"`
-	SoftSkills        []SkillDetail     `json:"soft_skills"`
-	Experience        []Experience      `json:"experience"`
-	Education         []Education       `json:"education"`
-	Languages         []LanguageInfo    `json:"languages,omitempty"`
-	Certifications    []string          `json:"certifications,omitempty"`
-	PersonalStatement PersonalStatement `json:"personal_statement,omitempty"`
+	PersonalInfo      PersonalInfo          `json:"personal_info"`
+	Summary           string                `json:"summary"`
+	HardSkills        []SkillDetail         `json:"hard_skills"`
+	SoftSkills        []SkillDetail         `json:"soft_skills"`
+	Experience        []Experience          `json:"experience"`
+	Education         []Education           `json:"education"`
+	Languages         []LanguageInfo        `json:"languages,omitempty"`
+	Certifications    []Certification       `json:"certifications,omitempty"`
+	Projects          []Project             `json:"projects,omitempty"`
+	VolunteerWork     []VolunteerExperience `json:"volunteer_work,omitempty"`
+	Achievements      []string              `json:"achievements,omitempty"` // Awards and honors not tied to a job
+	PersonalStatement PersonalStatement     `json:"personal_statement,omitempty"`
+	Fields            FieldSources          `json:"fields,omitempty"` // By field path, e.g. "personal_info.email" or "experience[0]"
+}
+
+// FieldSource is how sure the model was of an extracted field and where it found it
+type FieldSource struct {
+	Confidence float64 `json:"confidence"`     // 0 (guessed) to 1 (printed clearly)
+	Page       int     `json:"page,omitempty"` // 1-based, 0 when unknown
+}
+
+// FieldSources holds the source of each extracted field by its path. The model returns
+// it as a list of sources with their paths, since strict schemas cannot describe an
+// object with arbitrary keys.
+type FieldSources map[string]FieldSource
+
+// UnmarshalJSON accepts the list the model returns as well as the object it is stored as
+func (f *FieldSources) UnmarshalJSON(data []byte) error {
+	var list []struct {
+		Path string `json:"path"`
+		FieldSource
+	}
+	if err := json.Unmarshal(data, &list); err == nil {
+		*f = make(FieldSources, len(list))
+		for _, source := range list {
+			(*f)[source.Path] = source.FieldSource
+		}
+		return nil
+	}
+
+	var sources map[string]FieldSource
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return err
+	}
+	*f = sources
+	return nil
+}
+
+func (FieldSources) jsonSchema() any {
+	source := schemaFor(reflect.TypeFor[FieldSource]()).(*objectSchema)
+	item := &objectSchema{
+		Properties: append([]property{{Name: "path", Schema: map[string]any{"type": "string"}}}, source.Properties...),
+	}
+	return map[string]any{"type": "array", "items": item}
 }
 
 type PersonalInfo struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	Location string `json:"location"`
-	LinkedIn string `json:"linkedin,omitempty"`
+	Name      string       `json:"name"`
+	Email     string       `json:"email"`
+	Phone     string       `json:"phone"`
+	Location  LocationInfo `json:"location"`
+	LinkedIn  string       `json:"linkedin,omitempty"`
+	GitHub    string       `json:"github,omitempty"`
+	Portfolio string       `json:"portfolio,omitempty"`
+	Website   string       `json:"website,omitempty"`
+}
+
+type LocationInfo struct {
+	City    string `json:"city"`
+	State   string `json:"state,omitempty"`
+	Country string `json:"country"`
+	ZipCode string `json:"zip_code,omitempty"`
+}
+
+// UnmarshalJSON also accepts the location as a single string, which is kept as the city
+func (l *LocationInfo) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*l = LocationInfo{City: text}
+		return nil
+	}
+
+	type location LocationInfo
+	return json.Unmarshal(data, (*location)(l))
+}
+
+// String joins the known parts of the location
+func (l LocationInfo) String() string {
+	parts := []string{}
+	for _, part := range []string{l.City, l.State, l.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return joinStrings(parts, ", ")
 }
 
 type SkillDetail struct {
 	Name             string `json:"name"`
-	ProficiencyLevel string `json:"proficiency_level,omitempty"` // Beginner, Intermediate, Advanced, Expert
+	ProficiencyLevel string `json:"proficiency_level,omitempty" enum:"Beginner,Intermediate,Advanced,Expert"`
+	YearsExperience  *int   `json:"years_experience,omitempty"`
 }
 
 type LanguageInfo struct {
 	Language    string `json:"language"`
-	Proficiency string `json:"proficiency"` // Native, Fluent, Professional, Intermediate, Basic
+	Proficiency string `json:"proficiency" enum:"Native,Fluent,Professional,Intermediate,Basic"`
 }
 
 type Experience struct {
@@ -65,14 +141,60 @@ type Experience struct {
 	StartDate        string   `json:"start_date"` // YYYY-MM format
 	EndDate          string   `json:"end_date"`   // YYYY-MM or "Present"
 	Responsibilities []string `json:"responsibilities"`
+	Location         string   `json:"location,omitempty"`
+	EmploymentType   string   `json:"employment_type,omitempty" enum:"Full-time,Part-time,Contract,Internship,Freelance"`
+	Industry         string   `json:"industry,omitempty"`
+	SkillsUsed       []string `json:"skills_used,omitempty"`
 }
 
 type Education struct {
-	Institution    string `json:"institution"`
-	Degree         string `json:"degree"`
-	Field          string `json:"field"`
-	GraduationDate string `json:"graduation_date"` // YYYY-MM format
-	GPA            string `json:"gpa,omitempty"`
+	Institution    string   `json:"institution"`
+	Degree         string   `json:"degree"`
+	Field          string   `json:"field"`
+	GraduationDate string   `json:"graduation_date"` // YYYY-MM format
+	GPA            string   `json:"gpa,omitempty"`
+	Honors         []string `json:"honors,omitempty"`
+	Coursework     []string `json:"coursework,omitempty"`
+}
+
+type Certification struct {
+	Name           string `json:"name"`
+	Issuer         string `json:"issuer,omitempty"`
+	IssueDate      string `json:"issue_date,omitempty"`      // YYYY-MM format
+	ExpirationDate string `json:"expiration_date,omitempty"` // YYYY-MM format
+	CredentialID   string `json:"credential_id,omitempty"`
+	CredentialURL  string `json:"credential_url,omitempty"`
+}
+
+// UnmarshalJSON also accepts a certification given only by its name
+func (c *Certification) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*c = Certification{Name: name}
+		return nil
+	}
+
+	type certification Certification
+	return json.Unmarshal(data, (*certification)(c))
+}
+
+type Project struct {
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	Role         string   `json:"role,omitempty"`
+	Technologies []string `json:"technologies,omitempty"`
+	Duration     string   `json:"duration,omitempty"` // As written, e.g. "2022-03 to 2022-09" or "6 months"
+	URL          string   `json:"url,omitempty"`
+	Outcomes     []string `json:"outcomes,omitempty"`
+}
+
+type VolunteerExperience struct {
+	Organization string   `json:"organization"`
+	Role         string   `json:"role"`
+	StartDate    string   `json:"start_date"` // YYYY-MM format
+	EndDate      string   `json:"end_date"`   // YYYY-MM or "Present"
+	Description  string   `json:"description,omitempty"`
+	Achievements []string `json:"achievements,omitempty"`
 }
 
 // PersonalStatement represents personal statement or cover letter content
@@ -100,13 +222,22 @@ func (p *ResumeParser) ParseResumeFromImage(ctx context.Context, imageData []byt
     "name": string,
     "email": string,
     "phone": string,
-    "location": string,
-    "linkedin": string (optional)
+    "location": {
+      "city": string,
+      "state": string (optional),
+      "country": string,
+      "zip_code": string (optional)
+    },
+    "linkedin": string (optional),
+    "github": string (optional),
+    "portfolio": string (optional),
+    "website": string (optional - personal website or blog)
   },
   "summary": string (professional summary, max 250 words),
   "hard_skills": [{
     "name": string,
-    "proficiency_level": string (optional: "Beginner", "Intermediate", "Advanced", "Expert")
+    "proficiency_level": string (optional: "Beginner", "Intermediate", "Advanced", "Expert"),
+    "years_experience": number (optional - only when stated or clear from the dates of the jobs using it)
   }],
   "soft_skills": [{
     "name": string,
@@ -117,20 +248,51 @@ func (p *ResumeParser) ParseResumeFromImage(ctx context.Context, imageData []byt
     "title": string,
     "start_date": string (YYYY-MM format),
     "end_date": string (YYYY-MM or "Present"),
-    "responsibilities": string[] (key achievements and duties)
+    "responsibilities": string[] (key achievements and duties),
+    "location": string (optional),
+    "employment_type": string (optional: "Full-time", "Part-time", "Contract", "Internship", "Freelance"),
+    "industry": string (optional - industry of the company, e.g. "Fintech", "Healthcare", "Retail"),
+    "skills_used": string[] (optional - skills and tools mentioned for this job)
   }],
   "education": [{
     "institution": string,
     "degree": string,
     "field": string,
     "graduation_date": string (YYYY-MM format),
-    "gpa": string (optional)
+    "gpa": string (optional),
+    "honors": string[] (optional - e.g. "Cum Laude", "Dean's List"),
+    "coursework": string[] (optional - relevant courses listed)
   }],
   "languages": [{
     "language": string,
     "proficiency": string ("Native", "Fluent", "Professional", "Intermediate", "Basic")
   }],
-  "certifications": string[] (optional),
+  "certifications": [{
+    "name": string,
+    "issuer": string (optional - issuing organization),
+    "issue_date": string (optional, YYYY-MM format),
+    "expiration_date": string (optional, YYYY-MM format),
+    "credential_id": string (optional),
+    "credential_url": string (optional)
+  }],
+  "projects": [{
+    "title": string,
+    "description": string,
+    "role": string (optional - candidate's role in the project),
+    "technologies": string[] (optional),
+    "duration": string (optional - as written, e.g. "2022-03 to 2022-09" or "6 months"),
+    "url": string (optional),
+    "outcomes": string[] (optional - results and impact)
+  }],
+  "volunteer_work": [{
+    "organization": string,
+    "role": string,
+    "start_date": string (YYYY-MM format),
+    "end_date": string (YYYY-MM or "Present"),
+    "description": string (optional),
+    "achievements": string[] (optional)
+  }],
+  "achievements": string[] (optional - awards, honors, publications and competitions not tied to a single job),
   "personal_statement": {
     "why_this_company": string (optional - explains why candidate wants to work at this specific company),
     "why_this_role": string (optional - explains interest in this specific position/role),
@@ -144,11 +306,15 @@ IMPORTANT INSTRUCTIONS:
 - **hard_skills**: Technical, programming, tools, frameworks, software, platforms (e.g., Python, AWS, Docker, SQL, Photoshop, JavaScript, Kubernetes)
 - **soft_skills**: Interpersonal, leadership, communication, teamwork (e.g., Leadership, Communication, Problem Solving, Team Collaboration)
 - **personal_statement**: Look for sections titled "Cover Letter", "Personal Statement", "Why [Company Name]", "Career Objective", "About Me", "Professional Goal", or any narrative/essay text explaining motivation, fit, or aspirations
+- **projects**: Personal, academic, open-source or client projects listed in their own section; projects described inside a job stay in that job's responsibilities
+- **volunteer_work**: Unpaid, community or non-profit roles; do not repeat them under experience
 - Extract ALL visible text accurately
-- If a field is not available, omit it or use empty string/array
+- If a field is not available, use an empty string or array (null for numbers)
 - Maintain chronological order (newest first)
 - Return ONLY the JSON, no explanatory text before or after
-- Be thorough and precise`
+- Be thorough and precise
+
+` + fieldSourceInstructions
 
 	// Build messages with vision content
 	messages := []openai.ChatCompletionMessageParamUnion{
@@ -178,65 +344,31 @@ IMPORTANT INSTRUCTIONS:
 		},
 	}
 
-	// API call with JSON response format
-	completion, err := p.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-		Messages: messages,
-		Model:    "gpt-4o", // GPT-4o has best vision capabilities
-		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
-			OfJSONObject: &openai.ResponseFormatJSONObjectParam{
-				Type: constant.JSONObject("json_object"),
-			},
-		},
-		Temperature: openai.Float(0.1), // Low temperature for consistency
-		MaxTokens:   openai.Int(4000),
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("openai vision api error: %w", err)
-	}
-
-	if len(completion.Choices) == 0 {
-		return nil, errors.New("no response from openai")
-	}
-
-	// Parse JSON response
-	content := completion.Choices[0].Message.Content
-	var resumeData ResumeData
-	if err := json.Unmarshal([]byte(content), &resumeData); err != nil {
-		return nil, fmt.Errorf("failed to parse resume JSON: %w", err)
-	}
-
-	return &resumeData, nil
+	return p.extract(ctx, messages, 6000, 1)
 }
 
-// ParseResumeFromMultiplePages parses a multi-page resume
-func (p *ResumeParser) ParseResumeFromMultiplePages(ctx context.Context, pages [][]byte) (*ResumeData, error) {
-	if len(pages) == 0 {
-		return nil, errors.New("no pages provided")
-	}
-
-	// For single page, use standard parsing
-	if len(pages) == 1 {
-		return p.ParseResumeFromImage(ctx, pages[0])
-	}
-
-	// For multiple pages, send all images together
-	systemPrompt := `You are a professional resume parser. This is a multi-page resume. Extract ALL information from ALL pages and return ONLY valid JSON.`
-
-	userPrompt := `Extract all information from this multi-page resume in the following JSON structure:
-
-{
+// multiPageInstructions is the JSON structure and extraction rules for resumes sent page by page
+const multiPageInstructions = `{
   "personal_info": {
     "name": string,
     "email": string,
     "phone": string,
-    "location": string,
-    "linkedin": string (optional)
+    "location": {
+      "city": string,
+      "state": string (optional),
+      "country": string,
+      "zip_code": string (optional)
+    },
+    "linkedin": string (optional),
+    "github": string (optional),
+    "portfolio": string (optional),
+    "website": string (optional)
   },
   "summary": string,
   "hard_skills": [{
     "name": string,
-    "proficiency_level": string (optional: "Beginner", "Intermediate", "Advanced", "Expert")
+    "proficiency_level": string (optional: "Beginner", "Intermediate", "Advanced", "Expert"),
+    "years_experience": number (optional)
   }],
   "soft_skills": [{
     "name": string,
@@ -247,20 +379,51 @@ func (p *ResumeParser) ParseResumeFromMultiplePages(ctx context.Context, pages [
     "title": string,
     "start_date": string (YYYY-MM),
     "end_date": string (YYYY-MM or "Present"),
-    "responsibilities": string[]
+    "responsibilities": string[],
+    "location": string (optional),
+    "employment_type": string (optional: "Full-time", "Part-time", "Contract", "Internship", "Freelance"),
+    "industry": string (optional),
+    "skills_used": string[] (optional)
   }],
   "education": [{
     "institution": string,
     "degree": string,
     "field": string,
     "graduation_date": string (YYYY-MM),
-    "gpa": string (optional)
+    "gpa": string (optional),
+    "honors": string[] (optional),
+    "coursework": string[] (optional)
   }],
   "languages": [{
     "language": string,
     "proficiency": string
   }],
-  "certifications": string[],
+  "certifications": [{
+    "name": string,
+    "issuer": string (optional),
+    "issue_date": string (optional, YYYY-MM),
+    "expiration_date": string (optional, YYYY-MM),
+    "credential_id": string (optional),
+    "credential_url": string (optional)
+  }],
+  "projects": [{
+    "title": string,
+    "description": string,
+    "role": string (optional),
+    "technologies": string[] (optional),
+    "duration": string (optional, as written),
+    "url": string (optional),
+    "outcomes": string[] (optional)
+  }],
+  "volunteer_work": [{
+    "organization": string,
+    "role": string,
+    "start_date": string (YYYY-MM),
+    "end_date": string (YYYY-MM or "Present"),
+    "description": string (optional),
+    "achievements": string[] (optional)
+  }],
+  "achievements": string[] (optional),
   "personal_statement": {
     "why_this_company": string (optional),
     "why_this_role": string (optional),
@@ -274,9 +437,45 @@ IMPORTANT:
 - **hard_skills**: Technical/measurable skills (programming, tools, software, frameworks)
 - **soft_skills**: Interpersonal skills (leadership, communication, teamwork)
 - **personal_statement**: Extract any cover letter, personal statement, career objectives, or narrative text from any page
+- **skills_used**: Skills and tools mentioned within each job, in addition to the skills sections
+- **projects**: Projects listed in their own section; projects described inside a job stay in that job's responsibilities
+- **volunteer_work**: Unpaid, community or non-profit roles; do not repeat them under experience
+- **achievements**: Awards, honors, publications and competitions not tied to a single job
 - Combine information from all pages into a single coherent response
 - If personal statement spans multiple pages, combine it into the essay field
-- Return ONLY JSON`
+- Return ONLY JSON
+
+` + fieldSourceInstructions
+
+// fieldSourceInstructions asks for the confidence and source page of every extracted field
+const fieldSourceInstructions = `Also include a "fields" list that says how sure you are of each extracted field and which page it came from:
+
+"fields": [
+  {"path": string (field path), "confidence": number (0 to 1), "page": number (1-based)}
+]
+
+Field paths: "personal_info.name", "personal_info.email", "personal_info.phone", "personal_info.location", "personal_info.linkedin", "summary", "hard_skills", "soft_skills", "experience[i]", "education[i]", "projects[i]" and "volunteer_work[i]" (one per entry, i counting from 0 in the order returned), "languages", "certifications", "achievements", "personal_statement".
+- Include every path you returned a value for, and no others
+- confidence 0.9 or more: printed clearly and unambiguous
+- confidence 0.5 to 0.9: partly legible, split across columns or pages, or reformatted (e.g. dates normalized from text)
+- confidence below 0.5: guessed or inferred from context rather than read
+- page: the page the value appears on; the first one when it spans pages`
+
+// ParseResumeFromMultiplePages parses a multi-page resume
+func (p *ResumeParser) ParseResumeFromMultiplePages(ctx context.Context, pages [][]byte) (*ResumeData, error) {
+	if len(pages) == 0 {
+		return nil, &ParseError{Reason: FailureNoPages, Err: errors.New("no pages provided")}
+	}
+
+	// For single page, use standard parsing
+	if len(pages) == 1 {
+		return p.ParseResumeFromImage(ctx, pages[0])
+	}
+
+	// For multiple pages, send all images together
+	systemPrompt := `You are a professional resume parser. This is a multi-page resume. Extract ALL information from ALL pages and return ONLY valid JSON.`
+
+	userPrompt := "Extract all information from this multi-page resume in the following JSON structure:\n\n" + multiPageInstructions
 
 	// Build content parts with all pages
 	contentParts := []openai.ChatCompletionContentPartUnionParam{
@@ -325,33 +524,197 @@ IMPORTANT:
 		},
 	}
 
+	return p.extract(ctx, messages, 8000, len(pages))
+}
+
+// PageContent is one resume page: its extracted text, or a JPEG image of the
+// page when it has no usable text layer
+type PageContent struct {
+	Text  string
+	Image []byte
+}
+
+// ParseResumeFromPages parses a resume from extracted page text, sending images only
+// for the pages that need them. Text is far cheaper than high-detail images and is
+// read without OCR mistakes.
+func (p *ResumeParser) ParseResumeFromPages(ctx context.Context, pages []PageContent) (*ResumeData, error) {
+	if len(pages) == 0 {
+		return nil, &ParseError{Reason: FailureNoPages, Err: errors.New("no pages provided")}
+	}
+
+	systemPrompt := `You are a professional resume parser. The resume is given page by page, as text extracted from the document or as an image of pages without extractable text. Extract ALL information from ALL pages and return ONLY valid JSON.`
+
+	userPrompt := "Extract all information from this resume in the following JSON structure:\n\n" + multiPageInstructions
+
+	contentParts := []openai.ChatCompletionContentPartUnionParam{
+		{
+			OfText: &openai.ChatCompletionContentPartTextParam{
+				Type: constant.Text("text"),
+				Text: userPrompt,
+			},
+		},
+	}
+
+	for i, page := range pages {
+		if page.Image == nil {
+			contentParts = append(contentParts, openai.ChatCompletionContentPartUnionParam{
+				OfText: &openai.ChatCompletionContentPartTextParam{
+					Type: constant.Text("text"),
+					Text: fmt.Sprintf("--- Page %d (extracted text) ---\n%s", i+1, page.Text),
+				},
+			})
+			continue
+		}
+
+		base64Image := base64.StdEncoding.EncodeToString(page.Image)
+		contentParts = append(contentParts,
+			openai.ChatCompletionContentPartUnionParam{
+				OfText: &openai.ChatCompletionContentPartTextParam{
+					Type: constant.Text("text"),
+					Text: fmt.Sprintf("--- Page %d (image) ---", i+1),
+				},
+			},
+			openai.ChatCompletionContentPartUnionParam{
+				OfImageURL: &openai.ChatCompletionContentPartImageParam{
+					Type: constant.ImageURL("image_url"),
+					ImageURL: openai.ChatCompletionContentPartImageImageURLParam{
+						URL:    fmt.Sprintf("data:image/jpeg;base64,%s", base64Image),
+						Detail: "high",
+					},
+				},
+			},
+		)
+	}
+
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage(systemPrompt),
+		{
+			OfUser: &openai.ChatCompletionUserMessageParam{
+				Content: openai.ChatCompletionUserMessageParamContentUnion{
+					OfArrayOfContentParts: contentParts,
+				},
+			},
+		},
+	}
+
+	return p.extract(ctx, messages, 8000, len(pages))
+}
+
+// extract sends a parse request whose response must follow the ResumeData schema,
+// decodes it and repairs the fields that fail validation. Every error is a *ParseError.
+func (p *ResumeParser) extract(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion, maxTokens int64, pages int) (*ResumeData, error) {
+	content, err := p.complete(ctx, messages, "resume", resumeSchema(), maxTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	var resumeData ResumeData
+	if err := json.Unmarshal([]byte(content), &resumeData); err != nil {
+		return nil, &ParseError{Reason: FailureInvalidResponse, Err: fmt.Errorf("failed to parse resume JSON: %w", err)}
+	}
+
+	resumeData.normalizeFields(pages)
+
+	if problems := resumeData.validate(); len(problems) > 0 {
+		p.repair(ctx, messages, content, &resumeData, problems)
+	}
+
+	return &resumeData, nil
+}
+
+// complete runs a chat completion constrained to a strict JSON schema and returns its content
+func (p *ResumeParser) complete(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion, name string, schema any, maxTokens int64) (string, error) {
 	completion, err := p.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
 		Messages: messages,
-		Model:    "gpt-4o",
+		Model:    "gpt-4o", // GPT-4o has best vision capabilities
 		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
-			OfJSONObject: &openai.ResponseFormatJSONObjectParam{
-				Type: constant.JSONObject("json_object"),
+			OfJSONSchema: &openai.ResponseFormatJSONSchemaParam{
+				JSONSchema: openai.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   name,
+					Schema: schema,
+					Strict: openai.Bool(true),
+				},
 			},
 		},
-		Temperature: openai.Float(0.1),
-		MaxTokens:   openai.Int(6000),
+		Temperature: openai.Float(0.1), // Low temperature for consistency
+		MaxTokens:   openai.Int(maxTokens),
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("openai vision api error: %w", err)
+		return "", classifyAPIError(err)
 	}
 
 	if len(completion.Choices) == 0 {
-		return nil, errors.New("no response from openai")
+		return "", &ParseError{Reason: FailureInvalidResponse, Err: errors.New("no response from openai")}
 	}
 
-	content := completion.Choices[0].Message.Content
-	var resumeData ResumeData
-	if err := json.Unmarshal([]byte(content), &resumeData); err != nil {
-		return nil, fmt.Errorf("failed to parse resume JSON: %w", err)
+	choice := completion.Choices[0]
+	switch {
+	case choice.Message.Refusal != "":
+		return "", &ParseError{Reason: FailureRefused, Err: errors.New(choice.Message.Refusal)}
+	case choice.FinishReason == "length":
+		return "", &ParseError{Reason: FailureTruncated, Err: fmt.Errorf("response exceeded %d tokens", maxTokens)}
+	case choice.FinishReason == "content_filter":
+		return "", &ParseError{Reason: FailureContentFiltered, Err: errors.New("response stopped by the content filter")}
 	}
 
-	return &resumeData, nil
+	return choice.Message.Content, nil
+}
+
+// normalizeFields clamps confidences to [0, 1] and drops page numbers outside the document
+func (rd *ResumeData) normalizeFields(pages int) {
+	for path, source := range rd.Fields {
+		source.Confidence = min(max(source.Confidence, 0), 1)
+		if source.Page < 1 || source.Page > pages {
+			source.Page = 0
+		}
+		rd.Fields[path] = source
+	}
+}
+
+// UnreportedFields returns the paths of the extracted fields the model gave no source
+// for, neither for the field itself nor for its whole section
+func (rd *ResumeData) UnreportedFields() []string {
+	var paths []string
+	check := func(path string, extracted bool) {
+		if !extracted {
+			return
+		}
+		if _, ok := rd.Fields[path]; ok {
+			return
+		}
+		if section, _, isEntry := strings.Cut(path, "["); isEntry {
+			if _, ok := rd.Fields[section]; ok {
+				return
+			}
+		}
+		paths = append(paths, path)
+	}
+	entries := func(section string, n int) {
+		for i := range n {
+			check(fmt.Sprintf("%s[%d]", section, i), true)
+		}
+	}
+
+	info := rd.PersonalInfo
+	check("personal_info.name", info.Name != "")
+	check("personal_info.email", info.Email != "")
+	check("personal_info.phone", info.Phone != "")
+	check("personal_info.location", info.Location != LocationInfo{})
+	check("personal_info.linkedin", info.LinkedIn != "")
+	check("summary", rd.Summary != "")
+	check("hard_skills", len(rd.HardSkills) > 0)
+	check("soft_skills", len(rd.SoftSkills) > 0)
+	entries("experience", len(rd.Experience))
+	entries("education", len(rd.Education))
+	entries("projects", len(rd.Projects))
+	entries("volunteer_work", len(rd.VolunteerWork))
+	check("languages", len(rd.Languages) > 0)
+	check("certifications", len(rd.Certifications) > 0)
+	check("achievements", len(rd.Achievements) > 0)
+	check("personal_statement", rd.PersonalStatement != PersonalStatement{})
+
+	return paths
 }
 
 // FormatResumeForEmbedding creates a text representation for embedding
@@ -363,8 +726,8 @@ func (rd *ResumeData) FormatResumeForEmbedding() string {
 	if rd.PersonalInfo.Email != "" {
 		text += fmt.Sprintf("Email: %s\n", rd.PersonalInfo.Email)
 	}
-	if rd.PersonalInfo.Location != "" {
-		text += fmt.Sprintf("Location: %s\n", rd.PersonalInfo.Location)
+	if location := rd.PersonalInfo.Location.String(); location != "" {
+		text += fmt.Sprintf("Location: %s\n", location)
 	}
 
 	// Summary
@@ -413,7 +776,19 @@ func (rd *ResumeData) FormatResumeForEmbedding() string {
 
 	// Certifications
 	if len(rd.Certifications) > 0 {
-		text += fmt.Sprintf("\nCertifications: %s\n", joinStrings(rd.Certifications, ", "))
+		certNames := make([]string, len(rd.Certifications))
+		for i, cert := range rd.Certifications {
+			certNames[i] = cert.Name
+		}
+		text += fmt.Sprintf("\nCertifications: %s\n", joinStrings(certNames, ", "))
+	}
+
+	// Projects
+	if len(rd.Projects) > 0 {
+		text += "\nProjects:\n"
+		for _, project := range rd.Projects {
+			text += fmt.Sprintf("- %s: %s\n", project.Title, project.Description)
+		}
 	}
 
 	// Languages
@@ -0,0 +1,166 @@
+package resumeparser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Abraxas-365/relay/internal/normalize"
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/openai/openai-go/v3"
+)
+
+// canonicalDate is a date as the prompts ask for it: YYYY-MM, or YYYY when only the year is given
+var canonicalDate = regexp.MustCompile(`^\d{4}(-(0[1-9]|1[0-2]))?$`)
+
+// fieldProblem is an extracted value that failed validation
+type fieldProblem struct {
+	Path   string // e.g. "experience[0].start_date"
+	Value  string
+	Reason string
+	target *string
+}
+
+// validate checks the extracted values the schema cannot constrain. Values that can be
+// fixed without the model, such as dates written as "March 2020", are rewritten in place;
+// the others are returned to be repaired.
+func (rd *ResumeData) validate() []fieldProblem {
+	var problems []fieldProblem
+
+	if strings.TrimSpace(rd.PersonalInfo.Name) == "" {
+		problems = append(problems, fieldProblem{
+			Path: "personal_info.name", Reason: "missing", target: &rd.PersonalInfo.Name,
+		})
+	}
+	if email := rd.PersonalInfo.Email; email != "" {
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			problems = append(problems, fieldProblem{
+				Path: "personal_info.email", Value: email, Reason: "not a valid email address", target: &rd.PersonalInfo.Email,
+			})
+		}
+	}
+
+	date := func(path string, value *string, ongoing bool) {
+		if *value == "" || canonicalDate.MatchString(*value) || (ongoing && *value == normalize.Present) {
+			return
+		}
+		if parsed, ok := normalize.ParseDate(*value); ok && (ongoing || parsed != normalize.Present) {
+			*value = parsed
+			return
+		}
+		reason := "not a YYYY-MM date"
+		if ongoing {
+			reason = `not a YYYY-MM date or "Present"`
+		}
+		problems = append(problems, fieldProblem{Path: path, Value: *value, Reason: reason, target: value})
+	}
+
+	for i := range rd.Experience {
+		date(fmt.Sprintf("experience[%d].start_date", i), &rd.Experience[i].StartDate, false)
+		date(fmt.Sprintf("experience[%d].end_date", i), &rd.Experience[i].EndDate, true)
+	}
+	for i := range rd.Education {
+		date(fmt.Sprintf("education[%d].graduation_date", i), &rd.Education[i].GraduationDate, false)
+	}
+	for i := range rd.Certifications {
+		date(fmt.Sprintf("certifications[%d].issue_date", i), &rd.Certifications[i].IssueDate, false)
+		date(fmt.Sprintf("certifications[%d].expiration_date", i), &rd.Certifications[i].ExpirationDate, false)
+	}
+	for i := range rd.VolunteerWork {
+		date(fmt.Sprintf("volunteer_work[%d].start_date", i), &rd.VolunteerWork[i].StartDate, false)
+		date(fmt.Sprintf("volunteer_work[%d].end_date", i), &rd.VolunteerWork[i].EndDate, true)
+	}
+
+	// Years of experience out of range are dropped rather than asked for again
+	for _, skills := range [][]SkillDetail{rd.HardSkills, rd.SoftSkills} {
+		for i := range skills {
+			if years := skills[i].YearsExperience; years != nil && (*years < 0 || *years > 70) {
+				skills[i].YearsExperience = nil
+			}
+		}
+	}
+
+	return problems
+}
+
+// repairResponse is the answer to a repair request: a corrected value per invalid field
+type repairResponse struct {
+	Fixes []fieldFix `json:"fixes"`
+}
+
+type fieldFix struct {
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+var repairSchema = sync.OnceValue(func() any {
+	return schemaFor(reflect.TypeFor[repairResponse]())
+})
+
+// repair asks the model again about the invalid fields only, following up on the
+// original request and its answer. Values still invalid afterwards, or left as they were
+// when the repair request fails, are kept with no confidence so that the resume is
+// flagged for review.
+func (p *ResumeParser) repair(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion, answer string, rd *ResumeData, problems []fieldProblem) {
+	var sb strings.Builder
+	sb.WriteString("Some fields of your answer are invalid. Read the resume again and return a corrected value for each of these fields, and for no others:\n")
+	for _, problem := range problems {
+		fmt.Fprintf(&sb, "- %s: %q (%s)\n", problem.Path, problem.Value, problem.Reason)
+	}
+	sb.WriteString(`
+Dates are YYYY-MM, or YYYY when the resume only gives the year; end dates of ongoing periods are "Present".
+Return an empty string when the resume does not contain the value.`)
+
+	followUp := append(messages[:len(messages):len(messages)],
+		openai.AssistantMessage(answer),
+		openai.UserMessage(sb.String()),
+	)
+
+	var response repairResponse
+	content, err := p.complete(ctx, followUp, "resume_repair", repairSchema(), 1000)
+	if err == nil {
+		err = json.Unmarshal([]byte(content), &response)
+	}
+	if err != nil {
+		logx.Warnf("Resume repair of %d fields failed, keeping them for review: %v", len(problems), err)
+	} else {
+		targets := make(map[string]*string, len(problems))
+		for _, problem := range problems {
+			targets[problem.Path] = problem.target
+		}
+		for _, fix := range response.Fixes {
+			if target, ok := targets[fix.Path]; ok {
+				*target = strings.TrimSpace(fix.Value)
+			}
+		}
+	}
+
+	for _, problem := range rd.validate() {
+		rd.distrust(problem.Path)
+	}
+}
+
+// distrust sets the confidence of a field to 0. Confidences are given per entry or per
+// section, so a value is recorded under the closest path the model reported.
+func (rd *ResumeData) distrust(path string) {
+	key := path
+	if i := strings.LastIndex(path, "]"); i >= 0 {
+		if _, ok := rd.Fields[path[:i+1]]; ok {
+			key = path[:i+1] // "experience[0].start_date" is recorded under "experience[0]"
+		} else if _, ok := rd.Fields[path[:strings.Index(path, "[")]]; ok {
+			key = path[:strings.Index(path, "[")] // "certifications[0].issue_date" under "certifications"
+		}
+	}
+
+	if rd.Fields == nil {
+		rd.Fields = FieldSources{}
+	}
+	source := rd.Fields[key]
+	source.Confidence = 0
+	rd.Fields[key] = source
+}
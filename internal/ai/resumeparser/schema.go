@@ -0,0 +1,122 @@
+package resumeparser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Strict structured outputs only accept a subset of JSON Schema: every object lists all
+// its properties as required and allows no others, so a value the resume does not have
+// comes back empty ("", [] or null) rather than missing.
+
+// resumeSchema is the schema of ResumeData, generated once from its Go types
+var resumeSchema = sync.OnceValue(func() any {
+	return schemaFor(reflect.TypeFor[ResumeData]())
+})
+
+// schemaProvider is implemented by types whose JSON form differs from their Go shape
+type schemaProvider interface {
+	jsonSchema() any
+}
+
+var schemaProviderType = reflect.TypeFor[schemaProvider]()
+
+// schemaFor generates the strict schema of a Go type from its JSON tags. String fields
+// tagged `enum:"A,B"` are limited to those values or "". Pointers are nullable.
+func schemaFor(t reflect.Type) any {
+	if t.Implements(schemaProviderType) {
+		return reflect.Zero(t).Interface().(schemaProvider).jsonSchema()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object := &objectSchema{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			schema := schemaFor(field.Type)
+			if enum := field.Tag.Get("enum"); enum != "" {
+				schema = map[string]any{"type": "string", "enum": append(strings.Split(enum, ","), "")}
+			}
+			object.Properties = append(object.Properties, property{Name: name, Schema: schema})
+		}
+		return object
+
+	case reflect.Pointer:
+		schema, ok := schemaFor(t.Elem()).(map[string]any)
+		if !ok {
+			panic(fmt.Sprintf("resumeparser: no nullable schema for %s", t))
+		}
+		return map[string]any{"type": []any{schema["type"], "null"}}
+
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem())}
+
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+
+	// Maps have arbitrary keys, which strict schemas cannot describe
+	panic(fmt.Sprintf("resumeparser: no schema for %s", t))
+}
+
+// objectSchema is a strict object schema. Its properties keep the order of the Go
+// fields, which is the order the model writes them in.
+type objectSchema struct {
+	Properties []property
+}
+
+type property struct {
+	Name   string
+	Schema any
+}
+
+func (o *objectSchema) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	required := make([]string, 0, len(o.Properties))
+
+	buf.WriteString(`{"type":"object","properties":{`)
+	for i, prop := range o.Properties {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(schema)
+		required = append(required, prop.Name)
+	}
+	buf.WriteString(`},"required":`)
+
+	names, err := json.Marshal(required)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(names)
+	buf.WriteString(`,"additionalProperties":false}`)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,400 @@
+package document
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Compound File Binary (OLE2) constants
+var cfbSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+const (
+	cfbMaxRegSector = 0xFFFFFFFA // Higher sector numbers mark chain ends and unused sectors
+	cfbHeaderSize   = 512
+	cfbDirEntrySize = 128
+	cfbStreamEntry  = 2
+	cfbRootEntry    = 5
+)
+
+// Word 97-2003 File Information Block (FIB) offsets
+const (
+	fibIdent          = 0xA5EC
+	fibFlagWhichTable = 0x0200
+	fibFlagEncrypted  = 0x0100
+	fibCcpTextIndex   = 3  // ccpText in FibRgLw97
+	fibClxIndex       = 33 // fcClx/lcbClx pair in FibRgFcLcb97
+	pieceCompressed   = 0x40000000
+)
+
+// extractDOC reads the main text of a Word 97-2003 document through its piece table
+func extractDOC(data []byte) (string, error) {
+	cfb, err := openCompoundFile(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOC: %w", err)
+	}
+
+	wordDoc, err := cfb.stream("WordDocument")
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOC: %w", err)
+	}
+	if len(wordDoc) < 34 || binary.LittleEndian.Uint16(wordDoc) != fibIdent {
+		return "", errors.New("not a Word document")
+	}
+
+	flags := binary.LittleEndian.Uint16(wordDoc[0x0A:])
+	if flags&fibFlagEncrypted != 0 {
+		return "", errors.New("DOC is password protected")
+	}
+	tableName := "0Table"
+	if flags&fibFlagWhichTable != 0 {
+		tableName = "1Table"
+	}
+	table, err := cfb.stream(tableName)
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOC: %w", err)
+	}
+
+	// FIB: 32-byte base, then counted arrays of shorts, longs and fc/lcb pairs
+	pos := 32
+	csw := int(binary.LittleEndian.Uint16(wordDoc[pos:]))
+	pos += 2 + csw*2
+	if pos+2 > len(wordDoc) {
+		return "", errors.New("truncated DOC header")
+	}
+	cslw := int(binary.LittleEndian.Uint16(wordDoc[pos:]))
+	rgLw := pos + 2
+	pos = rgLw + cslw*4
+	if cslw <= fibCcpTextIndex || pos+2 > len(wordDoc) {
+		return "", errors.New("truncated DOC header")
+	}
+	ccpText := int(binary.LittleEndian.Uint32(wordDoc[rgLw+fibCcpTextIndex*4:]))
+	cbRgFcLcb := int(binary.LittleEndian.Uint16(wordDoc[pos:]))
+	rgFcLcb := pos + 2
+	if cbRgFcLcb <= fibClxIndex || rgFcLcb+(fibClxIndex+1)*8 > len(wordDoc) {
+		return "", errors.New("truncated DOC header")
+	}
+	fcClx := int(binary.LittleEndian.Uint32(wordDoc[rgFcLcb+fibClxIndex*8:]))
+	lcbClx := int(binary.LittleEndian.Uint32(wordDoc[rgFcLcb+fibClxIndex*8+4:]))
+	if lcbClx == 0 || fcClx+lcbClx > len(table) {
+		return "", errors.New("DOC has no piece table")
+	}
+
+	pieces, err := readPieceTable(table[fcClx : fcClx+lcbClx])
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	remaining := ccpText
+	for _, piece := range pieces {
+		if remaining <= 0 {
+			break
+		}
+		count := min(piece.chars, remaining)
+		remaining -= count
+
+		if piece.compressed {
+			end := piece.offset + count
+			if end > len(wordDoc) {
+				return "", errors.New("DOC text runs past the document stream")
+			}
+			for _, b := range wordDoc[piece.offset:end] {
+				sb.WriteRune(windows1252(b))
+			}
+			continue
+		}
+
+		end := piece.offset + count*2
+		if end > len(wordDoc) {
+			return "", errors.New("DOC text runs past the document stream")
+		}
+		units := make([]uint16, count)
+		for i := range units {
+			units[i] = binary.LittleEndian.Uint16(wordDoc[piece.offset+i*2:])
+		}
+		sb.WriteString(string(utf16.Decode(units)))
+	}
+
+	return cleanWordText(sb.String()), nil
+}
+
+// textPiece is a run of text in the WordDocument stream
+type textPiece struct {
+	offset     int
+	chars      int
+	compressed bool // One Windows-1252 byte per character instead of UTF-16
+}
+
+// readPieceTable reads the piece descriptors from the Clx structure, skipping
+// the property modifiers that precede them
+func readPieceTable(clx []byte) ([]textPiece, error) {
+	pos := 0
+	for pos < len(clx) && clx[pos] == 0x01 {
+		if pos+3 > len(clx) {
+			return nil, errors.New("truncated DOC piece table")
+		}
+		pos += 3 + int(binary.LittleEndian.Uint16(clx[pos+1:]))
+	}
+	if pos+5 > len(clx) || clx[pos] != 0x02 {
+		return nil, errors.New("invalid DOC piece table")
+	}
+
+	lcb := int(binary.LittleEndian.Uint32(clx[pos+1:]))
+	plc := clx[pos+5:]
+	if lcb > len(plc) || lcb < 16 || (lcb-4)%12 != 0 {
+		return nil, errors.New("invalid DOC piece table")
+	}
+
+	// n+1 character positions followed by n 8-byte piece descriptors
+	n := (lcb - 4) / 12
+	pieces := make([]textPiece, 0, n)
+	for i := 0; i < n; i++ {
+		start := int(binary.LittleEndian.Uint32(plc[i*4:]))
+		end := int(binary.LittleEndian.Uint32(plc[(i+1)*4:]))
+		if end < start {
+			return nil, errors.New("invalid DOC piece table")
+		}
+
+		fc := binary.LittleEndian.Uint32(plc[(n+1)*4+i*8+2:])
+		piece := textPiece{chars: end - start, offset: int(fc)}
+		if fc&pieceCompressed != 0 {
+			piece.compressed = true
+			piece.offset = int(fc&^pieceCompressed) / 2
+		}
+		pieces = append(pieces, piece)
+	}
+
+	return pieces, nil
+}
+
+// cleanWordText replaces Word's control characters, keeping the displayed result
+// of fields and dropping their instructions
+func cleanWordText(text string) string {
+	var sb strings.Builder
+	fieldDepth := 0
+	inInstruction := []bool{}
+
+	for _, r := range text {
+		switch r {
+		case 0x13: // Field begin
+			fieldDepth++
+			inInstruction = append(inInstruction, true)
+			continue
+		case 0x14: // Field separator
+			if fieldDepth > 0 {
+				inInstruction[fieldDepth-1] = false
+			}
+			continue
+		case 0x15: // Field end
+			if fieldDepth > 0 {
+				fieldDepth--
+				inInstruction = inInstruction[:fieldDepth]
+			}
+			continue
+		}
+		if fieldDepth > 0 && inInstruction[fieldDepth-1] {
+			continue
+		}
+
+		switch r {
+		case '\r', 0x0B, 0x0C:
+			sb.WriteRune('\n')
+		case 0x07: // Table cell or row end
+			sb.WriteString(" | ")
+		case 0x09:
+			sb.WriteRune('\t')
+		case 0x1E:
+			sb.WriteRune('-')
+		case 0x01, 0x08, 0x1F: // Embedded objects and optional hyphens
+		default:
+			if r >= 0x20 {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// compoundFile is a read-only view of a Compound File Binary container
+type compoundFile struct {
+	data       []byte
+	sectorSize int
+	miniSize   int
+	miniCutoff int
+	fat        []uint32
+	miniFAT    []uint32
+	miniStream []byte
+	entries    map[string]cfbEntry
+}
+
+type cfbEntry struct {
+	start uint32
+	size  int
+}
+
+func openCompoundFile(data []byte) (*compoundFile, error) {
+	if len(data) < cfbHeaderSize || !bytes.HasPrefix(data, cfbSignature) {
+		return nil, errors.New("not a compound file")
+	}
+
+	le := binary.LittleEndian
+	sectorShift := le.Uint16(data[0x1E:])
+	miniShift := le.Uint16(data[0x20:])
+	if sectorShift != 9 && sectorShift != 12 || miniShift != 6 {
+		return nil, errors.New("unsupported compound file sector size")
+	}
+	cf := &compoundFile{
+		data:       data,
+		sectorSize: 1 << sectorShift,
+		miniSize:   1 << miniShift,
+		miniCutoff: int(le.Uint32(data[0x38:])),
+		entries:    map[string]cfbEntry{},
+	}
+
+	// The FAT sectors are listed in the header, then in a chain of DIFAT sectors
+	fatSectors := []uint32{}
+	for i := 0; i < 109; i++ {
+		if s := le.Uint32(data[0x4C+i*4:]); s <= cfbMaxRegSector {
+			fatSectors = append(fatSectors, s)
+		}
+	}
+	perDIFAT := cf.sectorSize/4 - 1
+	for s, seen := le.Uint32(data[0x44:]), map[uint32]bool{}; s <= cfbMaxRegSector; {
+		if seen[s] {
+			return nil, errors.New("invalid DIFAT chain")
+		}
+		seen[s] = true
+		sector, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < perDIFAT; i++ {
+			if fs := le.Uint32(sector[i*4:]); fs <= cfbMaxRegSector {
+				fatSectors = append(fatSectors, fs)
+			}
+		}
+		s = le.Uint32(sector[perDIFAT*4:])
+	}
+	// A file cannot hold more FAT sectors than sectors; this bounds the FAT to the file size
+	if len(fatSectors) > len(data)/cf.sectorSize {
+		return nil, errors.New("too many FAT sectors")
+	}
+	for _, s := range fatSectors {
+		sector, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < cf.sectorSize/4; i++ {
+			cf.fat = append(cf.fat, le.Uint32(sector[i*4:]))
+		}
+	}
+
+	dir, err := cf.chain(le.Uint32(data[0x30:]), -1)
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+	var root cfbEntry
+	for off := 0; off+cfbDirEntrySize <= len(dir); off += cfbDirEntrySize {
+		entry := dir[off : off+cfbDirEntrySize]
+		nameLen := int(le.Uint16(entry[0x40:]))
+		if nameLen < 2 || nameLen > 64 {
+			continue
+		}
+		units := make([]uint16, nameLen/2-1) // Without the terminating NUL
+		for i := range units {
+			units[i] = le.Uint16(entry[i*2:])
+		}
+		e := cfbEntry{start: le.Uint32(entry[0x74:]), size: int(le.Uint32(entry[0x78:]))}
+
+		switch entry[0x42] {
+		case cfbRootEntry:
+			root = e
+		case cfbStreamEntry:
+			cf.entries[string(utf16.Decode(units))] = e
+		}
+	}
+
+	// Streams below the cutoff live in the mini stream, which the root entry holds
+	if root.size > 0 {
+		if cf.miniStream, err = cf.chain(root.start, root.size); err != nil {
+			return nil, fmt.Errorf("read mini stream: %w", err)
+		}
+		miniFAT, err := cf.chain(le.Uint32(data[0x3C:]), -1)
+		if err != nil {
+			return nil, fmt.Errorf("read mini FAT: %w", err)
+		}
+		for i := 0; i+4 <= len(miniFAT); i += 4 {
+			cf.miniFAT = append(cf.miniFAT, le.Uint32(miniFAT[i:]))
+		}
+	}
+
+	return cf, nil
+}
+
+// stream returns the contents of a named stream
+func (cf *compoundFile) stream(name string) ([]byte, error) {
+	e, ok := cf.entries[name]
+	if !ok {
+		return nil, fmt.Errorf("missing %s stream", name)
+	}
+	if e.size >= cf.miniCutoff {
+		return cf.chain(e.start, e.size)
+	}
+
+	var buf []byte
+	seen := map[uint32]bool{} // A repeated sector means the chain is cyclic
+	for s := e.start; s <= cfbMaxRegSector && len(buf) < e.size; {
+		if seen[s] || int(s) >= len(cf.miniFAT) {
+			return nil, errors.New("invalid mini sector chain")
+		}
+		seen[s] = true
+		off := int(s) * cf.miniSize
+		if off+cf.miniSize > len(cf.miniStream) {
+			return nil, errors.New("mini sector out of range")
+		}
+		buf = append(buf, cf.miniStream[off:off+cf.miniSize]...)
+		s = cf.miniFAT[s]
+	}
+	if len(buf) < e.size {
+		return nil, errors.New("truncated stream")
+	}
+	return buf[:e.size], nil
+}
+
+// chain concatenates a chain of regular sectors; size -1 reads the whole chain
+func (cf *compoundFile) chain(start uint32, size int) ([]byte, error) {
+	var buf []byte
+	seen := map[uint32]bool{} // A repeated sector means the chain is cyclic
+	for s := start; s <= cfbMaxRegSector && (size < 0 || len(buf) < size); {
+		if seen[s] || int(s) >= len(cf.fat) {
+			return nil, errors.New("invalid sector chain")
+		}
+		seen[s] = true
+		sector, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, sector...)
+		s = cf.fat[s]
+	}
+	if size >= 0 {
+		if len(buf) < size {
+			return nil, errors.New("truncated stream")
+		}
+		buf = buf[:size]
+	}
+	return buf, nil
+}
+
+func (cf *compoundFile) sector(s uint32) ([]byte, error) {
+	off := (int(s) + 1) * cf.sectorSize
+	if off+cf.sectorSize > len(cf.data) {
+		return nil, fmt.Errorf("sector %d out of range", s)
+	}
+	return cf.data[off : off+cf.sectorSize], nil
+}
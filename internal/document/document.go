@@ -0,0 +1,106 @@
+// Package document extracts plain text from word-processor, rich text and web
+// resume files so they can be parsed like the text layer of a PDF
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Text file types this package can read
+const (
+	TypeDOCX = "docx"
+	TypeDOC  = "doc"
+	TypeODT  = "odt"
+	TypeRTF  = "rtf"
+	TypeTXT  = "txt"
+	TypeHTML = "html"
+)
+
+// IsSupported reports whether text can be extracted from a file type
+func IsSupported(fileType string) bool {
+	switch fileType {
+	case TypeDOCX, TypeDOC, TypeODT, TypeRTF, TypeTXT, TypeHTML:
+		return true
+	}
+	return false
+}
+
+// DetectType corrects a file type taken from an upload's name or content type by
+// looking at the data: Word saves RTF under .doc, and .doc files are often DOCX
+// files renamed by hand
+func DetectType(fileType string, data []byte) string {
+	switch {
+	case bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte(`{\rtf`)):
+		return TypeRTF
+	case bytes.HasPrefix(data, cfbSignature):
+		return TypeDOC
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) && (fileType == TypeDOC || fileType == TypeDOCX):
+		return TypeDOCX
+	}
+	return fileType
+}
+
+// ExtractText returns the text of a document, one paragraph per line
+func ExtractText(fileType string, data []byte) (string, error) {
+	var text string
+	var err error
+
+	switch fileType {
+	case TypeDOCX:
+		text, err = extractDOCX(data)
+	case TypeDOC:
+		text, err = extractDOC(data)
+	case TypeODT:
+		text, err = extractODT(data)
+	case TypeRTF:
+		text, err = extractRTF(data)
+	case TypeTXT:
+		text = decodeText(data)
+	case TypeHTML:
+		text = extractHTML(decodeText(data))
+	default:
+		return "", fmt.Errorf("unsupported document type: %s", fileType)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return normalizeText(text), nil
+}
+
+// CanRender reports whether the pages of a file type can be rendered as images
+// (by MuPDF, see pdf.ExtractPages) when its layout matters
+func CanRender(fileType string) bool {
+	return fileType == TypeDOCX
+}
+
+// HasComplexLayout reports whether a document places text in columns, text boxes or
+// floating frames. Extracted text loses the reading order of such layouts, which are
+// common in designed resume templates, so they are better read from rendered pages.
+func HasComplexLayout(fileType string, data []byte) bool {
+	if fileType == TypeDOCX {
+		return docxHasComplexLayout(data)
+	}
+	return false
+}
+
+var (
+	horizontalSpace = regexp.MustCompile(`[ \t\p{Zs}]+`)
+	blankLines      = regexp.MustCompile(`\n{3,}`)
+)
+
+// normalizeText collapses runs of spaces and blank lines, and trims every line
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(horizontalSpace.ReplaceAllString(line, " "))
+	}
+
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
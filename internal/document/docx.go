@@ -0,0 +1,180 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WordprocessingML namespaces
+const (
+	nsWord   = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	nsMarkup = "http://schemas.openxmlformats.org/markup-compatibility/2006"
+)
+
+// extractDOCX reads the headers and body of a Word 2007+ document. Contact
+// details often sit in the header of resume templates, so headers come first.
+func extractDOCX(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOCX: %w", err)
+	}
+
+	body := findZipFile(archive, "word/document.xml")
+	if body == nil {
+		return "", fmt.Errorf("DOCX has no word/document.xml")
+	}
+
+	headers := []*zip.File{}
+	for _, f := range archive.File {
+		if dir, name := path.Split(f.Name); dir == "word/" && strings.HasPrefix(name, "header") && strings.HasSuffix(name, ".xml") {
+			headers = append(headers, f)
+		}
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+
+	var sb strings.Builder
+	seen := map[string]bool{}
+	for _, f := range headers {
+		text, err := readDOCXPart(f)
+		if err != nil {
+			return "", err
+		}
+		// Templates repeat one header for the first, odd and even pages
+		if text = strings.TrimSpace(text); text != "" && !seen[text] {
+			seen[text] = true
+			sb.WriteString(text)
+			sb.WriteString("\n\n")
+		}
+	}
+
+	text, err := readDOCXPart(body)
+	if err != nil {
+		return "", err
+	}
+	sb.WriteString(text)
+
+	return sb.String(), nil
+}
+
+// readDOCXPart returns the text of one WordprocessingML part
+func readDOCXPart(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	var sb strings.Builder
+	decoder := xml.NewDecoder(rc)
+	inText := false
+	fallbackDepth := 0 // Inside mc:Fallback, which repeats the text of mc:Choice for older readers
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space == nsMarkup && t.Name.Local == "Fallback" {
+				fallbackDepth++
+			}
+			if fallbackDepth > 0 || t.Name.Space != nsWord {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				sb.WriteString("\t")
+			case "br", "cr":
+				sb.WriteString("\n")
+			case "noBreakHyphen":
+				sb.WriteString("-")
+			}
+		case xml.EndElement:
+			if t.Name.Space == nsMarkup && t.Name.Local == "Fallback" {
+				fallbackDepth--
+			}
+			if fallbackDepth > 0 || t.Name.Space != nsWord {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				sb.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText && fallbackDepth == 0 {
+				sb.Write(t)
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// docxHasComplexLayout looks for multi-column sections, text boxes and floating
+// drawings in the document body
+func docxHasComplexLayout(data []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	body := findZipFile(archive, "word/document.xml")
+	if body == nil {
+		return false
+	}
+
+	rc, err := body.Open()
+	if err != nil {
+		return false
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(rc)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "txbxContent", "anchor":
+			return true
+		case "cols":
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "num" {
+					if n, err := strconv.Atoi(attr.Value); err == nil && n > 1 {
+						return true
+					}
+				}
+			}
+		}
+	}
+}
+
+func findZipFile(archive *zip.Reader, name string) *zip.File {
+	for _, f := range archive.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package document
+
+import (
+	"html"
+	"regexp"
+)
+
+var (
+	htmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlHidden  = []*regexp.Regexp{
+		regexp.MustCompile(`(?is)<head\b.*?</head\s*>`),
+		regexp.MustCompile(`(?is)<script\b.*?</script\s*>`),
+		regexp.MustCompile(`(?is)<style\b.*?</style\s*>`),
+		regexp.MustCompile(`(?is)<noscript\b.*?</noscript\s*>`),
+		regexp.MustCompile(`(?is)<template\b.*?</template\s*>`),
+		regexp.MustCompile(`(?is)<svg\b.*?</svg\s*>`),
+	}
+	htmlListItem = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	htmlCell     = regexp.MustCompile(`(?i)</t[dh]\s*>`)
+	htmlBlock    = regexp.MustCompile(`(?i)</?(?:p|div|br|hr|tr|ul|ol|li|dl|dt|dd|h[1-6]|table|thead|tbody|section|article|header|footer|aside|nav|main|blockquote|pre|address)\b[^>]*>`)
+	htmlTag      = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// extractHTML reduces an HTML page to its visible text, keeping block elements
+// on their own lines
+func extractHTML(page string) string {
+	page = htmlComment.ReplaceAllString(page, "")
+	for _, hidden := range htmlHidden {
+		page = hidden.ReplaceAllString(page, "")
+	}
+
+	page = htmlListItem.ReplaceAllString(page, "\n- ")
+	page = htmlCell.ReplaceAllString(page, " | ")
+	page = htmlBlock.ReplaceAllString(page, "\n")
+	page = htmlTag.ReplaceAllString(page, "")
+
+	return html.UnescapeString(page)
+}
@@ -0,0 +1,103 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OpenDocument namespaces
+const (
+	nsODFText   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	nsODFOffice = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+)
+
+// extractODT reads the body of an OpenDocument text file
+func extractODT(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open ODT: %w", err)
+	}
+
+	content := findZipFile(archive, "content.xml")
+	if content == nil {
+		return "", fmt.Errorf("ODT has no content.xml")
+	}
+
+	rc, err := content.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to read content.xml: %w", err)
+	}
+	defer rc.Close()
+
+	var sb strings.Builder
+	decoder := xml.NewDecoder(rc)
+	inBody := false
+	skipDepth := 0 // Inside annotations and tracked deletions
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse content.xml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space == nsODFOffice && (t.Name.Local == "body" || t.Name.Local == "annotation") {
+				if t.Name.Local == "body" {
+					inBody = true
+				} else {
+					skipDepth++
+				}
+				continue
+			}
+			if t.Name.Space == nsODFText && t.Name.Local == "tracked-changes" {
+				skipDepth++
+				continue
+			}
+			if !inBody || skipDepth > 0 || t.Name.Space != nsODFText {
+				continue
+			}
+			switch t.Name.Local {
+			case "tab":
+				sb.WriteString("\t")
+			case "line-break":
+				sb.WriteString("\n")
+			case "s":
+				count := 1
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "c" {
+						if n, err := strconv.Atoi(attr.Value); err == nil && n > 0 {
+							count = n
+						}
+					}
+				}
+				sb.WriteString(strings.Repeat(" ", count))
+			}
+		case xml.EndElement:
+			switch {
+			case t.Name.Space == nsODFOffice && t.Name.Local == "body":
+				inBody = false
+			case t.Name.Space == nsODFOffice && t.Name.Local == "annotation",
+				t.Name.Space == nsODFText && t.Name.Local == "tracked-changes":
+				skipDepth--
+			case skipDepth > 0 || !inBody:
+			case t.Name.Space == nsODFText && (t.Name.Local == "p" || t.Name.Local == "h"):
+				sb.WriteString("\n")
+			}
+		case xml.CharData:
+			if inBody && skipDepth == 0 {
+				sb.Write(t)
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,147 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// rtfSkippedDestinations hold formatting, metadata or embedded data rather than text
+var rtfSkippedDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "info": true, "pict": true,
+	"object": true, "themedata": true, "colorschememapping": true, "datastore": true,
+	"latentstyles": true, "listtable": true, "listoverridetable": true, "rsidtbl": true,
+	"generator": true, "xmlnstbl": true, "mmathPr": true, "fldinst": true, "filetbl": true,
+	"revtbl": true, "pgdsctbl": true, "listtext": true, "pntext": true,
+}
+
+// rtfSymbols are control words that stand for a character
+var rtfSymbols = map[string]string{
+	"par": "\n", "line": "\n", "sect": "\n", "page": "\n", "row": "\n",
+	"cell": " | ", "tab": "\t", "emdash": "—", "endash": "–", "bullet": "•",
+	"lquote": "‘", "rquote": "’", "ldblquote": "“", "rdblquote": "”",
+	"emspace": " ", "enspace": " ", "qmspace": " ",
+}
+
+// rtfGroup is the state a group inherits from its parent
+type rtfGroup struct {
+	skip   bool // Inside a destination that holds no text
+	ucSkip int  // Fallback characters that follow each \u character
+}
+
+// extractRTF reads the text of a Rich Text Format document
+func extractRTF(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte(`{\rtf`)) {
+		return "", fmt.Errorf("not an RTF document")
+	}
+
+	var sb strings.Builder
+	stack := []rtfGroup{}
+	state := rtfGroup{ucSkip: 1}
+	pendingSkip := 0 // Fallback characters still to drop after a \u character
+
+	emit := func(s string) {
+		if pendingSkip > 0 {
+			pendingSkip--
+			return
+		}
+		if !state.skip {
+			sb.WriteString(s)
+		}
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '{':
+			stack = append(stack, state)
+			pendingSkip = 0
+		case '}':
+			if len(stack) > 0 {
+				state = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+			pendingSkip = 0
+		case '\r', '\n':
+		case '\\':
+			if i+1 >= len(data) {
+				break
+			}
+			next := data[i+1]
+
+			if !isASCIILetter(next) {
+				i++
+				switch next {
+				case '\\', '{', '}':
+					emit(string(next))
+				case '~':
+					emit(" ")
+				case '_':
+					emit("-")
+				case '*':
+					state.skip = true
+				case '\'':
+					if i+2 < len(data) {
+						if b, err := strconv.ParseUint(string(data[i+1:i+3]), 16, 8); err == nil {
+							emit(string(windows1252(byte(b))))
+						}
+						i += 2
+					}
+				case '\r', '\n':
+					emit("\n")
+				}
+				break
+			}
+
+			// Control word: letters, an optional signed parameter and an optional space
+			j := i + 1
+			for j < len(data) && isASCIILetter(data[j]) {
+				j++
+			}
+			word := string(data[i+1 : j])
+			k := j
+			if k < len(data) && data[k] == '-' {
+				k++
+			}
+			for k < len(data) && data[k] >= '0' && data[k] <= '9' {
+				k++
+			}
+			param, hasParam := 0, k > j
+			if hasParam {
+				param, _ = strconv.Atoi(string(data[j:k]))
+			}
+			if k < len(data) && data[k] == ' ' {
+				k++
+			}
+			i = k - 1
+
+			switch {
+			case word == "bin" && hasParam:
+				i += max(param, 0)
+			case word == "u" && hasParam:
+				if param < 0 {
+					param += 65536
+				}
+				emit(string(rune(param)))
+				pendingSkip = state.ucSkip
+			case word == "uc" && hasParam:
+				state.ucSkip = max(param, 0)
+			case rtfSkippedDestinations[word]:
+				state.skip = true
+			default:
+				if symbol, ok := rtfSymbols[word]; ok {
+					emit(symbol)
+				}
+			}
+		default:
+			emit(string(windows1252(c)))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
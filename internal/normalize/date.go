@@ -0,0 +1,178 @@
+package normalize
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Present is how the end of an ongoing period is written once normalized
+const Present = "Present"
+
+// presentWords are the ways resumes say a period is ongoing, folded (see foldName)
+var presentWords = map[string]bool{
+	"present": true, "current": true, "currently": true, "now": true, "today": true,
+	"ongoing": true, "to date": true, "till date": true, "to present": true,
+	"actualidad": true, "la actualidad": true, "a la actualidad": true, "actual": true,
+	"actualmente": true, "presente": true, "al presente": true, "hoy": true, "hasta hoy": true,
+	"a la fecha": true, "la fecha": true, "hasta la fecha": true, "en curso": true,
+}
+
+// monthNames maps English and Spanish month names and abbreviations to month numbers
+var monthNames = map[string]int{
+	"january": 1, "jan": 1, "enero": 1, "ene": 1,
+	"february": 2, "feb": 2, "febrero": 2,
+	"march": 3, "mar": 3, "marzo": 3,
+	"april": 4, "apr": 4, "abril": 4, "abr": 4,
+	"may": 5, "mayo": 5,
+	"june": 6, "jun": 6, "junio": 6,
+	"july": 7, "jul": 7, "julio": 7,
+	"august": 8, "aug": 8, "agosto": 8, "ago": 8,
+	"september": 9, "sep": 9, "sept": 9, "septiembre": 9, "setiembre": 9, "set": 9,
+	"october": 10, "oct": 10, "octubre": 10,
+	"november": 11, "nov": 11, "noviembre": 11,
+	"december": 12, "dec": 12, "diciembre": 12, "dic": 12,
+}
+
+var (
+	yearMonthDay = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})(?:[-/.](\d{1,2}))?$`)
+	monthYear    = regexp.MustCompile(`^(\d{1,2})[-/.](\d{4})$`)
+	dayMonthYear = regexp.MustCompile(`^(\d{1,2})[-/.](\d{1,2})[-/.](\d{4})$`)
+	yearOnly     = regexp.MustCompile(`^(\d{4})$`)
+
+	// Month names with a year, optionally with a day: "March 2020", "15 de marzo de 2020",
+	// "Mar 15, 2020" and "Mar '20" after folding
+	namedMonth = regexp.MustCompile(`^(?:(\d{1,2}) )?([a-z]+) (?:(\d{1,2}) )?(\d{4}|\d{2})$`)
+)
+
+// ParseDate reads a date as written on a resume and returns it as YYYY-MM, as YYYY when
+// only the year is given, or as Present for ongoing periods. Numeric dates with the day
+// and month both 12 or less are read day first, as in Spanish.
+func ParseDate(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", false
+	}
+
+	folded := foldName(strings.NewReplacer("'", " ", "’", " ").Replace(text))
+	if presentWords[folded] {
+		return Present, true
+	}
+
+	// Numeric forms keep their separators, which folding would drop
+	numeric := strings.ReplaceAll(text, " ", "")
+	if m := yearMonthDay.FindStringSubmatch(numeric); m != nil {
+		return formatYearMonth(m[1], m[2])
+	}
+	if m := monthYear.FindStringSubmatch(numeric); m != nil {
+		return formatYearMonth(m[2], m[1])
+	}
+	if m := dayMonthYear.FindStringSubmatch(numeric); m != nil {
+		first, _ := strconv.Atoi(m[1])
+		second, _ := strconv.Atoi(m[2])
+		if first <= 12 && second > 12 {
+			return formatYearMonth(m[3], m[1]) // Month first, as in the US
+		}
+		return formatYearMonth(m[3], m[2])
+	}
+	if m := yearOnly.FindStringSubmatch(numeric); m != nil {
+		if year, ok := parseYear(m[1]); ok {
+			return strconv.Itoa(year), true
+		}
+		return "", false
+	}
+
+	// Drop the Spanish "de"/"del" between day, month and year
+	words := []string{}
+	for _, word := range strings.Fields(folded) {
+		if word != "de" && word != "del" {
+			words = append(words, word)
+		}
+	}
+	if m := namedMonth.FindStringSubmatch(strings.Join(words, " ")); m != nil {
+		month, ok := monthNames[m[2]]
+		if !ok {
+			return "", false
+		}
+		year, ok := parseYear(m[4])
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%04d-%02d", year, month), true
+	}
+
+	return "", false
+}
+
+// MonthsBetween counts the months from a start to an end date, in any form ParseDate
+// reads. Dates given only by their year count from January. The end may be Present,
+// which counts up to now.
+func MonthsBetween(start, end string, now time.Time) (int, bool) {
+	startYear, startMonth, ok := yearAndMonth(start, now)
+	if !ok {
+		return 0, false
+	}
+	endYear, endMonth, ok := yearAndMonth(end, now)
+	if !ok {
+		return 0, false
+	}
+
+	months := (endYear-startYear)*12 + endMonth - startMonth
+	if months < 0 {
+		return 0, false
+	}
+	return months, true
+}
+
+// yearAndMonth reads a date for MonthsBetween
+func yearAndMonth(text string, now time.Time) (int, int, bool) {
+	date, ok := ParseDate(text)
+	if !ok {
+		return 0, 0, false
+	}
+	if date == Present {
+		return now.Year(), int(now.Month()), true
+	}
+
+	year, month := date, "1"
+	if y, m, found := strings.Cut(date, "-"); found {
+		year, month = y, m
+	}
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	return y, m, true
+}
+
+// formatYearMonth checks a numeric year and month and writes them as YYYY-MM
+func formatYearMonth(year, month string) (string, bool) {
+	y, ok := parseYear(year)
+	if !ok {
+		return "", false
+	}
+	m, err := strconv.Atoi(month)
+	if err != nil || m < 1 || m > 12 {
+		return "", false
+	}
+	return fmt.Sprintf("%04d-%02d", y, m), true
+}
+
+// parseYear reads a four-digit year, or a two-digit one as the closest year not in the future
+func parseYear(text string) (int, bool) {
+	year, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, false
+	}
+	if len(text) == 2 {
+		century := time.Now().Year() / 100 * 100
+		year += century
+		if year > time.Now().Year() {
+			year -= 100
+		}
+	}
+	if year < 1900 || year > 2100 {
+		return 0, false
+	}
+	return year, true
+}
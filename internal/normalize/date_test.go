@@ -0,0 +1,72 @@
+package normalize
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+		ok   bool
+	}{
+		{"year and month", "2020-03", "2020-03", true},
+		{"full ISO date", "2020-03-15", "2020-03", true},
+		{"month and year", "03/2020", "2020-03", true},
+		{"year only", "2020", "2020", true},
+		{"day first when both fit", "05/03/2020", "2020-03", true},
+		{"day first", "25/03/2020", "2020-03", true},
+		{"month first when the day cannot be a month", "03/25/2020", "2020-03", true},
+		{"English month name", "March 2020", "2020-03", true},
+		{"English month with day", "Mar 15, 2020", "2020-03", true},
+		{"Spanish month with day", "15 de marzo de 2020", "2020-03", true},
+		{"Spanish abbreviation", "set. 2019", "2019-09", true},
+		{"two-digit year", "Mar '20", "2020-03", true},
+		{"two-digit year in the last century", "Dec '99", "1999-12", true},
+		{"present", "Present", Present, true},
+		{"Spanish present", "a la actualidad", Present, true},
+		{"empty", "", "", false},
+		{"invalid month", "2020-13", "", false},
+		{"year out of range", "1850", "", false},
+		{"unknown month name", "Smarch 2020", "", false},
+		{"free text", "two years ago", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseDate(tt.text)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParseDate(%q) = %q, %v; want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMonthsBetween(t *testing.T) {
+	now := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		start, end string
+		want       int
+		ok         bool
+	}{
+		{"same format", "2020-03", "2021-01", 10, true},
+		{"mixed formats", "March 2020", "05/2020", 2, true},
+		{"year only counts from January", "2020", "2020-07", 6, true},
+		{"ongoing", "2023-06", Present, 12, true},
+		{"end before start", "2021-01", "2020-01", 0, false},
+		{"unreadable start", "someday", "2020-01", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MonthsBetween(tt.start, tt.end, now)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("MonthsBetween(%q, %q) = %d, %v; want %d, %v", tt.start, tt.end, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package normalize rewrites contact details and dates taken from resumes in their
+// canonical forms: locations split into city, state and ISO country, phones in
+// E.164 and dates as YYYY-MM. Places are looked up in an offline gazetteer.
+package normalize
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed data/countries.tsv data/regions.tsv data/cities.tsv
+var gazetteerData embed.FS
+
+type country struct {
+	Code        string // ISO 3166-1 alpha-2
+	CallingCode string
+	TrunkPrefix string // Dialled before national numbers, dropped in E.164
+}
+
+type region struct {
+	Country string
+	Name    string
+}
+
+type city struct {
+	Country string
+	Region  string
+	Name    string
+}
+
+// gazetteer indexes places by their folded names (see foldName)
+type gazetteer struct {
+	countries    map[string]*country  // By code
+	countryNames map[string]*country  // By folded name
+	regions      map[string][]*region // By folded name, several countries can share one
+	cities       map[string][]*city   // By folded name, in file order
+	callingCodes map[string]bool
+}
+
+// places is loaded from the embedded data on first use
+var places = sync.OnceValue(func() *gazetteer {
+	g := &gazetteer{
+		countries:    map[string]*country{},
+		countryNames: map[string]*country{},
+		regions:      map[string][]*region{},
+		cities:       map[string][]*city{},
+		callingCodes: map[string]bool{},
+	}
+
+	readTable("data/countries.tsv", 4, func(cols []string) {
+		c := &country{Code: cols[0], CallingCode: cols[1], TrunkPrefix: cols[2]}
+		g.countries[c.Code] = c
+		g.callingCodes[c.CallingCode] = true
+		for _, name := range strings.Split(cols[3], "|") {
+			if _, taken := g.countryNames[foldName(name)]; !taken {
+				g.countryNames[foldName(name)] = c
+			}
+		}
+	})
+
+	readTable("data/regions.tsv", 2, func(cols []string) {
+		names := strings.Split(cols[1], "|")
+		r := &region{Country: cols[0], Name: names[0]}
+		for _, name := range names {
+			g.regions[foldName(name)] = append(g.regions[foldName(name)], r)
+		}
+	})
+
+	readTable("data/cities.tsv", 3, func(cols []string) {
+		names := strings.Split(cols[2], "|")
+		c := &city{Country: cols[0], Region: cols[1], Name: names[0]}
+		for _, name := range names {
+			g.cities[foldName(name)] = append(g.cities[foldName(name)], c)
+		}
+	})
+
+	return g
+})
+
+// readTable calls row with the columns of every line of an embedded tab-separated
+// file, skipping comments. The files ship with the binary, so a malformed one panics.
+func readTable(name string, columns int, row func(cols []string)) {
+	data, err := gazetteerData.ReadFile(name)
+	if err != nil {
+		panic(fmt.Sprintf("normalize: %v", err))
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		cols := strings.Split(text, "\t")
+		if len(cols) != columns {
+			panic(fmt.Sprintf("normalize: %s:%d: expected %d columns, got %d", name, line, columns, len(cols)))
+		}
+		row(cols)
+	}
+}
+
+// CountryCode returns the ISO 3166-1 alpha-2 code of a country given by name, in
+// English or Spanish, by a common alias or by its code
+func CountryCode(name string) (string, bool) {
+	g := places()
+	if c, ok := g.countries[strings.ToUpper(strings.TrimSpace(name))]; ok {
+		return c.Code, true
+	}
+	if c, ok := g.countryNames[foldName(name)]; ok {
+		return c.Code, true
+	}
+	return "", false
+}
+
+// CallingCode returns the international calling code of a country, without the +
+func CallingCode(countryCode string) string {
+	if c, ok := places().countries[countryCode]; ok {
+		return c.CallingCode
+	}
+	return ""
+}
+
+// foldName lowercases a place name and drops accents and punctuation, so that
+// "Perú", "PERU" and "peru." are looked up alike
+func foldName(name string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		if folded, ok := accentFolds[r]; ok {
+			r = folded
+		}
+		switch {
+		case r == '&':
+			sb.WriteString(" and ")
+			space = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && sb.Len() > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteRune(r)
+			space = false
+		case r == '.' || r == '\'':
+			// "D.C." and "O'Higgins" keep their letters together
+		default:
+			space = true
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// accentFolds maps the accented letters of Spanish, Portuguese, French and German
+// place names to their plain letters
+var accentFolds = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c', 'ș': 's', 'ț': 't', 'ł': 'l',
+}
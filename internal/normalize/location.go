@@ -0,0 +1,214 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Location is a place split into its parts. Country is an ISO 3166-1 alpha-2 code
+// once the location is normalized.
+type Location struct {
+	City    string
+	State   string
+	Country string
+	ZipCode string
+}
+
+var (
+	// zipCode matches US ZIP (+4) and other numeric postal codes, and Canadian postal codes
+	zipCode = regexp.MustCompile(`\b(\d{4,6}(-\d{4})?|[A-Za-z]\d[A-Za-z] ?\d[A-Za-z]\d)\b`)
+
+	locationSeparators = regexp.MustCompile(`\s*(?:[,;/|\n]|\s-\s|\s–\s)\s*`)
+)
+
+// ParseLocation splits a location written as free text, such as "Miraflores, Lima, Perú",
+// "Austin, TX 78701" or "Madrid España". The result reports whether the country could
+// be determined; parts that are not in the gazetteer are kept as written.
+func ParseLocation(text string) (Location, bool) {
+	var loc Location
+
+	if zip := zipCode.FindString(text); zip != "" {
+		loc.ZipCode = strings.ToUpper(zip)
+		text = strings.Replace(text, zip, " ", 1)
+	}
+
+	parts := []string{}
+	for _, part := range locationSeparators.Split(text, -1) {
+		if part = strings.Trim(part, " \t.-()"); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return loc, false
+	}
+
+	g := places()
+
+	// A trailing country or region is often written without a comma: "Lima Peru"
+	if last := parts[len(parts)-1]; !g.known(last) {
+		if head, tail, ok := g.splitTrailingPlace(last); ok {
+			parts = append(parts[:len(parts)-1], head, tail)
+		}
+	}
+
+	rest := parts
+	if len(rest) > 1 || g.cityCandidates(rest[0], "", "") == nil {
+		last := rest[len(rest)-1]
+		cityPart := ""
+		if len(rest) > 1 {
+			cityPart = rest[0]
+		}
+		if code, ok := countryPart(last); ok && !g.regionOverCountry(last, code, cityPart) {
+			loc.Country = code
+			rest = rest[:len(rest)-1]
+		}
+	}
+
+	// The part before the country (or the last one) can name a region
+	if len(rest) > 0 && (len(rest) > 1 || g.cityCandidates(rest[0], loc.Country, "") == nil) {
+		last := rest[len(rest)-1]
+		cityPart := ""
+		if len(rest) > 1 {
+			cityPart = rest[0]
+		}
+		if r := g.pickRegion(last, loc.Country, cityPart); r != nil {
+			loc.State = r.Name
+			loc.Country = r.Country
+			rest = rest[:len(rest)-1]
+		}
+	}
+
+	// The first remaining part is the city; the parts in between are districts or
+	// duplicates of the region and are dropped
+	if len(rest) > 0 {
+		loc.City = rest[0]
+		if c := g.pickCity(rest[0], loc.Country, loc.State); c != nil {
+			loc.City = c.Name
+			loc.Country = c.Country
+			if loc.State == "" {
+				loc.State = c.Region
+			}
+		}
+	}
+
+	return loc, loc.Country != ""
+}
+
+// NormalizeLocation normalizes a location whose parts were filled in separately, possibly
+// with the whole location in City and country names instead of codes
+func NormalizeLocation(loc Location) (Location, bool) {
+	parts := []string{}
+	for _, part := range []string{loc.City, loc.State, loc.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return loc, false
+	}
+
+	normalized, ok := ParseLocation(strings.Join(parts, ", "))
+	if normalized.ZipCode == "" {
+		normalized.ZipCode = strings.TrimSpace(loc.ZipCode)
+	}
+	return normalized, ok
+}
+
+// countryPart reads a part as a country. Two-letter parts count as ISO codes only when
+// written in capitals, so "co" in an address is not taken for Colombia.
+func countryPart(part string) (string, bool) {
+	if len(part) == 2 && part != strings.ToUpper(part) {
+		return "", false
+	}
+	return CountryCode(part)
+}
+
+// known reports whether a part is a country, region or city of the gazetteer
+func (g *gazetteer) known(part string) bool {
+	key := foldName(part)
+	_, isCountry := g.countryNames[key]
+	return isCountry || g.regions[key] != nil || g.cities[key] != nil
+}
+
+// splitTrailingPlace splits the last one to three words off a part when they name a
+// country or region and the words before them are left over
+func (g *gazetteer) splitTrailingPlace(part string) (string, string, bool) {
+	words := strings.Fields(part)
+	for n := min(3, len(words)-1); n >= 1; n-- {
+		head := strings.Join(words[:len(words)-n], " ")
+		tail := strings.Join(words[len(words)-n:], " ")
+		if _, ok := g.countryNames[foldName(tail)]; ok {
+			return head, tail, true
+		}
+		if g.regions[foldName(tail)] != nil && len(tail) > 2 {
+			return head, tail, true
+		}
+	}
+	return "", "", false
+}
+
+// regionOverCountry decides a part that names both a country and a region. The city
+// settles it when it can ("Los Angeles, CA" is California, "Lima, PE" is Peru);
+// otherwise abbreviations are read as the region ("Springfield, IL" is Illinois) and
+// full names as the country.
+func (g *gazetteer) regionOverCountry(part, countryCode, cityPart string) bool {
+	regions := g.regions[foldName(part)]
+	if len(regions) == 0 || (cityPart != "" && g.cityCandidates(cityPart, countryCode, "") != nil) {
+		return false
+	}
+	for _, r := range regions {
+		if cityPart != "" && g.cityCandidates(cityPart, r.Country, r.Name) != nil {
+			return true
+		}
+	}
+	return len(foldName(part)) <= 3 && g.pickRegion(part, "", cityPart) != nil
+}
+
+// pickRegion chooses the region a part names, within the country when it is known,
+// preferring one that holds the city. A name shared by regions of several countries
+// ("Amazonas", "BC") is left alone unless the city settles it.
+func (g *gazetteer) pickRegion(part, countryCode, cityPart string) *region {
+	var matches []*region
+	for _, r := range g.regions[foldName(part)] {
+		if countryCode != "" && r.Country != countryCode {
+			continue
+		}
+		if cityPart != "" && g.cityCandidates(cityPart, r.Country, r.Name) != nil {
+			return r
+		}
+		matches = append(matches, r)
+	}
+	if len(matches) != 1 {
+		return nil
+	}
+	return matches[0]
+}
+
+// pickCity chooses the city a part names, within the country and region when known
+func (g *gazetteer) pickCity(part, countryCode, regionName string) *city {
+	if candidates := g.cityCandidates(part, countryCode, regionName); candidates != nil {
+		return candidates[0]
+	}
+	if regionName != "" {
+		// A city outside the listed region is still the same city ("Callao, Lima")
+		if candidates := g.cityCandidates(part, countryCode, ""); candidates != nil {
+			return candidates[0]
+		}
+	}
+	return nil
+}
+
+// cityCandidates lists the cities a part names, filtered by country and region when given
+func (g *gazetteer) cityCandidates(part, countryCode, regionName string) []*city {
+	var candidates []*city
+	for _, c := range g.cities[foldName(part)] {
+		if countryCode != "" && c.Country != countryCode {
+			continue
+		}
+		if regionName != "" && c.Region != regionName {
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
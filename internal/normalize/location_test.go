@@ -0,0 +1,55 @@
+package normalize
+
+import "testing"
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Location
+		ok   bool
+	}{
+		{"district, city and country", "Miraflores, Lima, Perú", Location{City: "Miraflores", State: "Lima", Country: "PE"}, true},
+		{"city alone", "Arequipa", Location{City: "Arequipa", State: "Arequipa", Country: "PE"}, true},
+		{"city alias", "Cuzco", Location{City: "Cusco", State: "Cusco", Country: "PE"}, true},
+		{"country without a comma", "Madrid España", Location{City: "Madrid", State: "Comunidad de Madrid", Country: "ES"}, true},
+		{"region abbreviation and ZIP code", "Austin, TX 78701", Location{City: "Austin", State: "Texas", Country: "US", ZipCode: "78701"}, true},
+		{"Spanish city name", "Nueva York, EEUU", Location{City: "Nueva York", State: "New York", Country: "US"}, true},
+		{"country code", "Bogotá, CO", Location{City: "Bogotá", State: "Bogotá D.C.", Country: "CO"}, true},
+		{"unknown city kept as written", "Springfield, USA", Location{City: "Springfield", Country: "US"}, true},
+		{"unknown place", "Atlantis", Location{City: "Atlantis"}, false},
+		{"empty", "", Location{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLocation(tt.text)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParseLocation(%q) = %+v, %v; want %+v, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNormalizeLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  Location
+		want Location
+		ok   bool
+	}{
+		{"country name to code", Location{City: "Lima", Country: "Peru"}, Location{City: "Lima", State: "Lima", Country: "PE"}, true},
+		{"whole location in the city", Location{City: "San Isidro, Lima, Peru"}, Location{City: "San Isidro", State: "Lima", Country: "PE"}, true},
+		{"ZIP code kept", Location{City: "Austin", State: "TX", ZipCode: " 78701 "}, Location{City: "Austin", State: "Texas", Country: "US", ZipCode: "78701"}, true},
+		{"empty", Location{ZipCode: "78701"}, Location{ZipCode: "78701"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizeLocation(tt.loc)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("NormalizeLocation(%+v) = %+v, %v; want %+v, %v", tt.loc, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// phoneExtension matches an extension at the end of a phone number, which E.164 has no place for
+var phoneExtension = regexp.MustCompile(`(?i)\s*(?:ext\.?|extension|x|anexo|anx\.?|int\.?|#)\s*\d{1,6}\s*$`)
+
+// FormatPhone writes a phone number in E.164: a + followed by the country calling code
+// and the national number, with no spaces. Numbers written without an international
+// prefix are read as numbers of defaultCountry (ISO 3166-1 alpha-2), dropping its trunk
+// prefix; without a default country only international numbers can be formatted.
+func FormatPhone(text, defaultCountry string) (string, bool) {
+	text = phoneExtension.ReplaceAllString(strings.TrimSpace(text), "")
+
+	international := strings.HasPrefix(text, "+")
+	digits := make([]rune, 0, len(text))
+	for _, r := range text {
+		switch {
+		case unicode.IsDigit(r):
+			digits = append(digits, r)
+		case unicode.IsLetter(r):
+			return "", false // Vanity numbers and notes are left as written
+		}
+	}
+	number := string(digits)
+
+	g := places()
+	home := g.countries[defaultCountry]
+
+	// International call prefixes: 00 in most of the world, 011 from North America
+	switch {
+	case international:
+	case strings.HasPrefix(number, "00"):
+		international, number = true, number[2:]
+	case strings.HasPrefix(number, "011") && home != nil && home.CallingCode == "1":
+		international, number = true, number[3:]
+	}
+
+	if international {
+		if !g.knownCallingCode(number) {
+			return "", false
+		}
+		return checkE164(number)
+	}
+
+	if home == nil {
+		return "", false
+	}
+
+	// North American numbers are ten digits, sometimes written with the leading 1
+	if home.CallingCode == "1" {
+		switch {
+		case len(number) == 10:
+			return checkE164("1" + number)
+		case len(number) == 11 && number[0] == '1':
+			return checkE164(number)
+		}
+		return "", false
+	}
+
+	switch {
+	case home.TrunkPrefix != "" && strings.HasPrefix(number, home.TrunkPrefix):
+		number = strings.TrimPrefix(number, home.TrunkPrefix)
+	case strings.HasPrefix(number, home.CallingCode) && len(number) >= 11:
+		// Calling code written without the +, as in "51 987 654 321"
+		return checkE164(number)
+	}
+
+	if len(number) < 6 {
+		return "", false
+	}
+	return checkE164(home.CallingCode + number)
+}
+
+// checkE164 checks the length of a number with its calling code and adds the +
+func checkE164(number string) (string, bool) {
+	if len(number) < 8 || len(number) > 15 {
+		return "", false
+	}
+	return "+" + number, true
+}
+
+// knownCallingCode reports whether a number starts with a country calling code
+func (g *gazetteer) knownCallingCode(number string) bool {
+	for n := 1; n <= 3 && n <= len(number); n++ {
+		if g.callingCodes[number[:n]] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package normalize
+
+import "testing"
+
+func TestFormatPhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		country string
+		want    string
+		ok      bool
+	}{
+		{"international", "+51 987 654 321", "", "+51987654321", true},
+		{"00 prefix", "0051 987 654 321", "", "+51987654321", true},
+		{"011 prefix from North America", "011 51 987 654 321", "US", "+51987654321", true},
+		{"011 outside North America is national", "011 2345 6789", "PE", "+511123456789", true},
+		{"national mobile", "987 654 321", "PE", "+51987654321", true},
+		{"trunk prefix dropped", "01 234 5678", "PE", "+5112345678", true},
+		{"UK trunk prefix dropped", "020 7946 0958", "GB", "+442079460958", true},
+		{"calling code without the plus", "51 987 654 321", "PE", "+51987654321", true},
+		{"North American ten digits", "(512) 555-0100", "US", "+15125550100", true},
+		{"North American with leading 1", "1-512-555-0100", "US", "+15125550100", true},
+		{"extension dropped", "+1 512 555 0100 ext. 42", "", "+15125550100", true},
+		{"national without a country", "987 654 321", "", "", false},
+		{"North American too short", "555-0100", "US", "", false},
+		{"unknown calling code", "+999 123 456 789", "", "", false},
+		{"vanity number", "1-800-FLOWERS", "US", "", false},
+		{"too long", "+51 987 654 321 123 456", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FormatPhone(tt.text, tt.country)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("FormatPhone(%q, %q) = %q, %v; want %q, %v", tt.text, tt.country, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image/jpeg"
+	"strings"
+	"unicode"
+
+	"github.com/gen2brain/go-fitz"
+)
+
+const (
+	// minPageTextChars is the least non-space text a page needs to be read from its text layer
+	minPageTextChars = 80
+
+	// minReadableRatio is the least share of letters, digits and punctuation in that text;
+	// broken font encodings produce symbol soup that is better read from the image
+	minReadableRatio = 0.8
+)
+
+// Page is one PDF page prepared for parsing: its text layer when usable,
+// otherwise a JPEG rendering
+type Page struct {
+	Number int    // 1-based
+	Text   string // Set when the text layer is usable
+	Image  []byte // Set for pages without a usable text layer, such as scanned ones
+}
+
+// IsScanned reports whether the page has to be read from its image
+func (p Page) IsScanned() bool {
+	return p.Image != nil
+}
+
+// ExtractPages reads the text layer of every page and renders only the pages
+// whose text layer is missing or unusable. Besides PDF it accepts the other
+// formats MuPDF lays out into pages, such as DOCX and EPUB.
+func ExtractPages(data []byte) ([]Page, error) {
+	doc, err := fitz.NewFromMemory(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document: %w", err)
+	}
+	defer doc.Close()
+
+	pageCount := doc.NumPage()
+	pages := make([]Page, 0, pageCount)
+
+	for i := 0; i < pageCount; i++ {
+		page := Page{Number: i + 1}
+
+		text, err := doc.Text(i)
+		if err == nil && HasUsableText(text) {
+			page.Text = strings.TrimSpace(text)
+			pages = append(pages, page)
+			continue
+		}
+
+		img, err := doc.Image(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render page %d: %w", i, err)
+		}
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+			return nil, fmt.Errorf("failed to encode page %d: %w", i, err)
+		}
+		page.Image = buf.Bytes()
+		pages = append(pages, page)
+	}
+
+	return pages, nil
+}
+
+// HasUsableText reports whether extracted page text is long and clean enough
+// to be parsed without looking at the page
+func HasUsableText(text string) bool {
+	total, readable := 0, 0
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		if r != unicode.ReplacementChar && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsPunct(r)) {
+			readable++
+		}
+	}
+
+	if total < minPageTextChars {
+		return false
+	}
+	return float64(readable)/float64(total) >= minReadableRatio
+}
@@ -0,0 +1,49 @@
+-- ============================================================================
+-- Recruitment: Resume Search Filters
+-- ============================================================================
+
+-- ============================================================================
+-- HELPER FUNCTIONS
+-- ============================================================================
+
+-- Rank a free-text language proficiency (0 = unknown, 1 = basic ... 5 = native)
+-- Must stay in sync with resume.LanguageProficiencyRank
+CREATE OR REPLACE FUNCTION language_proficiency_rank(proficiency TEXT)
+RETURNS INTEGER AS $$
+BEGIN
+    RETURN CASE
+        WHEN proficiency ~* 'native|nativo|bilingual|biling[uü]e|mother tongue|materna' THEN 5
+        WHEN proficiency ~* 'fluent|fluido|fluency|advanced|avanzado|\yc1\y|\yc2\y' THEN 4
+        WHEN proficiency ~* 'professional|profesional|upper|\yb2\y' THEN 3
+        WHEN proficiency ~* 'intermediate|intermedio|conversational|\yb1\y' THEN 2
+        WHEN proficiency ~* 'basic|b[aá]sico|beginner|elementary|principiante|\ya1\y|\ya2\y' THEN 1
+        ELSE 0
+    END;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Rank a degree name (0 = unknown, 1 = high school ... 3 = bachelor, 4 = master, 5 = doctorate)
+-- Must stay in sync with resume.EducationLevelRank
+CREATE OR REPLACE FUNCTION education_level_rank(degree TEXT)
+RETURNS INTEGER AS $$
+BEGIN
+    RETURN CASE
+        WHEN degree ~* 'ph\.?\s?d|doctor|doctorado' THEN 5
+        WHEN degree ~* 'master|maestr[ií]a|mag[ií]ster|\ymba\y|\ym\.?sc?\y|\ym\.?a\y' THEN 4
+        WHEN degree ~* 'bachelor|bachiller|licenciad|licenciatura|ingenier|engineer|undergraduate|\yb\.?sc?\y|\yb\.?a\y' THEN 3
+        WHEN degree ~* 'associate|t[eé]cnico|technical|technician|diploma' THEN 2
+        WHEN degree ~* 'high[ _-]?school|secondary|secundaria' THEN 1
+        ELSE 0
+    END;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Highest education level rank across all education entries
+CREATE OR REPLACE FUNCTION highest_education_rank(education_json JSONB)
+RETURNS INTEGER AS $$
+    SELECT COALESCE(MAX(education_level_rank(edu->>'degree')), 0)
+    FROM jsonb_array_elements(education_json) edu;
+$$ LANGUAGE sql IMMUTABLE;
+
+COMMENT ON FUNCTION language_proficiency_rank(TEXT) IS 'Language proficiency ladder used by search filters';
+COMMENT ON FUNCTION education_level_rank(TEXT) IS 'Education ladder used by search filters: bachelor < master < PhD';
@@ -0,0 +1,43 @@
+-- ============================================================================
+-- Recruitment: Resume Full-Text Search
+-- ============================================================================
+
+-- Build the keyword search document of a resume.
+-- Uses the 'simple' configuration so exact tokens (e.g. "kubernetes",
+-- certification IDs) are kept as-is, regardless of the resume language.
+CREATE OR REPLACE FUNCTION resume_search_document(
+    resume_title TEXT,
+    summary TEXT,
+    work_exp JSONB,
+    skills_json JSONB,
+    certs JSONB
+)
+RETURNS tsvector AS $$
+    SELECT
+        setweight(to_tsvector('simple', COALESCE(resume_title, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(
+            (SELECT string_agg(w->>'title', ' ') FROM jsonb_array_elements(work_exp) w), ''
+        )), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(
+            array_to_string(extract_all_skills(skills_json), ' '), ''
+        )), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(
+            (SELECT string_agg(
+                CASE jsonb_typeof(c)
+                    WHEN 'string' THEN c #>> '{}'
+                    ELSE concat_ws(' ', c->>'name', c->>'issuer', c->>'credential_id')
+                END, ' ')
+             FROM jsonb_array_elements(certs) c), ''
+        )), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(summary, '')), 'B');
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE resumes
+    ADD COLUMN search_document tsvector
+    GENERATED ALWAYS AS (
+        resume_search_document(title, professional_summary, work_experience, skills, certifications)
+    ) STORED;
+
+CREATE INDEX idx_resumes_search_document ON resumes USING gin (search_document);
+
+COMMENT ON COLUMN resumes.search_document IS 'Full-text document over title, job titles, skills, certifications and summary';
@@ -0,0 +1,103 @@
+-- ============================================================================
+-- Recruitment: Embedding Model Migrations
+-- ============================================================================
+
+-- Vectors of several models may now live side by side, one row per model.
+ALTER TABLE resume_embeddings DROP CONSTRAINT uq_resume_embeddings_resume;
+ALTER TABLE resume_embeddings
+    ADD CONSTRAINT uq_resume_embeddings_resume_model UNIQUE (resume_id, model_used);
+
+-- Drop the fixed 1536 size so models with other dimensions fit in the same columns.
+DROP INDEX IF EXISTS idx_resume_embeddings_experience;
+DROP INDEX IF EXISTS idx_resume_embeddings_education;
+DROP INDEX IF EXISTS idx_resume_embeddings_skills;
+DROP INDEX IF EXISTS idx_resume_embeddings_languages;
+DROP INDEX IF EXISTS idx_resume_embeddings_personal_statement;
+
+ALTER TABLE resume_embeddings
+    ALTER COLUMN experience_embedding TYPE vector,
+    ALTER COLUMN education_embedding TYPE vector,
+    ALTER COLUMN skills_embedding TYPE vector,
+    ALTER COLUMN languages_embedding TYPE vector,
+    ALTER COLUMN personal_statement_embedding TYPE vector;
+
+CREATE INDEX IF NOT EXISTS idx_resume_embeddings_model ON resume_embeddings(model_used, embedding_dim);
+
+-- HNSW needs a fixed dimension, so each dimension in use gets partial
+-- expression indexes. Queries must cast to vector(dim) and filter on
+-- embedding_dim for the planner to pick them up.
+CREATE OR REPLACE FUNCTION ensure_resume_embedding_indexes(dim INTEGER)
+RETURNS VOID AS $$
+DECLARE
+    section TEXT;
+BEGIN
+    IF dim < 1 OR dim > 2000 THEN
+        RAISE NOTICE 'no HNSW index for % dimensions', dim;
+        RETURN;
+    END IF;
+
+    FOREACH section IN ARRAY ARRAY['experience', 'education', 'skills', 'languages', 'personal_statement']
+    LOOP
+        EXECUTE format(
+            'CREATE INDEX IF NOT EXISTS %I ON resume_embeddings USING hnsw ((%I::vector(%s)) vector_cosine_ops) WHERE embedding_dim = %s',
+            'idx_resume_embeddings_' || section || '_' || dim,
+            section || '_embedding',
+            dim,
+            dim
+        );
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT ensure_resume_embedding_indexes(1536);
+
+-- ============================================================================
+-- ACTIVE MODEL PER TENANT
+-- ============================================================================
+
+-- Tenants without a row use the configured default model.
+CREATE TABLE resume_embedding_models (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    active_model VARCHAR(100) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_resume_embedding_models_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+-- ============================================================================
+-- RE-INDEX JOBS
+-- ============================================================================
+
+CREATE TABLE embedding_migrations (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    source_model VARCHAR(100) NOT NULL,
+    target_model VARCHAR(100) NOT NULL,
+    target_dim INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+
+    heartbeat_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    cutover_at TIMESTAMP,
+    cleaned_at TIMESTAMP,
+
+    CONSTRAINT fk_embedding_migrations_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE,
+    CONSTRAINT chk_embedding_migrations_status CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cutover', 'cleaned'))
+);
+
+CREATE INDEX idx_embedding_migrations_tenant ON embedding_migrations(tenant_id, created_at DESC);
+CREATE INDEX idx_embedding_migrations_status ON embedding_migrations(status, created_at);
+
+-- At most one migration writing vectors per tenant
+CREATE UNIQUE INDEX uq_embedding_migrations_in_progress
+    ON embedding_migrations(tenant_id) WHERE status IN ('pending', 'running');
+
+COMMENT ON TABLE resume_embedding_models IS 'Embedding model searched for each tenant; missing rows use the default model';
+COMMENT ON TABLE embedding_migrations IS 'Background re-embedding of a tenant''s resumes with a new model';
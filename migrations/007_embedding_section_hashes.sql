@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Recruitment: Embedding Section Hashes
+-- ============================================================================
+
+-- Content hash of the text each section vector was generated from, keyed by
+-- section name. Unchanged sections are not re-embedded.
+ALTER TABLE resume_embeddings
+    ADD COLUMN section_hashes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN resume_embeddings.section_hashes IS 'Section name -> SHA-256 of the embedded text';
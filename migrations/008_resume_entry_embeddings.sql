@@ -0,0 +1,64 @@
+-- ============================================================================
+-- Recruitment: Per-Entry Embeddings
+-- ============================================================================
+
+-- One vector per work experience and project, so search can score a resume
+-- by its best-matching position instead of a blend of all of them.
+-- Resumes without rows here fall back to the combined experience vector
+-- until their embeddings are regenerated.
+CREATE TABLE resume_entry_embeddings (
+    id VARCHAR(255) PRIMARY KEY DEFAULT uuid_generate_v4()::text,
+    resume_id VARCHAR(255) NOT NULL,
+    entry_type VARCHAR(30) NOT NULL,
+    entry_index INTEGER NOT NULL,           -- Position in work_experience or projects
+    title TEXT NOT NULL DEFAULT '',
+    organization TEXT NOT NULL DEFAULT '',
+
+    embedding vector NOT NULL,
+    model_used VARCHAR(100) NOT NULL,
+    embedding_dim INTEGER NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    generated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_resume_entry_embeddings_resume FOREIGN KEY (resume_id) REFERENCES resumes(id) ON DELETE CASCADE,
+    CONSTRAINT uq_resume_entry_embeddings_entry UNIQUE (resume_id, model_used, entry_type, entry_index),
+    CONSTRAINT chk_resume_entry_embeddings_type CHECK (entry_type IN ('work_experience', 'project'))
+);
+
+CREATE INDEX idx_resume_entry_embeddings_resume ON resume_entry_embeddings(resume_id, model_used);
+
+-- Extend the per-dimension vector indexes to entry embeddings
+CREATE OR REPLACE FUNCTION ensure_resume_embedding_indexes(dim INTEGER)
+RETURNS VOID AS $$
+DECLARE
+    section TEXT;
+BEGIN
+    IF dim < 1 OR dim > 2000 THEN
+        RAISE NOTICE 'no HNSW index for % dimensions', dim;
+        RETURN;
+    END IF;
+
+    FOREACH section IN ARRAY ARRAY['experience', 'education', 'skills', 'languages', 'personal_statement']
+    LOOP
+        EXECUTE format(
+            'CREATE INDEX IF NOT EXISTS %I ON resume_embeddings USING hnsw ((%I::vector(%s)) vector_cosine_ops) WHERE embedding_dim = %s',
+            'idx_resume_embeddings_' || section || '_' || dim,
+            section || '_embedding',
+            dim,
+            dim
+        );
+    END LOOP;
+
+    EXECUTE format(
+        'CREATE INDEX IF NOT EXISTS %I ON resume_entry_embeddings USING hnsw ((embedding::vector(%s)) vector_cosine_ops) WHERE embedding_dim = %s',
+        'idx_resume_entry_embeddings_' || dim,
+        dim,
+        dim
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT ensure_resume_embedding_indexes(dim)
+FROM (SELECT 1536 AS dim UNION SELECT DISTINCT embedding_dim FROM resume_embeddings) dims;
+
+COMMENT ON TABLE resume_entry_embeddings IS 'Embedding of each work experience and project entry, per model';
@@ -0,0 +1,160 @@
+-- ============================================================================
+-- Recruitment: Skill Taxonomy
+-- ============================================================================
+
+-- Comparison key of a skill name: lowercase with collapsed whitespace.
+-- Must stay in sync with skill.Normalize.
+CREATE OR REPLACE FUNCTION normalize_skill_name(name TEXT)
+RETURNS TEXT AS $$
+    SELECT lower(btrim(regexp_replace(name, '\s+', ' ', 'g')));
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Normalized, de-duplicated hard and soft skill names of a resume
+CREATE OR REPLACE FUNCTION extract_normalized_skills(skills_json JSONB)
+RETURNS TEXT[] AS $$
+    SELECT COALESCE(array_agg(DISTINCT normalize_skill_name(s)), '{}')
+    FROM unnest(extract_all_skills(skills_json)) s
+    WHERE s IS NOT NULL AND btrim(s) <> '';
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Required and preferred skills are matched with && against their expansions
+CREATE INDEX idx_resumes_normalized_skills ON resumes USING GIN (extract_normalized_skills(skills));
+
+-- ============================================================================
+-- TAXONOMY
+-- ============================================================================
+
+-- Global entries have no tenant. A tenant entry with the same name as a
+-- global one overrides it for that tenant. Parents are referenced by
+-- canonical name so tenant entries can hang under global ones.
+CREATE TABLE skill_taxonomy (
+    id VARCHAR(255) PRIMARY KEY DEFAULT uuid_generate_v4()::text,
+    tenant_id VARCHAR(255),
+    name VARCHAR(100) NOT NULL,
+    normalized_name VARCHAR(100) NOT NULL,
+    parent VARCHAR(100),
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_skill_taxonomy_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX uq_skill_taxonomy_name ON skill_taxonomy (COALESCE(tenant_id, ''), normalized_name);
+CREATE INDEX idx_skill_taxonomy_tenant ON skill_taxonomy(tenant_id);
+
+COMMENT ON TABLE skill_taxonomy IS 'Canonical skill names with aliases and parent categories; global rows have no tenant';
+
+-- ============================================================================
+-- SEED DATA
+-- ============================================================================
+
+-- Search queries are matched against names and aliases word by word, and a match
+-- becomes a required skill, so common words ('Cloud', 'Next', 'Spring') and
+-- ambiguous short forms ('TS', 'ML') are not seeded as aliases. A skill accepts
+-- all its descendants, so each entry stays under its own category: Node.js is
+-- Backend, not a JavaScript framework under Frontend.
+
+INSERT INTO skill_taxonomy (tenant_id, name, normalized_name, parent, aliases)
+SELECT NULL, seed.name, normalize_skill_name(seed.name), seed.parent, seed.aliases
+FROM (VALUES
+    -- Frontend
+    ('Frontend', NULL::TEXT, ARRAY['Front-end', 'Front End', 'Frontend Development']::TEXT[]),
+    ('JavaScript', 'Frontend', ARRAY['JS', 'ECMAScript', 'ES6', 'ES2015']),
+    ('TypeScript', 'JavaScript', ARRAY[]::TEXT[]),
+    ('React', 'JavaScript', ARRAY['ReactJS', 'React.js']),
+    ('Redux', 'React', ARRAY['Redux Toolkit']),
+    ('Next.js', 'React', ARRAY['NextJS']),
+    ('Vue.js', 'JavaScript', ARRAY['Vue', 'VueJS']),
+    ('Nuxt.js', 'Vue.js', ARRAY['Nuxt', 'NuxtJS']),
+    ('Angular', 'TypeScript', ARRAY['Angular 2+', 'Angular2']),
+    ('Svelte', 'JavaScript', ARRAY['Svelte.js', 'SvelteKit']),
+    ('jQuery', 'JavaScript', ARRAY[]::TEXT[]),
+    ('HTML', 'Frontend', ARRAY['HTML5']),
+    ('CSS', 'Frontend', ARRAY['CSS3']),
+    ('Sass', 'CSS', ARRAY['SCSS']),
+    ('Tailwind CSS', 'CSS', ARRAY['Tailwind', 'TailwindCSS']),
+
+    -- Backend
+    ('Backend', NULL, ARRAY['Back-end', 'Back End', 'Backend Development']),
+    ('Node.js', 'Backend', ARRAY['NodeJS']),
+    ('Express', 'Node.js', ARRAY['Express.js', 'ExpressJS']),
+    ('NestJS', 'Node.js', ARRAY['Nest.js']),
+    ('Python', 'Backend', ARRAY['Python3', 'Python 3']),
+    ('Django', 'Python', ARRAY['Django REST Framework', 'DRF']),
+    ('Flask', 'Python', ARRAY[]::TEXT[]),
+    ('FastAPI', 'Python', ARRAY['Fast API']),
+    ('Java', 'Backend', ARRAY['Java SE', 'Java EE', 'J2EE']),
+    ('Spring Boot', 'Java', ARRAY['SpringBoot', 'Spring Framework']),
+    ('Go', 'Backend', ARRAY['Golang']),
+    ('C#', 'Backend', ARRAY['CSharp', 'C Sharp']),
+    ('.NET', 'C#', ARRAY['dotnet', '.NET Core', 'ASP.NET', 'ASP.NET Core']),
+    ('Ruby', 'Backend', ARRAY[]::TEXT[]),
+    ('Ruby on Rails', 'Ruby', ARRAY['RoR']),
+    ('PHP', 'Backend', ARRAY[]::TEXT[]),
+    ('Laravel', 'PHP', ARRAY[]::TEXT[]),
+    ('Rust', 'Backend', ARRAY[]::TEXT[]),
+    ('C++', 'Backend', ARRAY['CPP']),
+    ('GraphQL', 'Backend', ARRAY[]::TEXT[]),
+    ('REST APIs', 'Backend', ARRAY['RESTful', 'RESTful APIs', 'REST API']),
+
+    -- Mobile
+    ('Mobile Development', NULL, ARRAY['Mobile Apps']),
+    ('iOS', 'Mobile Development', ARRAY['iOS Development']),
+    ('Swift', 'iOS', ARRAY['SwiftUI']),
+    ('Android', 'Mobile Development', ARRAY['Android Development']),
+    ('Kotlin', 'Android', ARRAY[]::TEXT[]),
+    ('React Native', 'Mobile Development', ARRAY['ReactNative']),
+    ('Flutter', 'Mobile Development', ARRAY[]::TEXT[]),
+    ('Dart', 'Flutter', ARRAY[]::TEXT[]),
+
+    -- Data
+    ('Data Science', NULL, ARRAY[]::TEXT[]),
+    ('Machine Learning', 'Data Science', ARRAY[]::TEXT[]),
+    ('Deep Learning', 'Machine Learning', ARRAY[]::TEXT[]),
+    ('TensorFlow', 'Deep Learning', ARRAY[]::TEXT[]),
+    ('PyTorch', 'Deep Learning', ARRAY[]::TEXT[]),
+    ('scikit-learn', 'Machine Learning', ARRAY['sklearn', 'scikit learn']),
+    ('Natural Language Processing', 'Machine Learning', ARRAY['NLP']),
+    ('Pandas', 'Data Science', ARRAY[]::TEXT[]),
+    ('NumPy', 'Data Science', ARRAY[]::TEXT[]),
+    ('Data Analysis', 'Data Science', ARRAY['Data Analytics']),
+    ('Power BI', 'Data Analysis', ARRAY['PowerBI']),
+    ('Tableau', 'Data Analysis', ARRAY[]::TEXT[]),
+    ('Excel', 'Data Analysis', ARRAY['Microsoft Excel', 'MS Excel']),
+
+    -- Databases
+    ('Databases', NULL, ARRAY['Database Management']),
+    ('SQL', 'Databases', ARRAY[]::TEXT[]),
+    ('PostgreSQL', 'SQL', ARRAY['Postgres', 'PSQL']),
+    ('MySQL', 'SQL', ARRAY['MariaDB']),
+    ('SQL Server', 'SQL', ARRAY['MSSQL', 'Microsoft SQL Server', 'T-SQL']),
+    ('Oracle Database', 'SQL', ARRAY['Oracle DB', 'PL/SQL']),
+    ('MongoDB', 'Databases', ARRAY['Mongo']),
+    ('Redis', 'Databases', ARRAY[]::TEXT[]),
+    ('Elasticsearch', 'Databases', ARRAY['Elastic Search', 'OpenSearch']),
+
+    -- DevOps & Cloud
+    ('DevOps', NULL, ARRAY[]::TEXT[]),
+    ('Docker', 'DevOps', ARRAY[]::TEXT[]),
+    ('Kubernetes', 'DevOps', ARRAY['K8s']),
+    ('Terraform', 'DevOps', ARRAY[]::TEXT[]),
+    ('CI/CD', 'DevOps', ARRAY['CICD', 'Continuous Integration', 'Continuous Delivery', 'Continuous Deployment']),
+    ('Jenkins', 'CI/CD', ARRAY[]::TEXT[]),
+    ('GitHub Actions', 'CI/CD', ARRAY[]::TEXT[]),
+    ('Git', NULL, ARRAY['Version Control']),
+    ('Linux', NULL, ARRAY['Unix']),
+    ('Cloud Computing', NULL, ARRAY[]::TEXT[]),
+    ('AWS', 'Cloud Computing', ARRAY['Amazon Web Services']),
+    ('Azure', 'Cloud Computing', ARRAY['Microsoft Azure']),
+    ('Google Cloud', 'Cloud Computing', ARRAY['GCP', 'Google Cloud Platform']),
+
+    -- Soft skills & practices
+    ('Communication', NULL, ARRAY['Communication Skills', 'Comunicación']),
+    ('Leadership', NULL, ARRAY['Team Leadership', 'Liderazgo']),
+    ('Teamwork', NULL, ARRAY['Team Work', 'Collaboration', 'Trabajo en equipo']),
+    ('Problem Solving', NULL, ARRAY['Problem-Solving', 'Resolución de problemas']),
+    ('Project Management', NULL, ARRAY['Gestión de proyectos']),
+    ('Agile', NULL, ARRAY['Agile Methodologies', 'Metodologías ágiles']),
+    ('Scrum', 'Agile', ARRAY[]::TEXT[])
+) AS seed(name, parent, aliases);
@@ -0,0 +1,52 @@
+-- ============================================================================
+-- Recruitment: Duplicate Detection & Resume Merge
+-- ============================================================================
+
+-- Comparison keys of contact details. Each returns NULL when there is
+-- nothing reliable to compare, so empty values never match.
+CREATE OR REPLACE FUNCTION normalize_email(email TEXT)
+RETURNS TEXT AS $$
+    SELECT NULLIF(lower(btrim(email)), '');
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Last 9 digits, so "+51 999 888 777" and "999-888-777" match
+CREATE OR REPLACE FUNCTION normalize_phone(phone TEXT)
+RETURNS TEXT AS $$
+    SELECT CASE
+        WHEN length(regexp_replace(COALESCE(phone, ''), '\D', '', 'g')) < 7 THEN NULL
+        ELSE right(regexp_replace(phone, '\D', '', 'g'), 9)
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Profile slug of a LinkedIn URL ("https://www.linkedin.com/in/Jane-Doe/?x=1" -> "jane-doe")
+CREATE OR REPLACE FUNCTION normalize_linkedin(url TEXT)
+RETURNS TEXT AS $$
+    SELECT NULLIF(substring(lower(COALESCE(url, '')) FROM 'linkedin\.com/in/([^/?#]+)'), '');
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX idx_resumes_email_key ON resumes(tenant_id, normalize_email(personal_info->>'email'));
+CREATE INDEX idx_resumes_phone_key ON resumes(tenant_id, normalize_phone(personal_info->>'phone'));
+CREATE INDEX idx_resumes_linkedin_key ON resumes(tenant_id, normalize_linkedin(personal_info->>'linkedin'));
+
+-- ============================================================================
+-- MERGE PROVENANCE
+-- ============================================================================
+
+CREATE TABLE resume_merges (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    survivor_id VARCHAR(255) NOT NULL,
+    merged_resume_ids TEXT[] NOT NULL,
+    field_sources JSONB NOT NULL DEFAULT '{}',  -- Field -> resume the value was taken from
+    merged_resumes JSONB NOT NULL DEFAULT '[]', -- Snapshots of the deleted resumes
+    moved_jobs INTEGER NOT NULL DEFAULT 0,
+    merged_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_resume_merges_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE,
+    CONSTRAINT fk_resume_merges_survivor FOREIGN KEY (survivor_id) REFERENCES resumes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_resume_merges_survivor ON resume_merges(survivor_id, created_at DESC);
+
+COMMENT ON TABLE resume_merges IS 'Provenance of duplicate resumes folded into a surviving resume';
@@ -0,0 +1,55 @@
+-- ============================================================================
+-- Recruitment: Saved Searches & New-Match Notifications
+-- ============================================================================
+
+CREATE TABLE saved_searches (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    request JSONB NOT NULL,                  -- SearchResumesRequest as submitted (after interpretation)
+    min_score DOUBLE PRECISION NOT NULL DEFAULT 0.75,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    email_alerts BOOLEAN NOT NULL DEFAULT FALSE,
+    last_match_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_saved_searches_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE,
+    CONSTRAINT fk_saved_searches_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_saved_searches_min_score CHECK (min_score >= 0 AND min_score <= 1)
+);
+
+CREATE INDEX idx_saved_searches_user ON saved_searches(tenant_id, user_id, created_at DESC);
+CREATE INDEX idx_saved_searches_alerting ON saved_searches(tenant_id) WHERE alerts_enabled;
+
+COMMENT ON TABLE saved_searches IS 'Searches recruiters keep to re-run and to be alerted about new matching resumes';
+
+-- ============================================================================
+-- NOTIFICATIONS INBOX
+-- ============================================================================
+
+CREATE TABLE saved_search_notifications (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    saved_search_id VARCHAR(255) NOT NULL,
+    saved_search_name VARCHAR(255) NOT NULL,
+    resume_id VARCHAR(255) NOT NULL,
+    candidate_name TEXT NOT NULL DEFAULT '',
+    resume_title TEXT NOT NULL DEFAULT '',
+    score DOUBLE PRECISION NOT NULL,
+    matched_skills TEXT[] NOT NULL DEFAULT '{}',
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_saved_search_notifications_search FOREIGN KEY (saved_search_id) REFERENCES saved_searches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_saved_search_notifications_resume FOREIGN KEY (resume_id) REFERENCES resumes(id) ON DELETE CASCADE,
+    -- A resume alerts each search at most once
+    CONSTRAINT uq_saved_search_notifications_match UNIQUE (saved_search_id, resume_id)
+);
+
+CREATE INDEX idx_saved_search_notifications_user ON saved_search_notifications(tenant_id, user_id, created_at DESC);
+CREATE INDEX idx_saved_search_notifications_unread ON saved_search_notifications(tenant_id, user_id) WHERE read_at IS NULL;
+
+COMMENT ON TABLE saved_search_notifications IS 'Inbox of new resumes that matched a user''s saved searches';
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- Keyset Pagination
+-- ============================================================================
+
+-- Lists page by (created_at, id) from a cursor; these indexes resolve the cursor
+-- position directly instead of scanning past every earlier row like OFFSET does.
+CREATE INDEX idx_resumes_tenant_keyset ON resumes(tenant_id, created_at DESC, id DESC);
+CREATE INDEX idx_resumes_keyset ON resumes(created_at DESC, id DESC);
+CREATE INDEX idx_resume_jobs_tenant_keyset ON resume_processing_jobs(tenant_id, created_at DESC, id DESC);
+
+-- Superseded by the keyset indexes above
+DROP INDEX IF EXISTS idx_resumes_created_at;
+DROP INDEX IF EXISTS idx_resume_jobs_created_at;
@@ -0,0 +1,136 @@
+-- ============================================================================
+-- Recruitment: Vector Index Tuning and Compressed Storage
+-- ============================================================================
+
+-- HNSW parameters and the type section vectors are stored as. Single row;
+-- change it through configure_resume_vector_indexes() so the vector columns
+-- and indexes are converted to match.
+CREATE TABLE vector_index_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE,
+    storage VARCHAR(10) NOT NULL DEFAULT 'vector',  -- vector (4 bytes per dimension) or halfvec (2 bytes)
+    m INTEGER NOT NULL DEFAULT 16,                  -- Links per graph node: higher recall, larger indexes
+    ef_construction INTEGER NOT NULL DEFAULT 64,    -- Candidates kept while building: higher recall, slower builds
+    ef_search INTEGER NOT NULL DEFAULT 40,          -- Candidates kept while searching, unless a search sets its own
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_vector_index_settings_single CHECK (id),
+    CONSTRAINT chk_vector_index_settings_storage CHECK (storage IN ('vector', 'halfvec')),
+    CONSTRAINT chk_vector_index_settings_m CHECK (m BETWEEN 2 AND 100),
+    CONSTRAINT chk_vector_index_settings_ef_construction CHECK (ef_construction BETWEEN 4 AND 1000 AND ef_construction >= 2 * m),
+    CONSTRAINT chk_vector_index_settings_ef_search CHECK (ef_search BETWEEN 1 AND 1000)
+);
+
+INSERT INTO vector_index_settings DEFAULT VALUES;
+
+-- Build the indexes of a dimension with the configured storage and parameters.
+-- halfvec indexes take up to 4000 dimensions, vector indexes up to 2000.
+CREATE OR REPLACE FUNCTION ensure_resume_embedding_indexes(dim INTEGER)
+RETURNS VOID AS $$
+DECLARE
+    settings vector_index_settings%ROWTYPE;
+    section TEXT;
+BEGIN
+    SELECT * INTO settings FROM vector_index_settings;
+
+    IF dim < 1 OR dim > CASE settings.storage WHEN 'halfvec' THEN 4000 ELSE 2000 END THEN
+        RAISE NOTICE 'no HNSW index for % dimensions with % storage', dim, settings.storage;
+        RETURN;
+    END IF;
+
+    FOREACH section IN ARRAY ARRAY['experience', 'education', 'skills', 'languages', 'personal_statement']
+    LOOP
+        EXECUTE format(
+            'CREATE INDEX IF NOT EXISTS %I ON resume_embeddings USING hnsw ((%I::%s(%s)) %s_cosine_ops) WITH (m = %s, ef_construction = %s) WHERE embedding_dim = %s',
+            'idx_resume_embeddings_' || section || '_' || dim,
+            section || '_embedding',
+            settings.storage,
+            dim,
+            settings.storage,
+            settings.m,
+            settings.ef_construction,
+            dim
+        );
+    END LOOP;
+
+    EXECUTE format(
+        'CREATE INDEX IF NOT EXISTS %I ON resume_entry_embeddings USING hnsw ((embedding::%s(%s)) %s_cosine_ops) WITH (m = %s, ef_construction = %s) WHERE embedding_dim = %s',
+        'idx_resume_entry_embeddings_' || dim,
+        settings.storage,
+        dim,
+        settings.storage,
+        settings.m,
+        settings.ef_construction,
+        dim
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- Change the vector settings; NULL keeps the current value. A new storage
+-- converts every stored vector, and a new storage, m or ef_construction rebuilds
+-- every vector index. Search runs unindexed until the rebuild commits, so large
+-- installations should run it in a maintenance window, e.g.
+--   SELECT configure_resume_vector_indexes(new_storage => 'halfvec');
+--   SELECT configure_resume_vector_indexes(new_m => 24, new_ef_construction => 128);
+--   SELECT configure_resume_vector_indexes(new_ef_search => 100);
+CREATE OR REPLACE FUNCTION configure_resume_vector_indexes(
+    new_storage TEXT DEFAULT NULL,
+    new_m INTEGER DEFAULT NULL,
+    new_ef_construction INTEGER DEFAULT NULL,
+    new_ef_search INTEGER DEFAULT NULL
+)
+RETURNS VOID AS $$
+DECLARE
+    previous vector_index_settings%ROWTYPE;
+    current vector_index_settings%ROWTYPE;
+    index_name TEXT;
+    dim INTEGER;
+BEGIN
+    SELECT * INTO previous FROM vector_index_settings FOR UPDATE;
+
+    UPDATE vector_index_settings SET
+        storage = COALESCE(new_storage, storage),
+        m = COALESCE(new_m, m),
+        ef_construction = COALESCE(new_ef_construction, ef_construction),
+        ef_search = COALESCE(new_ef_search, ef_search),
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING * INTO current;
+
+    IF current.storage = previous.storage
+        AND current.m = previous.m
+        AND current.ef_construction = previous.ef_construction THEN
+        RETURN;
+    END IF;
+
+    FOR index_name IN
+        SELECT indexname FROM pg_indexes
+        WHERE tablename IN ('resume_embeddings', 'resume_entry_embeddings')
+            AND indexdef LIKE '%USING hnsw%'
+    LOOP
+        EXECUTE format('DROP INDEX IF EXISTS %I', index_name);
+    END LOOP;
+
+    IF current.storage <> previous.storage THEN
+        EXECUTE format(
+            'ALTER TABLE resume_embeddings
+                ALTER COLUMN experience_embedding TYPE %1$s USING experience_embedding::%1$s,
+                ALTER COLUMN education_embedding TYPE %1$s USING education_embedding::%1$s,
+                ALTER COLUMN skills_embedding TYPE %1$s USING skills_embedding::%1$s,
+                ALTER COLUMN languages_embedding TYPE %1$s USING languages_embedding::%1$s,
+                ALTER COLUMN personal_statement_embedding TYPE %1$s USING personal_statement_embedding::%1$s',
+            current.storage
+        );
+        EXECUTE format(
+            'ALTER TABLE resume_entry_embeddings ALTER COLUMN embedding TYPE %1$s USING embedding::%1$s',
+            current.storage
+        );
+    END IF;
+
+    FOR dim IN
+        SELECT 1536 UNION SELECT DISTINCT embedding_dim FROM resume_embeddings
+    LOOP
+        PERFORM ensure_resume_embedding_indexes(dim);
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE vector_index_settings IS 'HNSW parameters and vector storage; change through configure_resume_vector_indexes()';
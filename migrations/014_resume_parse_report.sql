@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Recruitment: Resume Parse Report
+-- ============================================================================
+
+-- How each uploaded file was read: from the PDF text layer, from page images,
+-- or both. NULL for resumes created without a file.
+ALTER TABLE resumes ADD COLUMN parse_report JSONB;
+
+COMMENT ON COLUMN resumes.parse_report IS 'Parse method and the pages sent to the vision model, for auditing';
@@ -0,0 +1,14 @@
+-- ============================================================================
+-- Recruitment: Parse Confidence and Review Flag
+-- ============================================================================
+
+-- The parser's confidence in each field and the page it came from are kept in
+-- parse_report (fields, low_confidence). Resumes with any field below the review
+-- threshold are flagged until a recruiter clears the flag.
+ALTER TABLE resumes ADD COLUMN needs_review BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Review queue per tenant, newest first like the resume list
+CREATE INDEX idx_resumes_needs_review ON resumes(tenant_id, created_at DESC, id DESC) WHERE needs_review;
+
+COMMENT ON COLUMN resumes.needs_review IS 'Parser was unsure of at least one field; cleared once reviewed';
+COMMENT ON COLUMN resumes.parse_report IS 'Parse method, pages sent to the vision model, and per-field confidence and source page, for auditing';
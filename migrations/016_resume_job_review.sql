@@ -0,0 +1,26 @@
+-- ============================================================================
+-- Recruitment: Review of Parsed Resumes
+-- ============================================================================
+
+-- Tenants can have parsed resumes wait for a reviewer (tenant setting
+-- resume.parse.review). The job then ends in awaiting_review with the parsed
+-- resume kept as a draft; the resume is only created when a reviewer approves it.
+ALTER TABLE resume_processing_jobs
+    ADD COLUMN draft JSONB NULL,
+    ADD COLUMN reviewed_by VARCHAR(255) NULL,
+    ADD COLUMN reviewed_at TIMESTAMP NULL,
+    ADD COLUMN review_note TEXT NOT NULL DEFAULT '',
+    ADD CONSTRAINT fk_resume_processing_reviewed_by FOREIGN KEY (reviewed_by)
+        REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE resume_processing_jobs DROP CONSTRAINT chk_status;
+ALTER TABLE resume_processing_jobs ADD CONSTRAINT chk_status
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'awaiting_review', 'rejected'));
+
+-- Review queue per tenant, oldest drafts are usually handled first
+CREATE INDEX idx_resume_jobs_awaiting_review ON resume_processing_jobs(tenant_id, created_at) WHERE status = 'awaiting_review';
+
+COMMENT ON COLUMN resume_processing_jobs.status IS 'Job status: pending, processing, completed, failed, awaiting_review, rejected';
+COMMENT ON COLUMN resume_processing_jobs.current_step IS 'Current processing step: uploading, parsing, embedding, saving, review';
+COMMENT ON COLUMN resume_processing_jobs.draft IS 'Parsed resume awaiting review; cleared once approved or rejected';
+COMMENT ON COLUMN resume_processing_jobs.review_note IS 'Reason given when the draft was rejected';
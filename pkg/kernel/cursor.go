@@ -0,0 +1,199 @@
+package kernel
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// SortDirection is the order of a keyset-paginated list
+type SortDirection string
+
+const (
+	SortDesc SortDirection = "desc"
+	SortAsc  SortDirection = "asc"
+)
+
+// IsValid checks if the sort direction is supported
+func (d SortDirection) IsValid() bool {
+	return d == SortDesc || d == SortAsc
+}
+
+// Reverse returns the opposite direction
+func (d SortDirection) Reverse() SortDirection {
+	if d == SortAsc {
+		return SortDesc
+	}
+	return SortAsc
+}
+
+// ErrInvalidCursor is returned for cursors that were not issued by NewCursor
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor marks a position in a keyset-ordered list: the sort key and ID of the item
+// at the edge of a page. Unlike an offset it keeps pointing at the same place when
+// items are added or removed before it, and it can be resolved through an index.
+// Clients receive it encoded and opaque.
+type Cursor struct {
+	SortKey   string        `json:"k"`           // Sort value of the edge item
+	ID        string        `json:"id"`          // Tie-breaker between items with the same sort value
+	Direction SortDirection `json:"d"`           // Order of the list the cursor was issued for
+	Before    bool          `json:"b,omitempty"` // Reads the page before the position instead of after it
+	Token     string        `json:"t,omitempty"` // Result set the position is in, for lists the endpoint keeps between pages
+}
+
+// NewCursor creates a cursor that reads the items after (or before) an item
+func NewCursor(sortKey, id string, direction SortDirection, before bool) Cursor {
+	return Cursor{SortKey: sortKey, ID: id, Direction: direction, Before: before}
+}
+
+// Encode returns the opaque form handed to clients
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(encoded string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" || !c.Direction.IsValid() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Ascending reports whether the rows to read come in ascending sort order,
+// which is the list order reversed when reading backwards
+func (c Cursor) Ascending() bool {
+	return (c.Direction == SortAsc) != c.Before
+}
+
+// TimeSortKey formats a timestamp sort key
+func TimeSortKey(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// TimeKey parses a timestamp sort key
+func (c Cursor) TimeKey() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.SortKey)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+// FloatSortKey formats a numeric sort key without losing precision
+func FloatSortKey(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// FloatKey parses a numeric sort key
+func (c Cursor) FloatKey() (float64, error) {
+	f, err := strconv.ParseFloat(c.SortKey, 64)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	return f, nil
+}
+
+// NewCursorPaginated builds a keyset page. Items must be in list order and hold at
+// most PageSize entries; hasMore reports whether another item was found past the
+// page in the reading direction (callers fetch PageSize+1 rows to know).
+// The next and previous cursors point at the last and first items of the page, in the
+// result set named by opts.Token if any.
+func NewCursorPaginated[T any](items []T, opts PaginationOptions, cursor *Cursor, total int, hasMore bool, key func(T) (sortKey, id string)) Paginated[T] {
+	direction := opts.SortDirection()
+	if cursor != nil {
+		direction = cursor.Direction
+	}
+
+	number := 0 // Unknown when paging by cursor
+	if cursor == nil {
+		number = max(opts.Page, 1)
+	}
+
+	paginated := NewPaginated(items, number, opts.PageSize, total)
+	if len(items) == 0 {
+		return paginated
+	}
+
+	hasNext, hasPrev := hasMore, cursor != nil || opts.Page > 1
+	if cursor != nil && cursor.Before {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		sortKey, id := key(items[len(items)-1])
+		next := NewCursor(sortKey, id, direction, false)
+		next.Token = opts.Token
+		paginated.Page.NextCursor = next.Encode()
+	}
+	if hasPrev {
+		sortKey, id := key(items[0])
+		prev := NewCursor(sortKey, id, direction, true)
+		prev.Token = opts.Token
+		paginated.Page.PrevCursor = prev.Encode()
+	}
+	return paginated
+}
+
+// PaginateSlice cuts a keyset page out of items that are already in list order.
+// compare reports where an item sits relative to the cursor position in that
+// order (negative before it, positive after it).
+func PaginateSlice[T any](items []T, opts PaginationOptions, key func(T) (sortKey, id string), compare func(item T, cursor Cursor) int) (Paginated[T], error) {
+	size := max(opts.PageSize, 1)
+
+	cursor, err := opts.DecodeCursor()
+	if err != nil {
+		return Paginated[T]{}, err
+	}
+
+	var start, end int
+	hasMore := false
+	switch {
+	case cursor == nil:
+		start = min((max(opts.Page, 1)-1)*size, len(items))
+		end = min(start+size, len(items))
+		hasMore = end < len(items)
+
+	case cursor.Before:
+		// Last item before the position, and up to a page of items ending there
+		end = 0
+		for end < len(items) && compare(items[end], *cursor) < 0 {
+			end++
+		}
+		start = max(end-size, 0)
+		hasMore = start > 0
+
+	default:
+		// First item after the position, and up to a page of items from there
+		start = 0
+		for start < len(items) && compare(items[start], *cursor) <= 0 {
+			start++
+		}
+		end = min(start+size, len(items))
+		hasMore = end < len(items)
+	}
+
+	return NewCursorPaginated(items[start:end], opts, cursor, len(items), hasMore, key), nil
+}
+
+// MapPaginated converts the items of a page, keeping its metadata
+func MapPaginated[T, U any](p Paginated[T], convert func(T) U) Paginated[U] {
+	items := make([]U, len(p.Items))
+	for i, item := range p.Items {
+		items[i] = convert(item)
+	}
+	return Paginated[U]{
+		Items: items,
+		Page:  p.Page,
+		Empty: p.Empty,
+	}
+}
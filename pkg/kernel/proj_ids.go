@@ -17,3 +17,33 @@ type ResumeID string
 func NewResumeID(id string) ResumeID { return ResumeID(id) }
 func (r ResumeID) String() string    { return string(r) }
 func (r ResumeID) IsEmpty() bool     { return string(r) == "" }
+
+type EmbeddingMigrationID string
+
+func NewEmbeddingMigrationID(id string) EmbeddingMigrationID { return EmbeddingMigrationID(id) }
+func (r EmbeddingMigrationID) String() string                { return string(r) }
+func (r EmbeddingMigrationID) IsEmpty() bool                 { return string(r) == "" }
+
+type SkillID string
+
+func NewSkillID(id string) SkillID { return SkillID(id) }
+func (r SkillID) String() string   { return string(r) }
+func (r SkillID) IsEmpty() bool    { return string(r) == "" }
+
+type ResumeMergeID string
+
+func NewResumeMergeID(id string) ResumeMergeID { return ResumeMergeID(id) }
+func (r ResumeMergeID) String() string         { return string(r) }
+func (r ResumeMergeID) IsEmpty() bool          { return string(r) == "" }
+
+type SavedSearchID string
+
+func NewSavedSearchID(id string) SavedSearchID { return SavedSearchID(id) }
+func (r SavedSearchID) String() string         { return string(r) }
+func (r SavedSearchID) IsEmpty() bool          { return string(r) == "" }
+
+type NotificationID string
+
+func NewNotificationID(id string) NotificationID { return NotificationID(id) }
+func (r NotificationID) String() string          { return string(r) }
+func (r NotificationID) IsEmpty() bool           { return string(r) == "" }
@@ -2,10 +2,12 @@ package kernel
 
 // Page represents pagination metadata
 type Page struct {
-	Number int `json:"page"`      // Current page number (1-based)
-	Size   int `json:"page_size"` // Number of records per page
-	Total  int `json:"total"`     // Total number of records
-	Pages  int `json:"pages"`     // Total number of pages
+	Number     int    `json:"page"`                  // Current page number (1-based, 0 when paging by cursor)
+	Size       int    `json:"page_size"`             // Number of records per page
+	Total      int    `json:"total"`                 // Total number of records
+	Pages      int    `json:"pages"`                 // Total number of pages
+	NextCursor string `json:"next_cursor,omitempty"` // Set when there are records after this page
+	PrevCursor string `json:"prev_cursor,omitempty"` // Set when there are records before this page
 }
 
 // Paginated is a generic container for paginated data with metadata
@@ -44,8 +46,28 @@ func (p Paginated[T]) HasPrevious() bool {
 	return p.Page.Number > 1
 }
 
-// PaginationOptions holds options for pagination queries
+// PaginationOptions holds options for pagination queries.
+// A cursor from a previous page takes precedence over the page number.
 type PaginationOptions struct {
-	Page     int // Page number (1-based)
-	PageSize int // Number of records per page
+	Page      int           // Page number (1-based)
+	PageSize  int           // Number of records per page
+	Cursor    string        // Optional: next_cursor or prev_cursor of a previous page
+	Direction SortDirection // Optional: list order where supported, newest first by default
+	Token     string        `json:"-"` // Set by endpoints that keep the result set between pages, carried by the page cursors
+}
+
+// SortDirection returns the requested order, descending by default
+func (p PaginationOptions) SortDirection() SortDirection {
+	if p.Direction == SortAsc {
+		return SortAsc
+	}
+	return SortDesc
+}
+
+// DecodeCursor returns the decoded cursor, or nil when paging by page number
+func (p PaginationOptions) DecodeCursor() (*Cursor, error) {
+	if p.Cursor == "" {
+		return nil, nil
+	}
+	return DecodeCursor(p.Cursor)
 }
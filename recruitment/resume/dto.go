@@ -1,6 +1,9 @@
 package resume
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Abraxas-365/relay/pkg/kernel"
@@ -15,7 +18,7 @@ type ParseResumeRequest struct {
 	TenantID  kernel.TenantID `json:"tenant_id" validate:"required"`
 	FilePath  string          `json:"file_path" validate:"required"`
 	FileName  string          `json:"file_name" validate:"required"`
-	FileType  string          `json:"file_type" validate:"required,oneof=pdf jpg jpeg png"`
+	FileType  string          `json:"file_type" validate:"required,oneof=pdf jpg jpeg png docx doc odt rtf txt html"`
 	Title     string          `json:"title" validate:"required"` // Resume title/name
 	IsActive  bool            `json:"is_active"`                 // Set as active
 	IsDefault bool            `json:"is_default"`                // Set as default
@@ -54,6 +57,7 @@ type UpdateResumeRequest struct {
 	VolunteerWork       *[]VolunteerExperience `json:"volunteer_work,omitempty"`
 	ProfessionalSummary *string                `json:"professional_summary,omitempty"`
 	PersonalStatement   *PersonalStatement     `json:"personal_statement,omitempty"`
+	NeedsReview         *bool                  `json:"needs_review,omitempty"` // false once a recruiter has checked the parse
 }
 
 // AddPersonalStatementRequest - Add/update personal statement
@@ -77,14 +81,23 @@ type ToggleActiveRequest struct {
 
 // ListResumesRequest - List resumes for a tenant
 type ListResumesRequest struct {
-	TenantID   kernel.TenantID          `json:"tenant_id" validate:"required"`
-	OnlyActive bool                     `json:"only_active"`
-	Pagination kernel.PaginationOptions `json:"pagination"`
+	TenantID    kernel.TenantID          `json:"tenant_id" validate:"required"`
+	OnlyActive  bool                     `json:"only_active"`
+	NeedsReview *bool                    `json:"needs_review,omitempty"` // Optional: only resumes that do or do not need review
+	Pagination  kernel.PaginationOptions `json:"pagination"`
+	Facets      []Facet                  `json:"facets,omitempty"` // Optional: count the tenant's resumes by these facets
+}
+
+// ResumeListFilter narrows a paginated list of a tenant's resumes
+type ResumeListFilter struct {
+	OnlyActive  bool
+	NeedsReview *bool // Optional: only resumes that do or do not need review
 }
 
 // SearchResumesRequest - Semantic search request
 type SearchResumesRequest struct {
 	Query              string                   `json:"query" validate:"required"`
+	Mode               SearchMode               `json:"mode,omitempty"` // semantic (default), keyword or hybrid
 	TopK               int                      `json:"top_k" validate:"min=1,max=100"`
 	MinYearsExperience *float64                 `json:"min_years_experience,omitempty"`
 	MaxYearsExperience *float64                 `json:"max_years_experience,omitempty"`
@@ -92,11 +105,47 @@ type SearchResumesRequest struct {
 	PreferredSkills    []string                 `json:"preferred_skills,omitempty"`
 	Locations          []string                 `json:"locations,omitempty"`
 	EducationLevel     *string                  `json:"education_level,omitempty"`
-	Languages          []string                 `json:"languages,omitempty"`
+	Languages          []LanguageRequirement    `json:"languages,omitempty"`
 	Industries         []string                 `json:"industries,omitempty"`
 	OnlyActive         bool                     `json:"only_active"`
+	Weights            *SectionWeights          `json:"weights,omitempty"`   // Optional: defaults to the tenant's weights
 	TenantID           *kernel.TenantID         `json:"tenant_id,omitempty"` // Optional: search within specific tenant
 	Pagination         kernel.PaginationOptions `json:"pagination"`
+	EmbeddingModel     string                   `json:"embedding_model,omitempty"` // Optional: model to query, defaults to the tenant's active model
+	EmbeddingDim       int                      `json:"-"`                         // Set by the service from the model
+	VectorStorage      VectorStorage            `json:"-"`                         // Set by the service from the vector index settings
+	EfSearch           *int                     `json:"ef_search,omitempty"`       // Optional: HNSW candidate list size, trading latency for recall
+	SkillExpansions    SkillExpansions          `json:"-"`                         // Set by the service from the skill taxonomy
+	Facets             []Facet                  `json:"facets,omitempty"`          // Optional: count the filtered resumes by these facets
+	Interpret          bool                     `json:"interpret,omitempty"`       // Optional: extract filters from the query text
+	Rerank             *bool                    `json:"rerank,omitempty"`          // Optional: false skips the tenant's re-ranking stage
+	JobDescription     string                   `json:"job_description,omitempty"` // Optional: re-rank against this instead of the query
+	ResumeIDs          []kernel.ResumeID        `json:"-"`                         // Set internally to score only these resumes
+	FirstPageOnly      bool                     `json:"-"`                         // Set internally when no cursor will be followed: the hits are not kept
+}
+
+// LanguageRequirement - A language the candidate must speak, optionally at a minimum proficiency
+// Accepts either a plain string ("English") or an object in JSON.
+type LanguageRequirement struct {
+	Language       string `json:"language"`
+	MinProficiency string `json:"min_proficiency,omitempty"` // Basic, Intermediate, Professional, Fluent, Native
+}
+
+// UnmarshalJSON accepts both "English" and {"language":"English","min_proficiency":"Fluent"}
+func (l *LanguageRequirement) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*l = LanguageRequirement{Language: name}
+		return nil
+	}
+
+	type languageRequirement LanguageRequirement
+	var req languageRequirement
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+	*l = LanguageRequirement(req)
+	return nil
 }
 
 // GetResumeRequest - Get resume by ID
@@ -118,7 +167,7 @@ type UpdateEmbeddingsRequest struct {
 // BulkUpdateEmbeddingsRequest - Update embeddings for multiple resumes
 type BulkUpdateEmbeddingsRequest struct {
 	TenantID kernel.TenantID `json:"tenant_id" validate:"required"`
-	Force    bool            `json:"force"` // Force regeneration even if embeddings exist
+	Force    bool            `json:"force"` // Regenerate every vector, bypassing stored and cached ones
 }
 
 // ============================================================================
@@ -149,6 +198,10 @@ type ResumeResponse struct {
 	FileType            string                `json:"file_type"`
 	TotalYearsExp       float64               `json:"total_years_experience"`
 	HasEmbeddings       bool                  `json:"has_embeddings"`
+	PossibleDuplicates  []PossibleDuplicate   `json:"possible_duplicates,omitempty"`
+	ParseReport         *ParseReport          `json:"parse_report,omitempty"`
+	NeedsReview         bool                  `json:"needs_review"`
+	NormalizationIssues []NormalizationIssue  `json:"normalization_issues,omitempty"` // Set on create and update
 	ParsedAt            time.Time             `json:"parsed_at"`
 	LastUpdatedAt       time.Time             `json:"last_updated_at"`
 	CreatedAt           time.Time             `json:"created_at"`
@@ -172,6 +225,7 @@ type ResumeSummaryResponse struct {
 	TopSkills            []string        `json:"top_skills,omitempty"`
 	HighestEducation     string          `json:"highest_education,omitempty"`
 	HasPersonalStatement bool            `json:"has_personal_statement"`
+	NeedsReview          bool            `json:"needs_review"`
 	ParsedAt             time.Time       `json:"parsed_at"`
 	LastUpdatedAt        time.Time       `json:"last_updated_at"`
 }
@@ -179,39 +233,110 @@ type ResumeSummaryResponse struct {
 // ResumeMatchResult - Single resume match with similarity score
 type ResumeMatchResult struct {
 	Resume           ResumeSummaryResponse `json:"resume"`
-	SimilarityScore  float64               `json:"similarity_score"`
+	SimilarityScore  float64               `json:"similarity_score"`         // Ranking score of the search mode
+	SemanticScore    float64               `json:"semantic_score,omitempty"` // Weighted section similarity
+	KeywordScore     float64               `json:"keyword_score,omitempty"`  // Full-text rank (0-1)
 	MatchExplanation string                `json:"match_explanation"`
 	MatchedSkills    []string              `json:"matched_skills"`
 	MatchedSections  MatchedSections       `json:"matched_sections"`
+
+	// Set when the hit went through the re-ranking stage
+	RerankScore         *float64 `json:"rerank_score,omitempty"`
+	RerankJustification string   `json:"rerank_justification,omitempty"`
+
+	// BestMatchingEntry is the work experience or project closest to the query
+	BestMatchingEntry *MatchedEntry `json:"best_matching_entry,omitempty"`
+
+	// Explanation details which requirements of the search the resume meets
+	Explanation Explanation `json:"explanation"`
+}
+
+// MatchedEntry - The single work experience or project that matched best
+type MatchedEntry struct {
+	Type         EntryType `json:"type"`
+	Index        int       `json:"index"` // Position in work_experience or projects
+	Title        string    `json:"title"`
+	Organization string    `json:"organization,omitempty"`
+	Score        float64   `json:"score"`
+}
+
+// SetBestMatchingEntry records the best-matching entry and mentions it in the explanation
+func (m *ResumeMatchResult) SetBestMatchingEntry(entry *MatchedEntry) {
+	m.BestMatchingEntry = entry
+	m.Explanation.BestMatchingEntry = entry
+	if entry == nil {
+		return
+	}
+
+	position := entry.Title
+	if entry.Organization != "" {
+		position += " at " + entry.Organization
+	}
+	note := fmt.Sprintf("best matching %s: %s (%.2f)", strings.ReplaceAll(string(entry.Type), "_", " "), position, entry.Score)
+
+	if m.MatchExplanation == "" {
+		m.MatchExplanation = strings.ToUpper(note[:1]) + note[1:]
+	} else {
+		m.MatchExplanation += "; " + note
+	}
 }
 
 // MatchedSections - Shows which sections matched in search
+// Each score is the cosine similarity between the query and that section (0 when the section has no embedding)
 type MatchedSections struct {
 	ExperienceScore        float64 `json:"experience_score"`
 	EducationScore         float64 `json:"education_score"`
 	SkillsScore            float64 `json:"skills_score"`
+	LanguagesScore         float64 `json:"languages_score"`
 	PersonalStatementScore float64 `json:"personal_statement_score,omitempty"`
+	PreferredSkillsBoost   float64 `json:"preferred_skills_boost,omitempty"` // Added on top of the weighted section score
+}
+
+// BestSection returns the name and score of the strongest matching section
+func (m MatchedSections) BestSection() (string, float64) {
+	best, bestScore := "experience", m.ExperienceScore
+	candidates := []struct {
+		name  string
+		score float64
+	}{
+		{"education", m.EducationScore},
+		{"skills", m.SkillsScore},
+		{"languages", m.LanguagesScore},
+		{"personal_statement", m.PersonalStatementScore},
+	}
+	for _, c := range candidates {
+		if c.score > bestScore {
+			best, bestScore = c.name, c.score
+		}
+	}
+	return best, bestScore
 }
 
 // SearchResumesResponse - Results from semantic search with pagination
 type SearchResumesResponse struct {
-	Results       kernel.Paginated[ResumeMatchResult] `json:"results"`
-	SearchQuery   string                              `json:"search_query"`
-	Filters       SearchFilters                       `json:"filters,omitempty"`
-	ExecutionTime string                              `json:"execution_time"`
+	Results        kernel.Paginated[ResumeMatchResult] `json:"results"`
+	SearchQuery    string                              `json:"search_query"`
+	SimilarTo      *kernel.ResumeID                    `json:"similar_to,omitempty"` // Set by "find similar" searches
+	Mode           SearchMode                          `json:"mode"`
+	Filters        SearchFilters                       `json:"filters,omitempty"`
+	AppliedWeights SectionWeights                      `json:"applied_weights"`
+	Facets         SearchFacets                        `json:"facets,omitempty"`
+	Interpretation *QueryInterpretation                `json:"interpretation,omitempty"` // Set when the query was interpreted
+	Rerank         *RerankSummary                      `json:"rerank,omitempty"`         // Set when re-ranking is enabled for the tenant
+	ExecutionTime  string                              `json:"execution_time"`
 }
 
 // SearchFilters - Applied filters in search
 type SearchFilters struct {
-	MinYearsExperience *float64 `json:"min_years_experience,omitempty"`
-	MaxYearsExperience *float64 `json:"max_years_experience,omitempty"`
-	RequiredSkills     []string `json:"required_skills,omitempty"`
-	PreferredSkills    []string `json:"preferred_skills,omitempty"`
-	Locations          []string `json:"locations,omitempty"`
-	EducationLevel     *string  `json:"education_level,omitempty"`
-	Languages          []string `json:"languages,omitempty"`
-	Industries         []string `json:"industries,omitempty"`
-	OnlyActive         bool     `json:"only_active"`
+	MinYearsExperience *float64              `json:"min_years_experience,omitempty"`
+	MaxYearsExperience *float64              `json:"max_years_experience,omitempty"`
+	RequiredSkills     []string              `json:"required_skills,omitempty"`
+	PreferredSkills    []string              `json:"preferred_skills,omitempty"`
+	Locations          []string              `json:"locations,omitempty"`
+	EducationLevel     *string               `json:"education_level,omitempty"`
+	Languages          []LanguageRequirement `json:"languages,omitempty"`
+	Industries         []string              `json:"industries,omitempty"`
+	OnlyActive         bool                  `json:"only_active"`
 }
 
 // ListResumesResponse - List of tenant's resumes with pagination
@@ -220,6 +345,7 @@ type ListResumesResponse struct {
 	ActiveCount   int                                     `json:"active_count"`
 	InactiveCount int                                     `json:"inactive_count"`
 	DefaultResume *kernel.ResumeID                        `json:"default_resume,omitempty"`
+	Facets        SearchFacets                            `json:"facets,omitempty"`
 }
 
 // ResumeStatsResponse - Statistics about resumes
@@ -280,6 +406,8 @@ func ToResumeResponse(r *Resume) *ResumeResponse {
 		FileType:            r.FileType,
 		TotalYearsExp:       r.TotalYearsOfExperience(),
 		HasEmbeddings:       r.HasEmbeddings(),
+		ParseReport:         r.ParseReport,
+		NeedsReview:         r.NeedsReview,
 		ParsedAt:            r.ParsedAt,
 		LastUpdatedAt:       r.LastUpdatedAt,
 		CreatedAt:           r.CreatedAt,
@@ -300,6 +428,7 @@ func ToResumeSummaryResponse(r *Resume) *ResumeSummaryResponse {
 		Phone:                r.PersonalInfo.Phone,
 		TotalYearsExp:        r.TotalYearsOfExperience(),
 		HasPersonalStatement: r.HasPersonalStatement(),
+		NeedsReview:          r.NeedsReview,
 		ParsedAt:             r.ParsedAt,
 		LastUpdatedAt:        r.LastUpdatedAt,
 	}
@@ -336,34 +465,56 @@ func ToResumeSummaryResponse(r *Resume) *ResumeSummaryResponse {
 
 // ToListResumesResponse creates a paginated list response
 func ToListResumesResponse(
-	resumes []*Resume,
-	page, pageSize, total int,
+	resumes kernel.Paginated[*Resume],
 	activeCount, inactiveCount int,
 	defaultResumeID *kernel.ResumeID,
 ) *ListResumesResponse {
-	summaries := make([]ResumeSummaryResponse, len(resumes))
-	for i, r := range resumes {
-		summaries[i] = *ToResumeSummaryResponse(r)
-	}
-
 	return &ListResumesResponse{
-		Resumes:       kernel.NewPaginated(summaries, page, pageSize, total),
+		Resumes: kernel.MapPaginated(resumes, func(r *Resume) ResumeSummaryResponse {
+			return *ToResumeSummaryResponse(r)
+		}),
 		ActiveCount:   activeCount,
 		InactiveCount: inactiveCount,
 		DefaultResume: defaultResumeID,
 	}
 }
 
+// NewResumeMatchResult builds a search hit from a resume and its section scores.
+// Callers set SemanticScore or KeywordScore depending on how the hit was found.
+// The required and preferred skills of the search fill MatchedSkills through their
+// taxonomy expansions, and the whole request is explained in Explanation.
+func NewResumeMatchResult(r *Resume, score float64, sections MatchedSections, req SearchResumesRequest) ResumeMatchResult {
+	querySkills := append(append([]string{}, req.RequiredSkills...), req.PreferredSkills...)
+	matchedSkills := r.MatchSkills(querySkills, req.SkillExpansions)
+
+	parts := []string{}
+	if section, sectionScore := sections.BestSection(); sectionScore > 0 {
+		parts = append(parts, fmt.Sprintf("Strongest match on %s (%.2f)", section, sectionScore))
+	}
+	if len(matchedSkills) > 0 {
+		parts = append(parts, fmt.Sprintf("matched %d of %d requested skills", len(matchedSkills), len(querySkills)))
+	}
+	explanation := strings.Join(parts, "; ")
+
+	return ResumeMatchResult{
+		Resume:           *ToResumeSummaryResponse(r),
+		SimilarityScore:  score,
+		MatchExplanation: explanation,
+		MatchedSkills:    matchedSkills,
+		MatchedSections:  sections,
+		Explanation:      ExplainMatch(r, req, sections),
+	}
+}
+
 // ToSearchResumesResponse creates a paginated search response
 func ToSearchResumesResponse(
-	matches []ResumeMatchResult,
-	page, pageSize, total int,
+	results kernel.Paginated[ResumeMatchResult],
 	query string,
 	filters SearchFilters,
 	executionTime string,
 ) *SearchResumesResponse {
 	return &SearchResumesResponse{
-		Results:       kernel.NewPaginated(matches, page, pageSize, total),
+		Results:       results,
 		SearchQuery:   query,
 		Filters:       filters,
 		ExecutionTime: executionTime,
@@ -0,0 +1,235 @@
+package resume
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+)
+
+// DuplicateSimilarityThreshold is the combined section similarity from which
+// two resumes are reported as near-duplicates
+const DuplicateSimilarityThreshold = 0.92
+
+// DuplicateReason explains why two resumes look like the same person
+type DuplicateReason string
+
+const (
+	DuplicateReasonEmail     DuplicateReason = "email"     // Same normalized email
+	DuplicateReasonPhone     DuplicateReason = "phone"     // Same phone digits
+	DuplicateReasonLinkedIn  DuplicateReason = "linkedin"  // Same LinkedIn profile
+	DuplicateReasonEmbedding DuplicateReason = "embedding" // Near-identical content
+)
+
+// DuplicateMatch is a resume that shares contact details with another one
+type DuplicateMatch struct {
+	ResumeID kernel.ResumeID   `json:"resume_id"`
+	Reasons  []DuplicateReason `json:"reasons"`
+}
+
+// DuplicatePair links two resumes of the tenant that may belong to the same person
+type DuplicatePair struct {
+	ResumeID    kernel.ResumeID   `json:"resume_id"`
+	DuplicateID kernel.ResumeID   `json:"duplicate_id"`
+	Reasons     []DuplicateReason `json:"reasons"`
+	Similarity  float64           `json:"similarity,omitempty"`
+}
+
+// AddReason records a reason once
+func (p *DuplicatePair) AddReason(reason DuplicateReason) {
+	for _, existing := range p.Reasons {
+		if existing == reason {
+			return
+		}
+	}
+	p.Reasons = append(p.Reasons, reason)
+}
+
+// IsExact reports whether the pair shares contact details, not just similar content
+func (p *DuplicatePair) IsExact() bool {
+	for _, reason := range p.Reasons {
+		if reason != DuplicateReasonEmbedding {
+			return true
+		}
+	}
+	return false
+}
+
+// PossibleDuplicate - Another resume that may belong to the same person
+type PossibleDuplicate struct {
+	Resume     ResumeSummaryResponse `json:"resume"`
+	Reasons    []DuplicateReason     `json:"reasons"`
+	Similarity float64               `json:"similarity,omitempty"`
+}
+
+// ScanDuplicatesResponse - Result of a tenant-wide duplicate pass
+type ScanDuplicatesResponse struct {
+	Pairs          []DuplicatePair `json:"pairs"`
+	Total          int             `json:"total"`
+	ScannedResumes int             `json:"scanned_resumes"`
+	Truncated      bool            `json:"truncated,omitempty"` // More near-duplicate pairs exist than were returned
+}
+
+// ============================================================================
+// Merge
+// ============================================================================
+
+// MergeField is a resume field whose value the merge takes from one of the merged resumes
+type MergeField string
+
+const (
+	MergeFieldPersonalInfo        MergeField = "personal_info"
+	MergeFieldWorkExperience      MergeField = "work_experience"
+	MergeFieldEducation           MergeField = "education"
+	MergeFieldSkills              MergeField = "skills"
+	MergeFieldLanguages           MergeField = "languages"
+	MergeFieldCertifications      MergeField = "certifications"
+	MergeFieldProjects            MergeField = "projects"
+	MergeFieldAchievements        MergeField = "achievements"
+	MergeFieldVolunteerWork       MergeField = "volunteer_work"
+	MergeFieldProfessionalSummary MergeField = "professional_summary"
+	MergeFieldPersonalStatement   MergeField = "personal_statement"
+)
+
+// MergeFields lists the fields resolved by a merge, in order
+var MergeFields = []MergeField{
+	MergeFieldPersonalInfo,
+	MergeFieldWorkExperience,
+	MergeFieldEducation,
+	MergeFieldSkills,
+	MergeFieldLanguages,
+	MergeFieldCertifications,
+	MergeFieldProjects,
+	MergeFieldAchievements,
+	MergeFieldVolunteerWork,
+	MergeFieldProfessionalSummary,
+	MergeFieldPersonalStatement,
+}
+
+// IsValid reports whether the field can be picked in a merge
+func (f MergeField) IsValid() bool {
+	for _, field := range MergeFields {
+		if field == f {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeResumesRequest - Fold duplicate resumes into a surviving one
+type MergeResumesRequest struct {
+	TenantID     kernel.TenantID                `json:"-"`
+	SurvivorID   kernel.ResumeID                `json:"-"`
+	SourceIDs    []kernel.ResumeID              `json:"source_ids" validate:"required,min=1"`
+	FieldSources map[MergeField]kernel.ResumeID `json:"field_sources,omitempty"` // Optional: resume to take each field from
+	MergedBy     *kernel.UserID                 `json:"-"`
+}
+
+// ResumeMerge records where a merged resume's fields came from and what was merged away
+type ResumeMerge struct {
+	ID              kernel.ResumeMergeID           `json:"id"`
+	TenantID        kernel.TenantID                `json:"tenant_id"`
+	SurvivorID      kernel.ResumeID                `json:"survivor_id"`
+	MergedResumeIDs []kernel.ResumeID              `json:"merged_resume_ids"`
+	FieldSources    map[MergeField]kernel.ResumeID `json:"field_sources"`
+	MergedResumes   []ResumeResponse               `json:"merged_resumes"` // Snapshots of the deleted resumes
+	MovedJobs       int                            `json:"moved_jobs"`
+	MergedBy        *kernel.UserID                 `json:"merged_by,omitempty"`
+	CreatedAt       time.Time                      `json:"created_at"`
+}
+
+// MergeResumesResponse - The surviving resume and the merge record
+type MergeResumesResponse struct {
+	Resume ResumeResponse `json:"resume"`
+	Merge  ResumeMerge    `json:"merge"`
+}
+
+// IsFieldEmpty reports whether the resume has no value for a merge field
+func (r *Resume) IsFieldEmpty(field MergeField) bool {
+	switch field {
+	case MergeFieldPersonalInfo:
+		return r.PersonalInfo.FullName == "" && r.PersonalInfo.Email == "" && r.PersonalInfo.Phone == ""
+	case MergeFieldWorkExperience:
+		return len(r.WorkExperience) == 0
+	case MergeFieldEducation:
+		return len(r.Education) == 0
+	case MergeFieldSkills:
+		return len(r.Skills.HardSkills) == 0 && len(r.Skills.SoftSkills) == 0
+	case MergeFieldLanguages:
+		return len(r.Languages) == 0
+	case MergeFieldCertifications:
+		return len(r.Certifications) == 0
+	case MergeFieldProjects:
+		return len(r.Projects) == 0
+	case MergeFieldAchievements:
+		return len(r.Achievements) == 0
+	case MergeFieldVolunteerWork:
+		return len(r.VolunteerWork) == 0
+	case MergeFieldProfessionalSummary:
+		return strings.TrimSpace(r.ProfessionalSummary) == ""
+	case MergeFieldPersonalStatement:
+		ps := r.PersonalStatement
+		return ps.WhyThisCompany == "" && ps.WhyThisRole == "" && ps.CareerGoals == "" && ps.UniqueValue == "" && ps.Essay == ""
+	}
+	return true
+}
+
+// CopyField replaces the value of a merge field with the one of another resume
+func (r *Resume) CopyField(from *Resume, field MergeField) {
+	switch field {
+	case MergeFieldPersonalInfo:
+		r.PersonalInfo = from.PersonalInfo
+	case MergeFieldWorkExperience:
+		r.WorkExperience = from.WorkExperience
+	case MergeFieldEducation:
+		r.Education = from.Education
+	case MergeFieldSkills:
+		r.Skills = from.Skills
+	case MergeFieldLanguages:
+		r.Languages = from.Languages
+	case MergeFieldCertifications:
+		r.Certifications = from.Certifications
+	case MergeFieldProjects:
+		r.Projects = from.Projects
+	case MergeFieldAchievements:
+		r.Achievements = from.Achievements
+	case MergeFieldVolunteerWork:
+		r.VolunteerWork = from.VolunteerWork
+	case MergeFieldProfessionalSummary:
+		r.ProfessionalSummary = from.ProfessionalSummary
+	case MergeFieldPersonalStatement:
+		r.PersonalStatement = from.PersonalStatement
+	}
+}
+
+// MergeFrom takes each field from the resume chosen in picks. Fields without a pick
+// keep the survivor's value, or take the first non-empty value of the sources.
+// It returns the resume each field was taken from.
+func (r *Resume) MergeFrom(sources []*Resume, picks map[MergeField]kernel.ResumeID) map[MergeField]kernel.ResumeID {
+	byID := map[kernel.ResumeID]*Resume{r.ID: r}
+	for _, source := range sources {
+		byID[source.ID] = source
+	}
+
+	origins := make(map[MergeField]kernel.ResumeID, len(MergeFields))
+	for _, field := range MergeFields {
+		origin := r.ID
+		if picked, ok := picks[field]; ok {
+			origin = picked
+		} else if r.IsFieldEmpty(field) {
+			for _, source := range sources {
+				if !source.IsFieldEmpty(field) {
+					origin = source.ID
+					break
+				}
+			}
+		}
+
+		if origin != r.ID {
+			r.CopyField(byID[origin], field)
+		}
+		origins[field] = origin
+	}
+
+	return origins
+}
@@ -24,6 +24,26 @@ var (
 	CodeInvalidFileFormat         = ErrRegistry.Register("INVALID_FILE_FORMAT", errx.TypeValidation, http.StatusBadRequest, "Invalid file format")
 	CodeTenantMismatch            = ErrRegistry.Register("TENANT_MISMATCH", errx.TypeAuthorization, http.StatusForbidden, "Resume does not belong to this tenant")
 	CodeSearchFailed              = ErrRegistry.Register("SEARCH_FAILED", errx.TypeInternal, http.StatusInternalServerError, "Search operation failed")
+	CodeInvalidSearchRequest      = ErrRegistry.Register("INVALID_SEARCH_REQUEST", errx.TypeValidation, http.StatusBadRequest, "Invalid search request")
+	CodeEmbeddingDimMismatch      = ErrRegistry.Register("EMBEDDING_DIMENSION_MISMATCH", errx.TypeInternal, http.StatusInternalServerError, "Embedding dimension does not match vector storage")
+	CodeInvalidPagination         = ErrRegistry.Register("INVALID_PAGINATION", errx.TypeValidation, http.StatusBadRequest, "Invalid pagination cursor or sort direction")
+	CodeSearchExpired             = ErrRegistry.Register("SEARCH_EXPIRED", errx.TypeNotFound, http.StatusGone, "Search results expired, run the search again")
+)
+
+// Error codes - Embedding Migrations
+var (
+	CodeMigrationNotFound         = ErrRegistry.Register("MIGRATION_NOT_FOUND", errx.TypeNotFound, http.StatusNotFound, "Embedding migration not found")
+	CodeInvalidMigrationState     = ErrRegistry.Register("INVALID_MIGRATION_STATE", errx.TypeBusiness, http.StatusUnprocessableEntity, "Embedding migration is not in a valid state for this operation")
+	CodeMigrationAlreadyRunning   = ErrRegistry.Register("MIGRATION_ALREADY_RUNNING", errx.TypeConflict, http.StatusConflict, "An embedding migration is already in progress")
+	CodeEmbeddingModelUnavailable = ErrRegistry.Register("EMBEDDING_MODEL_UNAVAILABLE", errx.TypeValidation, http.StatusBadRequest, "Embedding model is not configured")
+	CodeMigrationFailed           = ErrRegistry.Register("MIGRATION_FAILED", errx.TypeInternal, http.StatusInternalServerError, "Embedding migration operation failed")
+)
+
+// Error codes - Duplicates & Merge
+var (
+	CodeInvalidMergeRequest = ErrRegistry.Register("INVALID_MERGE_REQUEST", errx.TypeValidation, http.StatusBadRequest, "Invalid resume merge request")
+	CodeMergeFailed         = ErrRegistry.Register("MERGE_FAILED", errx.TypeInternal, http.StatusInternalServerError, "Failed to merge resumes")
+	CodeDuplicateScanFailed = ErrRegistry.Register("DUPLICATE_SCAN_FAILED", errx.TypeInternal, http.StatusInternalServerError, "Failed to detect duplicate resumes")
 )
 
 // Error codes - Job/Queue Operations
@@ -42,6 +62,8 @@ var (
 	CodeJobUpdateFailed      = ErrRegistry.Register("JOB_UPDATE_FAILED", errx.TypeInternal, http.StatusInternalServerError, "Failed to update job status")
 	CodeInvalidJobStatus     = ErrRegistry.Register("INVALID_JOB_STATUS", errx.TypeValidation, http.StatusBadRequest, "Invalid job status")
 	CodeJobRetryFailed       = ErrRegistry.Register("JOB_RETRY_FAILED", errx.TypeInternal, http.StatusInternalServerError, "Failed to schedule job retry")
+	CodeInvalidReviewConfig  = ErrRegistry.Register("INVALID_REVIEW_SETTINGS", errx.TypeValidation, http.StatusBadRequest, "Invalid parse review settings")
+	CodeReviewRequired       = ErrRegistry.Register("REVIEW_REQUIRED", errx.TypeBusiness, http.StatusUnprocessableEntity, "Parsed resumes are reviewed before they are created, parse them as a job")
 )
 
 // Helper functions - Resume Operations
@@ -101,6 +123,56 @@ func ErrSearchFailed() *errx.Error {
 	return ErrRegistry.New(CodeSearchFailed)
 }
 
+func ErrInvalidSearchRequest() *errx.Error {
+	return ErrRegistry.New(CodeInvalidSearchRequest)
+}
+
+func ErrInvalidPagination() *errx.Error {
+	return ErrRegistry.New(CodeInvalidPagination)
+}
+
+func ErrSearchExpired() *errx.Error {
+	return ErrRegistry.New(CodeSearchExpired)
+}
+
+func ErrEmbeddingDimMismatch() *errx.Error {
+	return ErrRegistry.New(CodeEmbeddingDimMismatch)
+}
+
+// Helper functions - Embedding Migrations
+func ErrMigrationNotFound() *errx.Error {
+	return ErrRegistry.New(CodeMigrationNotFound)
+}
+
+func ErrInvalidMigrationState() *errx.Error {
+	return ErrRegistry.New(CodeInvalidMigrationState)
+}
+
+func ErrMigrationAlreadyRunning() *errx.Error {
+	return ErrRegistry.New(CodeMigrationAlreadyRunning)
+}
+
+func ErrEmbeddingModelUnavailable() *errx.Error {
+	return ErrRegistry.New(CodeEmbeddingModelUnavailable)
+}
+
+func ErrMigrationFailed() *errx.Error {
+	return ErrRegistry.New(CodeMigrationFailed)
+}
+
+// Helper functions - Duplicates & Merge
+func ErrInvalidMergeRequest() *errx.Error {
+	return ErrRegistry.New(CodeInvalidMergeRequest)
+}
+
+func ErrMergeFailed() *errx.Error {
+	return ErrRegistry.New(CodeMergeFailed)
+}
+
+func ErrDuplicateScanFailed() *errx.Error {
+	return ErrRegistry.New(CodeDuplicateScanFailed)
+}
+
 // Helper functions - Job/Queue Operations
 func ErrJobNotFound() *errx.Error {
 	return ErrRegistry.New(CodeJobNotFound)
@@ -157,3 +229,11 @@ func ErrInvalidJobStatus() *errx.Error {
 func ErrJobRetryFailed() *errx.Error {
 	return ErrRegistry.New(CodeJobRetryFailed)
 }
+
+func ErrInvalidReviewConfig() *errx.Error {
+	return ErrRegistry.New(CodeInvalidReviewConfig)
+}
+
+func ErrReviewRequired() *errx.Error {
+	return ErrRegistry.New(CodeReviewRequired)
+}
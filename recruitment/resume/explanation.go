@@ -0,0 +1,182 @@
+package resume
+
+import (
+	"strings"
+
+	"github.com/Abraxas-365/relay/recruitment/skill"
+)
+
+// Explanation - Structured reasons a resume matched a search, ready to render
+type Explanation struct {
+	StrongestSection      string          `json:"strongest_section,omitempty"`
+	StrongestSectionScore float64         `json:"strongest_section_score,omitempty"`
+	RequiredSkills        SkillCoverage   `json:"required_skills"`
+	PreferredSkills       SkillCoverage   `json:"preferred_skills"`
+	TotalYears            float64         `json:"total_years_experience"`
+	RelevantYears         float64         `json:"relevant_years_experience"`
+	RelevantRoles         []RelevantRole  `json:"relevant_roles"`
+	MeetsExperience       *bool           `json:"meets_experience,omitempty"` // Set when the search bounds years of experience
+	Languages             []LanguageMatch `json:"languages"`
+	BestMatchingEntry     *MatchedEntry   `json:"best_matching_entry,omitempty"`
+}
+
+// SkillCoverage - Which requested skills the resume has
+type SkillCoverage struct {
+	Found   []SkillMatch `json:"found"`
+	Missing []string     `json:"missing"`
+}
+
+// SkillMatch - A requested skill and the resume's skills that satisfy it (itself, an alias or a sub-skill)
+type SkillMatch struct {
+	Skill     string   `json:"skill"`
+	MatchedAs []string `json:"matched_as"`
+}
+
+// RelevantRole - A work experience that counts towards the relevant years
+type RelevantRole struct {
+	Title         string   `json:"title"`
+	Company       string   `json:"company,omitempty"`
+	Years         float64  `json:"years"`
+	MatchedSkills []string `json:"matched_skills,omitempty"` // Requested skills used or mentioned in the role
+	Industry      string   `json:"industry,omitempty"`       // Set when the role is in a requested industry
+}
+
+// LanguageMatch - How the resume meets a language requirement
+type LanguageMatch struct {
+	Language             string `json:"language"`
+	RequiredProficiency  string `json:"required_proficiency,omitempty"`
+	CandidateProficiency string `json:"candidate_proficiency,omitempty"` // Empty when the candidate does not list the language
+	Met                  bool   `json:"met"`
+}
+
+// ExplainMatch explains a hit against the search request: requested skills found and
+// missing, years in relevant roles and language requirements met. A role is relevant when
+// it uses or mentions a requested skill or is in a requested industry; a search without
+// skills or industries counts every role. The best-matching entry is set separately.
+func ExplainMatch(r *Resume, req SearchResumesRequest, sections MatchedSections) Explanation {
+	explanation := Explanation{
+		RequiredSkills:  r.skillCoverage(req.RequiredSkills, req.SkillExpansions),
+		PreferredSkills: r.skillCoverage(req.PreferredSkills, req.SkillExpansions),
+		TotalYears:      r.TotalYearsOfExperience(),
+		RelevantRoles:   []RelevantRole{},
+		Languages:       []LanguageMatch{},
+	}
+
+	if section, score := sections.BestSection(); score > 0 {
+		explanation.StrongestSection = section
+		explanation.StrongestSectionScore = score
+	}
+
+	requested := append(append([]string{}, req.RequiredSkills...), req.PreferredSkills...)
+	countAll := len(uniqueNormalized(requested)) == 0 && len(req.Industries) == 0
+	relevantMonths := 0
+	for _, exp := range r.WorkExperience {
+		role := RelevantRole{
+			Title:         exp.Title,
+			Company:       exp.Company,
+			Years:         float64(exp.DurationMonths) / 12.0,
+			MatchedSkills: exp.matchSkills(requested, req.SkillExpansions),
+		}
+		for _, industry := range req.Industries {
+			if industry = strings.TrimSpace(industry); industry != "" &&
+				strings.Contains(strings.ToLower(exp.Industry), strings.ToLower(industry)) {
+				role.Industry = exp.Industry
+				break
+			}
+		}
+
+		if countAll || len(role.MatchedSkills) > 0 || role.Industry != "" {
+			explanation.RelevantRoles = append(explanation.RelevantRoles, role)
+			relevantMonths += exp.DurationMonths
+		}
+	}
+	explanation.RelevantYears = float64(relevantMonths) / 12.0
+
+	if req.MinYearsExperience != nil || req.MaxYearsExperience != nil {
+		meets := (req.MinYearsExperience == nil || explanation.TotalYears >= *req.MinYearsExperience) &&
+			(req.MaxYearsExperience == nil || explanation.TotalYears <= *req.MaxYearsExperience)
+		explanation.MeetsExperience = &meets
+	}
+
+	for _, requirement := range req.Languages {
+		match := LanguageMatch{
+			Language:            strings.TrimSpace(requirement.Language),
+			RequiredProficiency: requirement.MinProficiency,
+			Met:                 r.MeetsLanguageRequirement(requirement),
+		}
+		for _, language := range r.Languages {
+			if strings.EqualFold(strings.TrimSpace(language.Language), match.Language) {
+				match.CandidateProficiency = language.Proficiency
+				break
+			}
+		}
+		explanation.Languages = append(explanation.Languages, match)
+	}
+
+	return explanation
+}
+
+// skillCoverage splits the requested skills into found (with the resume skills that satisfy them) and missing
+func (r *Resume) skillCoverage(requested []string, expansions SkillExpansions) SkillCoverage {
+	owned := make(map[string][]string)
+	for _, name := range r.GetAllSkills() {
+		key := skill.Normalize(name)
+		owned[key] = append(owned[key], name)
+	}
+
+	coverage := SkillCoverage{Found: []SkillMatch{}, Missing: []string{}}
+	for _, name := range uniqueNormalized(requested) {
+		match := SkillMatch{Skill: name, MatchedAs: []string{}}
+		for _, accepted := range expansions.Accepted(name) {
+			match.MatchedAs = append(match.MatchedAs, owned[accepted]...)
+		}
+		if len(match.MatchedAs) > 0 {
+			coverage.Found = append(coverage.Found, match)
+		} else {
+			coverage.Missing = append(coverage.Missing, name)
+		}
+	}
+	return coverage
+}
+
+// matchSkills returns the requested skills the role lists in its skills or mentions in its title or description
+func (e WorkExperience) matchSkills(requested []string, expansions SkillExpansions) []string {
+	used := make(map[string]bool)
+	for _, name := range e.SkillsUsed {
+		used[skill.Normalize(name)] = true
+	}
+	words := strings.FieldsFunc(strings.ToLower(e.Title+" "+e.DescriptionNormalized), func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';' || r == ':' || r == '(' || r == ')' || r == '/' || r == '\n' || r == '\t'
+	})
+	for i, word := range words {
+		words[i] = strings.TrimRight(word, ".!?") // Sentence ends, but not "node.js"
+	}
+	// Padded so names only match whole words: "go" is not found in "google"
+	text := " " + strings.Join(words, " ") + " "
+
+	matched := []string{}
+	for _, name := range uniqueNormalized(requested) {
+		for _, accepted := range expansions.Accepted(name) {
+			if used[accepted] || strings.Contains(text, " "+accepted+" ") {
+				matched = append(matched, name)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// uniqueNormalized drops empty and repeated names (compared normalized), keeping the first spelling
+func uniqueNormalized(names []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		key := skill.Normalize(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, strings.TrimSpace(name))
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package resume
+
+// Facet is a dimension the filtered resumes are counted by
+type Facet string
+
+const (
+	FacetSkills     Facet = "skills"     // Normalized hard and soft skill names
+	FacetCities     Facet = "cities"     // City part of the location ("Lima, Peru" -> "Lima")
+	FacetExperience Facet = "experience" // Total years of experience, in ExperienceBuckets
+	FacetLanguages  Facet = "languages"  // Spoken languages
+)
+
+// Facets lists the supported facets
+var Facets = []Facet{FacetSkills, FacetCities, FacetExperience, FacetLanguages}
+
+// IsValid checks if the facet is supported
+func (f Facet) IsValid() bool {
+	for _, facet := range Facets {
+		if facet == f {
+			return true
+		}
+	}
+	return false
+}
+
+// FacetBucketLimit is the maximum number of buckets returned per facet, most frequent first
+const FacetBucketLimit = 20
+
+// ExperienceBucket is a range of total years of experience, MinYears inclusive and MaxYears exclusive.
+// A zero MaxYears leaves the range open.
+type ExperienceBucket struct {
+	Label    string
+	MinYears int
+	MaxYears int
+}
+
+// ExperienceBuckets are the ranges of the experience facet, in order
+var ExperienceBuckets = []ExperienceBucket{
+	{Label: "0-2", MinYears: 0, MaxYears: 2},
+	{Label: "2-5", MinYears: 2, MaxYears: 5},
+	{Label: "5-10", MinYears: 5, MaxYears: 10},
+	{Label: "10+", MinYears: 10},
+}
+
+// FacetBucket - Number of resumes with a facet value
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets - Buckets of each requested facet over the filtered resumes
+type SearchFacets map[Facet][]FacetBucket
+
+// UniqueFacets drops repeated facets, keeping the requested order
+func UniqueFacets(facets []Facet) []Facet {
+	result := []Facet{}
+	seen := make(map[Facet]bool)
+	for _, f := range facets {
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		result = append(result, f)
+	}
+	return result
+}
@@ -12,6 +12,10 @@ const (
 	JobStatusProcessing JobStatus = "processing"
 	JobStatusCompleted  JobStatus = "completed"
 	JobStatusFailed     JobStatus = "failed"
+
+	// Parsed into a draft that waits for a reviewer (see ParseReviewSettings)
+	JobStatusAwaitingReview JobStatus = "awaiting_review"
+	JobStatusRejected       JobStatus = "rejected"
 )
 
 type ProcessingStep string
@@ -21,6 +25,7 @@ const (
 	StepParsing   ProcessingStep = "parsing"
 	StepEmbedding ProcessingStep = "embedding"
 	StepSaving    ProcessingStep = "saving"
+	StepReview    ProcessingStep = "review"
 )
 
 type ResumeProcessingJob struct {
@@ -50,6 +55,12 @@ type ResumeProcessingJob struct {
 	NextRetryAt *time.Time `db:"next_retry_at" json:"next_retry_at,omitempty"`
 
 	RequestPayload ParseResumeRequest `db:"request_payload" json:"request_payload"`
+
+	// Parsed resume awaiting review; the resume is only created when it is approved
+	Draft      *Resume        `db:"draft" json:"draft,omitempty"`
+	ReviewedBy *kernel.UserID `db:"reviewed_by" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time     `db:"reviewed_at" json:"reviewed_at,omitempty"`
+	ReviewNote string         `db:"review_note" json:"review_note,omitempty"` // Why the draft was rejected
 }
 
 // JobStatusResponse - Response for job status queries
@@ -70,6 +81,10 @@ type JobStatusResponse struct {
 	StartedAt   *time.Time `json:"started_at,omitempty"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 	FailedAt    *time.Time `json:"failed_at,omitempty"`
+
+	Draft      *ResumeResponse `json:"draft,omitempty"` // Set while the job awaits review
+	ReviewedAt *time.Time      `json:"reviewed_at,omitempty"`
+	ReviewNote string          `json:"review_note,omitempty"`
 }
 
 // JobError - Error details for failed jobs
@@ -80,13 +95,15 @@ type JobError struct {
 
 // JobStatsResponse - Statistics about jobs for a tenant
 type JobStatsResponse struct {
-	TenantID         kernel.TenantID `json:"tenant_id"`
-	TotalJobs        int             `json:"total_jobs"`
-	PendingJobs      int             `json:"pending_jobs"`
-	ProcessingJobs   int             `json:"processing_jobs"`
-	CompletedJobs    int             `json:"completed_jobs"`
-	FailedJobs       int             `json:"failed_jobs"`
-	AverageProgress  float64         `json:"average_progress"`
-	OldestPendingJob *time.Time      `json:"oldest_pending_job,omitempty"`
-	LastCompletedJob *time.Time      `json:"last_completed_job,omitempty"`
+	TenantID           kernel.TenantID `json:"tenant_id"`
+	TotalJobs          int             `json:"total_jobs"`
+	PendingJobs        int             `json:"pending_jobs"`
+	ProcessingJobs     int             `json:"processing_jobs"`
+	CompletedJobs      int             `json:"completed_jobs"`
+	FailedJobs         int             `json:"failed_jobs"`
+	AwaitingReviewJobs int             `json:"awaiting_review_jobs"`
+	RejectedJobs       int             `json:"rejected_jobs"`
+	AverageProgress    float64         `json:"average_progress"`
+	OldestPendingJob   *time.Time      `json:"oldest_pending_job,omitempty"`
+	LastCompletedJob   *time.Time      `json:"last_completed_job,omitempty"`
 }
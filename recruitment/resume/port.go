@@ -17,6 +17,10 @@ type Repository interface {
 	// GetByID retrieves a resume by ID
 	GetByID(ctx context.Context, id kernel.ResumeID) (*Resume, error)
 
+	// ListByIDs retrieves the resumes with the given IDs in one query, without their
+	// embeddings; IDs that no longer exist are left out
+	ListByIDs(ctx context.Context, ids []kernel.ResumeID) ([]*Resume, error)
+
 	// GetByTenantID retrieves the default resume for a tenant (backward compatibility)
 	GetByTenantID(ctx context.Context, tenantID kernel.TenantID) (*Resume, error)
 
@@ -44,8 +48,14 @@ type Repository interface {
 	// CountByTenantID counts resumes for a tenant
 	CountByTenantID(ctx context.Context, tenantID kernel.TenantID) (int64, error)
 
-	// SemanticSearch performs vector similarity search
-	SemanticSearch(ctx context.Context, req SearchResumesRequest) ([]ResumeMatchResult, error)
+	// SemanticSearch performs vector similarity search against a query embedding
+	SemanticSearch(ctx context.Context, queryEmbedding []float32, req SearchResumesRequest) ([]ResumeMatchResult, error)
+
+	// FindSimilar finds the resumes most similar to an existing resume using its stored embeddings
+	FindSimilar(ctx context.Context, id kernel.ResumeID, req SearchResumesRequest) ([]ResumeMatchResult, error)
+
+	// KeywordSearch performs full-text search over titles, skills, certifications and summary
+	KeywordSearch(ctx context.Context, req SearchResumesRequest) ([]ResumeMatchResult, error)
 
 	// UpdateEmbeddings updates only the embeddings for a resume
 	UpdateEmbeddings(ctx context.Context, id kernel.ResumeID, embeddings ResumeEmbeddings) error
@@ -53,11 +63,48 @@ type Repository interface {
 	// List retrieves all resumes with pagination
 	List(ctx context.Context, pagination kernel.PaginationOptions) (*kernel.Paginated[Resume], error)
 
-	// ListByTenantIDWithPagination retrieves resumes for a tenant with pagination
-	ListByTenantIDWithPagination(ctx context.Context, tenantID kernel.TenantID, pagination kernel.PaginationOptions) (*kernel.Paginated[Resume], error)
+	// ListByTenantIDWithPagination retrieves the resumes of a tenant that match the filter with pagination
+	ListByTenantIDWithPagination(ctx context.Context, tenantID kernel.TenantID, filter ResumeListFilter, pagination kernel.PaginationOptions) (*kernel.Paginated[Resume], error)
 
 	// SearchByTenant performs semantic search within a specific tenant
-	SearchByTenant(ctx context.Context, tenantID kernel.TenantID, req SearchResumesRequest) ([]ResumeMatchResult, error)
+	SearchByTenant(ctx context.Context, tenantID kernel.TenantID, queryEmbedding []float32, req SearchResumesRequest) ([]ResumeMatchResult, error)
+
+	// Facets counts the resumes matching the hard filters of a search by each requested facet
+	Facets(ctx context.Context, req SearchResumesRequest) (SearchFacets, error)
+}
+
+type DuplicateRepository interface {
+	// FindExactDuplicates returns the tenant's other resumes sharing the normalized email, phone or LinkedIn profile
+	FindExactDuplicates(ctx context.Context, r *Resume) ([]DuplicateMatch, error)
+
+	// ListExactDuplicatePairs returns every pair of the tenant's resumes sharing contact details
+	ListExactDuplicatePairs(ctx context.Context, tenantID kernel.TenantID) ([]DuplicatePair, error)
+
+	// ListNearDuplicatePairs returns up to limit pairs of the tenant's resumes whose embeddings
+	// of the model are at least threshold similar, most similar first
+	ListNearDuplicatePairs(ctx context.Context, tenantID kernel.TenantID, model string, threshold float64, limit int) ([]DuplicatePair, error)
+
+	// CompleteMerge atomically saves the merged survivor, re-points the merged resumes' jobs to it,
+	// records the merge and deletes the merged resumes
+	CompleteMerge(ctx context.Context, merge *ResumeMerge, survivor *Resume) error
+
+	// ListMerges retrieves the merges into a resume, newest first
+	ListMerges(ctx context.Context, survivorID kernel.ResumeID) ([]*ResumeMerge, error)
+}
+
+// SkillTaxonomy resolves skill names through the skill taxonomy
+type SkillTaxonomy interface {
+	// Canonicalize maps each name to its canonical skill name; names outside the taxonomy are left out
+	Canonicalize(ctx context.Context, tenantID kernel.TenantID, names []string) (map[string]string, error)
+
+	// Expand resolves the names that satisfy each requested skill (global taxonomy only when tenantID is nil)
+	Expand(ctx context.Context, tenantID *kernel.TenantID, names []string) (SkillExpansions, error)
+}
+
+// ProcessedListener is told about resumes that finished background processing
+type ProcessedListener interface {
+	// ResumeProcessed runs in the background after the resume is saved; it must not block for long
+	ResumeProcessed(ctx context.Context, r *Resume)
 }
 
 type JobRepository interface {
@@ -74,6 +121,65 @@ type JobRepository interface {
 	MarkAsCompleted(ctx context.Context, jobID kernel.JobID, resumeID kernel.ResumeID) error
 	MarkAsFailed(ctx context.Context, jobID kernel.JobID, errorMsg string, errorDetails map[string]any) error
 	UpdateProgress(ctx context.Context, jobID kernel.JobID, step ProcessingStep, percentage int) error
+
+	// Review of drafts; each reports false when the job was no longer awaiting review
+	UpdateAwaitingReview(ctx context.Context, job *ResumeProcessingJob) (bool, error)
+	ApproveDraft(ctx context.Context, job *ResumeProcessingJob, resume *Resume) (bool, error)
+}
+
+// EmbeddingMigrationRepository persists embedding migrations and the per-tenant active model
+type EmbeddingMigrationRepository interface {
+	// Create creates a pending migration
+	Create(ctx context.Context, migration *EmbeddingMigration) error
+
+	// GetByID retrieves a migration by ID
+	GetByID(ctx context.Context, id kernel.EmbeddingMigrationID) (*EmbeddingMigration, error)
+
+	// ListByTenant retrieves the migrations of a tenant, newest first
+	ListByTenant(ctx context.Context, tenantID kernel.TenantID) ([]*EmbeddingMigration, error)
+
+	// ClaimNext marks the oldest pending migration, or a running one whose heartbeat
+	// is older than staleAfter, as running and returns it; nil when there is none
+	ClaimNext(ctx context.Context, staleAfter time.Duration) (*EmbeddingMigration, error)
+
+	// UpdateProgress stores the counters and refreshes the heartbeat
+	UpdateProgress(ctx context.Context, migration *EmbeddingMigration) error
+
+	// UpdateStatus stores the status, error message and lifecycle timestamps
+	UpdateStatus(ctx context.Context, migration *EmbeddingMigration) error
+
+	// CountPending counts the tenant's resumes without up-to-date vectors for the model
+	CountPending(ctx context.Context, tenantID kernel.TenantID, model string) (int, error)
+
+	// ListPendingResumeIDs returns resumes without up-to-date vectors for the model, skipping excluded IDs
+	ListPendingResumeIDs(ctx context.Context, tenantID kernel.TenantID, model string, exclude []kernel.ResumeID, limit int) ([]kernel.ResumeID, error)
+
+	// GetActiveModel returns the tenant's active embedding model; empty when the default applies
+	GetActiveModel(ctx context.Context, tenantID kernel.TenantID) (string, error)
+
+	// EnsureActiveModel records model as the tenant's active model unless one is already set
+	EnsureActiveModel(ctx context.Context, tenantID kernel.TenantID, model string) error
+
+	// Cutover atomically makes the target model active and marks the migration as cut over
+	Cutover(ctx context.Context, migration *EmbeddingMigration) error
+
+	// DeleteModelEmbeddings deletes the tenant's vectors of a model and returns how many rows were removed
+	DeleteModelEmbeddings(ctx context.Context, tenantID kernel.TenantID, model string) (int64, error)
+
+	// EnsureVectorIndexes creates the vector indexes for a dimension if missing
+	EnsureVectorIndexes(ctx context.Context, dim int) error
+
+	// GetVectorIndexSettings returns the HNSW parameters and vector storage in use
+	GetVectorIndexSettings(ctx context.Context) (*VectorIndexSettings, error)
+}
+
+// SearchResultCache keeps ranked searches for the cursors of their pages
+type SearchResultCache interface {
+	// Save stores a search under a token until it expires
+	Save(ctx context.Context, token string, snapshot *SearchSnapshot) error
+
+	// Get returns the search stored under a token, or nil when it has expired
+	Get(ctx context.Context, token string) (*SearchSnapshot, error)
 }
 
 // Queue defines the interface for job queue operations
@@ -0,0 +1,307 @@
+package resume
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/skill"
+)
+
+// QueryInterpreter identifies what turned a free-text query into filters
+type QueryInterpreter string
+
+const (
+	QueryInterpreterLLM   QueryInterpreter = "llm"   // Extracted by the language model
+	QueryInterpreterRules QueryInterpreter = "rules" // Deterministic fallback, see InterpretQueryRules
+)
+
+// QueryInterpretation - Filters understood from a free-text search query.
+// The UI shows them so the recruiter can edit them and search again without interpretation.
+type QueryInterpretation struct {
+	OriginalQuery      string                `json:"original_query"`
+	SemanticQuery      string                `json:"semantic_query"` // What is left once the filters are taken out
+	MinYearsExperience *float64              `json:"min_years_experience,omitempty"`
+	Locations          []string              `json:"locations"`
+	Languages          []LanguageRequirement `json:"languages"`
+	RequiredSkills     []string              `json:"required_skills"`
+	InterpretedBy      QueryInterpreter      `json:"interpreted_by"`
+}
+
+// InterpretQueryRequest - Preview how a query would be interpreted
+type InterpretQueryRequest struct {
+	Query    string          `json:"query" validate:"required"`
+	TenantID kernel.TenantID `json:"-"`
+}
+
+// ApplyTo fills the search filters the request does not set yet and replaces
+// the query with its semantic remainder. Filters set explicitly always win.
+func (q *QueryInterpretation) ApplyTo(req *SearchResumesRequest) {
+	if q.SemanticQuery != "" {
+		req.Query = q.SemanticQuery
+	}
+
+	if req.MinYearsExperience == nil && q.MinYearsExperience != nil {
+		years := *q.MinYearsExperience
+		req.MinYearsExperience = &years
+	}
+
+	req.Locations = appendMissing(req.Locations, q.Locations)
+	req.RequiredSkills = appendMissing(req.RequiredSkills, q.RequiredSkills)
+
+	for _, lang := range q.Languages {
+		present := false
+		for _, existing := range req.Languages {
+			if strings.EqualFold(strings.TrimSpace(existing.Language), lang.Language) {
+				present = true
+				break
+			}
+		}
+		if !present {
+			req.Languages = append(req.Languages, lang)
+		}
+	}
+}
+
+// appendMissing appends the values not already present, compared case-insensitively
+func appendMissing(values, extra []string) []string {
+	for _, v := range extra {
+		present := false
+		for _, existing := range values {
+			if skill.Normalize(existing) == skill.Normalize(v) {
+				present = true
+				break
+			}
+		}
+		if !present {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// ============================================================================
+// Deterministic Interpretation
+// ============================================================================
+
+// queryLanguages maps spoken language names, in English and Spanish, to their English name
+var queryLanguages = map[string]string{
+	"english": "English", "inglés": "English", "ingles": "English",
+	"spanish": "Spanish", "español": "Spanish", "espanol": "Spanish", "castellano": "Spanish",
+	"portuguese": "Portuguese", "portugués": "Portuguese", "portugues": "Portuguese",
+	"french": "French", "francés": "French", "frances": "French",
+	"german": "German", "alemán": "German", "aleman": "German",
+	"italian": "Italian", "italiano": "Italian",
+	"chinese": "Chinese", "mandarin": "Chinese", "chino": "Chinese", "mandarín": "Chinese",
+	"japanese": "Japanese", "japonés": "Japanese", "japones": "Japanese",
+}
+
+const queryProficiencyWords = `native|nativo|bilingual|bilingüe|fluent|fluido|advanced|avanzado|professional|profesional|intermediate|intermedio|conversational|basic|básico|basico|c1|c2|b1|b2`
+
+var (
+	queryYearsPattern = regexp.MustCompile(
+		`(?i)(?:at\s+least\s+|minimum\s+(?:of\s+)?|more\s+than\s+|over\s+|m[aá]s\s+de\s+|al\s+menos\s+)?` +
+			`(\d+(?:[.,]\d+)?)\s*\+?\s*(?:years?|yrs?|años|anos)` +
+			`(?:\s+(?:of\s+)?(?:experience|exp)|\s+de\s+experiencia)?`)
+
+	queryLanguagePattern = regexp.MustCompile(
+		`(?i)(?:^|[^\p{L}\p{N}])((?:(` + queryProficiencyWords + `)\s+)?(` + languageNames() + `)(?:\s+(` + queryProficiencyWords + `))?)(?:$|[^\p{L}\p{N}])`)
+
+	// Locations are capitalized place names after a preposition: "in Lima", "based in Buenos Aires, Argentina"
+	queryLocationPattern = regexp.MustCompile(
+		`(?:^|\s)((?:[Bb]ased\s+in|[Ll]ocated\s+in|[Ii]n|[Ff]rom|[Ee]n|[Dd]esde)\s+` +
+			`(\p{Lu}[\p{L}.'-]*(?:\s+(?:de\s+)?\p{Lu}[\p{L}.'-]*)*(?:,\s*\p{Lu}[\p{L}.'-]*(?:\s+\p{Lu}[\p{L}.'-]*)*)?))`)
+)
+
+// languageNames returns the alternation of queryLanguages, longest names first
+func languageNames() string {
+	names := make([]string, 0, len(queryLanguages))
+	for name := range queryLanguages {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return strings.Join(names, "|")
+}
+
+// queryConnectors are left dangling once filters are taken out of a query
+var queryConnectors = map[string]bool{
+	"with": true, "and": true, "in": true, "who": true, "speaking": true, "speak": true, "plus": true,
+	"of": true, "experience": true, "con": true, "y": true, "en": true, "que": true, "hablen": true,
+	"de": true, "experiencia": true, "e": true, "or": true, "o": true,
+}
+
+// maxSkillWords is the longest skill name, in words, looked for in a query
+const maxSkillWords = 3
+
+// SkillCandidates returns the phrases of up to maxSkillWords words that could name a skill,
+// to be resolved through the skill taxonomy before calling InterpretQueryRules
+func SkillCandidates(query string) []string {
+	words := queryWords(query)
+	candidates := []string{}
+	seen := make(map[string]bool)
+	for i := range words {
+		for n := 1; n <= maxSkillWords && i+n <= len(words); n++ {
+			// Skill names neither start nor end with a connector ("Ruby on Rails" does contain one)
+			if queryConnectors[strings.ToLower(words[i])] || queryConnectors[strings.ToLower(words[i+n-1])] {
+				continue
+			}
+			phrase := strings.Join(words[i:i+n], " ")
+			if key := skill.Normalize(phrase); key != "" && !seen[key] {
+				seen[key] = true
+				candidates = append(candidates, phrase)
+			}
+		}
+	}
+	return candidates
+}
+
+// queryWords splits a query into words, dropping surrounding punctuation but keeping
+// characters that are part of skill names (C++, C#, Node.js)
+func queryWords(query string) []string {
+	words := []string{}
+	for _, field := range strings.FieldsFunc(query, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == ',' || r == ';'
+	}) {
+		if word := strings.Trim(field, `.:!?()[]"'`); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// InterpretQueryRules extracts filters from a query without a language model:
+// "N+ years" style experience, spoken languages with an optional proficiency,
+// capitalized places after "in"/"from" and the skills found in canonicalSkills,
+// which maps skill candidates (see SkillCandidates) to their canonical names.
+// Skills stay in the semantic query since they also describe the profile.
+func InterpretQueryRules(query string, canonicalSkills map[string]string) *QueryInterpretation {
+	interpretation := &QueryInterpretation{
+		OriginalQuery:  query,
+		Locations:      []string{},
+		Languages:      []LanguageRequirement{},
+		RequiredSkills: []string{},
+		InterpretedBy:  QueryInterpreterRules,
+	}
+
+	// Spans of the query taken out of the semantic remainder
+	removed := [][2]int{}
+
+	if m := queryYearsPattern.FindStringSubmatchIndex(query); m != nil {
+		if years, err := strconv.ParseFloat(strings.ReplaceAll(query[m[2]:m[3]], ",", "."), 64); err == nil {
+			interpretation.MinYearsExperience = &years
+			removed = append(removed, [2]int{m[0], m[1]})
+		}
+	}
+
+	for _, m := range queryLanguagePattern.FindAllStringSubmatchIndex(query, -1) {
+		lang := LanguageRequirement{Language: queryLanguages[strings.ToLower(query[m[6]:m[7]])]}
+		for _, group := range [][2]int{{m[4], m[5]}, {m[8], m[9]}} {
+			if group[0] >= 0 {
+				lang.MinProficiency = proficiencyName(LanguageProficiencyRank(query[group[0]:group[1]]))
+			}
+		}
+		interpretation.Languages = append(interpretation.Languages, lang)
+		removed = append(removed, [2]int{m[2], m[3]})
+	}
+
+	for _, m := range queryLocationPattern.FindAllStringSubmatchIndex(query, -1) {
+		location := query[m[4]:m[5]]
+		if _, isSkill := canonicalSkills[location]; isSkill || overlaps(removed, m[2], m[3]) {
+			continue // "in Go" is a skill, "in English" a language
+		}
+		interpretation.Locations = append(interpretation.Locations, location)
+		removed = append(removed, [2]int{m[2], m[3]})
+	}
+
+	// Longest phrases first, so "Ruby on Rails" wins over "Ruby"
+	words := queryWords(query)
+	used := make([]bool, len(words))
+	seen := make(map[string]bool)
+	for n := maxSkillWords; n >= 1; n-- {
+		for i := 0; i+n <= len(words); i++ {
+			if anyUsed(used[i : i+n]) {
+				continue
+			}
+			canonical, ok := canonicalSkills[strings.Join(words[i:i+n], " ")]
+			if !ok || seen[skill.Normalize(canonical)] {
+				continue
+			}
+			seen[skill.Normalize(canonical)] = true
+			interpretation.RequiredSkills = append(interpretation.RequiredSkills, canonical)
+			for j := i; j < i+n; j++ {
+				used[j] = true
+			}
+		}
+	}
+
+	interpretation.SemanticQuery = semanticRemainder(query, removed)
+	return interpretation
+}
+
+// semanticRemainder removes the spans from the query and tidies the connectors left behind
+func semanticRemainder(query string, removed [][2]int) string {
+	sort.Slice(removed, func(i, j int) bool { return removed[i][0] > removed[j][0] })
+	for _, span := range removed {
+		query = query[:span[0]] + " " + query[span[1]:]
+	}
+
+	kept := []string{}
+	for _, word := range strings.Fields(query) {
+		bare := strings.ToLower(strings.Trim(word, ",;."))
+		if bare == "" {
+			continue
+		}
+		// A connector right after another connector or at the start has nothing to connect
+		if queryConnectors[bare] && (len(kept) == 0 || queryConnectors[strings.ToLower(strings.Trim(kept[len(kept)-1], ",;."))]) {
+			continue
+		}
+		kept = append(kept, word)
+	}
+	for len(kept) > 0 && queryConnectors[strings.ToLower(strings.Trim(kept[len(kept)-1], ",;."))] {
+		kept = kept[:len(kept)-1]
+	}
+
+	return strings.TrimRight(strings.Join(kept, " "), ",;")
+}
+
+func overlaps(spans [][2]int, start, end int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+func anyUsed(used []bool) bool {
+	for _, u := range used {
+		if u {
+			return true
+		}
+	}
+	return false
+}
+
+// proficiencyName returns the canonical name of a proficiency rank, empty when unknown
+func proficiencyName(rank int) string {
+	switch rank {
+	case ProficiencyNative:
+		return "Native"
+	case ProficiencyFluent:
+		return "Fluent"
+	case ProficiencyProfessional:
+		return "Professional"
+	case ProficiencyIntermediate:
+		return "Intermediate"
+	case ProficiencyBasic:
+		return "Basic"
+	}
+	return ""
+}
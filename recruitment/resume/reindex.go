@@ -0,0 +1,91 @@
+package resume
+
+import (
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+)
+
+// MigrationStatus is the lifecycle state of an embedding migration
+type MigrationStatus string
+
+const (
+	MigrationStatusPending   MigrationStatus = "pending"   // Waiting for a worker
+	MigrationStatusRunning   MigrationStatus = "running"   // Writing target vectors next to the source ones
+	MigrationStatusCompleted MigrationStatus = "completed" // Every resume has target vectors; ready for cutover
+	MigrationStatusFailed    MigrationStatus = "failed"    // Stopped on an unrecoverable error
+	MigrationStatusCutover   MigrationStatus = "cutover"   // Target model is now the tenant's active model
+	MigrationStatusCleaned   MigrationStatus = "cleaned"   // Source vectors have been deleted
+)
+
+// EmbeddingMigration re-embeds every resume of a tenant with a new model.
+// Target vectors are written next to the source ones, so search keeps using the
+// source model until the migration is cut over.
+type EmbeddingMigration struct {
+	ID          kernel.EmbeddingMigrationID `db:"id" json:"id"`
+	TenantID    kernel.TenantID             `db:"tenant_id" json:"tenant_id"`
+	SourceModel string                      `db:"source_model" json:"source_model"`
+	TargetModel string                      `db:"target_model" json:"target_model"`
+	TargetDim   int                         `db:"target_dim" json:"target_dim"`
+	Status      MigrationStatus             `db:"status" json:"status"`
+
+	Total        int    `db:"total" json:"total"`
+	Processed    int    `db:"processed" json:"processed"`
+	FailedCount  int    `db:"failed_count" json:"failed_count"`
+	ErrorMessage string `db:"error_message" json:"error_message,omitempty"`
+
+	HeartbeatAt *time.Time `db:"heartbeat_at" json:"heartbeat_at,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	StartedAt   *time.Time `db:"started_at" json:"started_at,omitempty"`
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at,omitempty"`
+	CutoverAt   *time.Time `db:"cutover_at" json:"cutover_at,omitempty"`
+	CleanedAt   *time.Time `db:"cleaned_at" json:"cleaned_at,omitempty"`
+}
+
+// IsInProgress checks if the migration is still writing target vectors
+func (m *EmbeddingMigration) IsInProgress() bool {
+	return m.Status == MigrationStatusPending || m.Status == MigrationStatusRunning
+}
+
+// CanCutover checks if search can be switched to the target model
+func (m *EmbeddingMigration) CanCutover() bool {
+	return m.Status == MigrationStatusCompleted
+}
+
+// CanCleanup checks if the source vectors can be deleted
+func (m *EmbeddingMigration) CanCleanup() bool {
+	return m.Status == MigrationStatusCutover
+}
+
+// ProgressPercentage returns the share of resumes processed, 0-100
+func (m *EmbeddingMigration) ProgressPercentage() int {
+	if m.Total <= 0 {
+		if m.IsInProgress() {
+			return 0
+		}
+		return 100
+	}
+	return min(m.Processed*100/m.Total, 100)
+}
+
+// StartEmbeddingMigrationRequest - Re-embed the tenant's resumes with another model
+type StartEmbeddingMigrationRequest struct {
+	TenantID    kernel.TenantID `json:"-"`
+	TargetModel string          `json:"target_model" validate:"required"`
+}
+
+// EmbeddingMigrationResponse - Migration state with progress
+type EmbeddingMigrationResponse struct {
+	EmbeddingMigration
+	ActiveModel        string `json:"active_model"`
+	ProgressPercentage int    `json:"progress_percentage"`
+}
+
+// ToEmbeddingMigrationResponse converts a migration to its response
+func ToEmbeddingMigrationResponse(m *EmbeddingMigration, activeModel string) *EmbeddingMigrationResponse {
+	return &EmbeddingMigrationResponse{
+		EmbeddingMigration: *m,
+		ActiveModel:        activeModel,
+		ProgressPercentage: m.ProgressPercentage(),
+	}
+}
@@ -0,0 +1,137 @@
+package resume
+
+import (
+	"cmp"
+	"math"
+	"sort"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+)
+
+// RerankConfigKey is the tenant config key holding the tenant's re-ranking settings (JSON)
+const RerankConfigKey = "resume.search.rerank"
+
+// MaxRerankCandidates is the most search hits that can be sent to the reranker at once
+const MaxRerankCandidates = 50
+
+// RerankSettings - Per-tenant second-stage re-ranking of search results
+type RerankSettings struct {
+	Enabled         bool    `json:"enabled"`
+	TopN            int     `json:"top_n"`              // Number of top hits re-ranked
+	MaxCostPerQuery float64 `json:"max_cost_per_query"` // USD; fewer hits are re-ranked to stay under it
+}
+
+// DefaultRerankSettings returns the settings of tenants that have not configured re-ranking
+func DefaultRerankSettings() RerankSettings {
+	return RerankSettings{
+		Enabled:         false,
+		TopN:            20,
+		MaxCostPerQuery: 0.01,
+	}
+}
+
+// Validate checks the candidate count and cost cap
+func (s RerankSettings) Validate() error {
+	if s.TopN < 1 || s.TopN > MaxRerankCandidates {
+		return ErrInvalidSearchRequest().
+			WithDetail("field", "top_n").
+			WithDetail("value", s.TopN).
+			WithDetail("reason", "top_n must be between 1 and 50")
+	}
+	if s.MaxCostPerQuery < 0 || math.IsNaN(s.MaxCostPerQuery) {
+		return ErrInvalidSearchRequest().
+			WithDetail("field", "max_cost_per_query").
+			WithDetail("value", s.MaxCostPerQuery).
+			WithDetail("reason", "max_cost_per_query must not be negative")
+	}
+	return nil
+}
+
+// RerankScore - Relevance of a search hit according to the reranker
+type RerankScore struct {
+	ResumeID      kernel.ResumeID
+	Score         float64 // 0-1
+	Justification string
+}
+
+// RerankSummary - How the second stage ran for a search
+type RerankSummary struct {
+	Applied       bool    `json:"applied"`
+	Reranker      string  `json:"reranker,omitempty"`
+	Candidates    int     `json:"candidates"`               // Hits sent to the reranker
+	EstimatedCost float64 `json:"estimated_cost"`           // USD
+	SkippedReason string  `json:"skipped_reason,omitempty"` // Set when the results keep their first-pass order
+}
+
+// ApplyRerankScores orders the re-ranked hits by their rerank score, ahead of the
+// hits that were not re-ranked, which keep their first-pass order
+func ApplyRerankScores(results []ResumeMatchResult, scores []RerankScore) []ResumeMatchResult {
+	byID := make(map[kernel.ResumeID]RerankScore, len(scores))
+	for _, score := range scores {
+		byID[score.ResumeID] = score
+	}
+
+	reranked := []ResumeMatchResult{}
+	rest := []ResumeMatchResult{}
+	for _, result := range results {
+		score, ok := byID[result.Resume.ID]
+		if !ok {
+			rest = append(rest, result)
+			continue
+		}
+		value := score.Score
+		result.RerankScore = &value
+		result.RerankJustification = score.Justification
+		reranked = append(reranked, result)
+	}
+
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return *reranked[i].RerankScore > *reranked[j].RerankScore
+	})
+
+	return append(reranked, rest...)
+}
+
+// RankScore is the value a hit is ordered by: re-ranked hits (2 + rerank score) come
+// ahead of the rest, which keep their first-pass score
+func (m ResumeMatchResult) RankScore() float64 {
+	if m.RerankScore != nil {
+		return 2 + *m.RerankScore
+	}
+	return m.SimilarityScore
+}
+
+// PaginateMatches cuts a page out of ranked hits. A cursor holds the rank score and ID
+// of the hit at the edge of a page: the next page starts right after that hit while it
+// is still among the hits, otherwise after every hit ranked above its score.
+func PaginateMatches(matches []ResumeMatchResult, opts kernel.PaginationOptions) (kernel.Paginated[ResumeMatchResult], error) {
+	opts.Direction = kernel.SortDesc // Hits are always best first
+
+	cursor, err := opts.DecodeCursor()
+	if err != nil {
+		return kernel.Paginated[ResumeMatchResult]{}, err
+	}
+	var cursorScore float64
+	if cursor != nil {
+		if cursorScore, err = cursor.FloatKey(); err != nil {
+			return kernel.Paginated[ResumeMatchResult]{}, err
+		}
+	}
+
+	position := make(map[kernel.ResumeID]int, len(matches))
+	for i, match := range matches {
+		position[match.Resume.ID] = i
+	}
+
+	return kernel.PaginateSlice(matches, opts,
+		func(match ResumeMatchResult) (string, string) {
+			return kernel.FloatSortKey(match.RankScore()), match.Resume.ID.String()
+		},
+		func(match ResumeMatchResult, c kernel.Cursor) int {
+			if at, ok := position[kernel.ResumeID(c.ID)]; ok {
+				return cmp.Compare(position[match.Resume.ID], at)
+			}
+			return cmp.Compare(cursorScore, match.RankScore()) // Lower scores come later
+		},
+	)
+}
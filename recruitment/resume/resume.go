@@ -1,9 +1,14 @@
 package resume
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/skill"
 )
 
 // Resume represents a parsed resume with embeddings
@@ -60,6 +65,82 @@ type Resume struct {
 	ParsedAt      time.Time `db:"parsed_at" json:"parsed_at"`
 	LastUpdatedAt time.Time `db:"last_updated_at" json:"last_updated_at"`
 	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+
+	// How the file was read; nil for resumes created without a file
+	ParseReport *ParseReport `db:"parse_report" json:"parse_report,omitempty"`
+
+	// Set when the parser was unsure of a field, until a recruiter clears it
+	NeedsReview bool `db:"needs_review" json:"needs_review"`
+}
+
+// SupportedFileTypes are the resume file types that can be uploaded and parsed
+var SupportedFileTypes = []string{"pdf", "jpg", "jpeg", "png", "docx", "doc", "odt", "rtf", "txt", "html"}
+
+// ParseMethod is how the pages of a resume file were read before extraction
+type ParseMethod string
+
+const (
+	ParseMethodText   ParseMethod = "text"   // Text layer of every page
+	ParseMethodVision ParseMethod = "vision" // Images of every page, read by a vision model
+	ParseMethodMixed  ParseMethod = "mixed"  // Text layer where usable, images of the other pages
+)
+
+// ReviewConfidenceThreshold is the parser confidence below which a field, and so
+// its resume, needs review
+const ReviewConfidenceThreshold = 0.6
+
+// ParseReport records how a resume file was parsed, for auditing
+type ParseReport struct {
+	Method        ParseMethod            `json:"method"`
+	Pages         int                    `json:"pages"`
+	VisionPages   []int                  `json:"vision_pages,omitempty"`   // 1-based pages sent as images
+	Fields        map[string]FieldSource `json:"fields,omitempty"`         // By field path, e.g. "personal_info.email" or "experience[0]"
+	LowConfidence []string               `json:"low_confidence,omitempty"` // Field paths below ReviewConfidenceThreshold
+	Unnormalized  []NormalizationIssue   `json:"unnormalized,omitempty"`   // Values kept as written, see NormalizationIssue
+}
+
+// NormalizationIssue is a value that could not be rewritten in its canonical form
+// (ISO country, E.164 phone, YYYY-MM date) and was kept as written
+type NormalizationIssue struct {
+	Field  string `json:"field"` // e.g. "personal_info.phone" or "work_experience[0].start_date"
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// FieldSource is the parser's confidence in an extracted field and the page it came from
+type FieldSource struct {
+	Confidence float64 `json:"confidence"`     // 0 (guessed) to 1 (printed clearly)
+	Page       int     `json:"page,omitempty"` // 1-based, 0 when unknown
+}
+
+// SetFields records the field sources reported by the parser and which of them
+// fall below ReviewConfidenceThreshold
+func (r *ParseReport) SetFields(fields map[string]FieldSource) {
+	r.Fields = fields
+	r.LowConfidence = nil
+	for path, source := range fields {
+		if source.Confidence < ReviewConfidenceThreshold {
+			r.LowConfidence = append(r.LowConfidence, path)
+		}
+	}
+	slices.Sort(r.LowConfidence)
+}
+
+// NeedsReview reports whether the parser was unsure of any field
+func (r *ParseReport) NeedsReview() bool {
+	return r != nil && len(r.LowConfidence) > 0
+}
+
+// NewParseReport derives the method from the pages that had to be sent as images
+func NewParseReport(pages int, visionPages []int) *ParseReport {
+	method := ParseMethodMixed
+	switch len(visionPages) {
+	case 0:
+		method = ParseMethodText
+	case pages:
+		method = ParseMethodVision
+	}
+	return &ParseReport{Method: method, Pages: pages, VisionPages: visionPages}
 }
 
 // PersonalInfo contains personal information
@@ -78,7 +159,7 @@ type PersonalInfo struct {
 type Location struct {
 	City    string `json:"city"`
 	State   string `json:"state,omitempty"`
-	Country string `json:"country"`
+	Country string `json:"country"` // ISO 3166-1 alpha-2 once normalized
 	ZipCode string `json:"zip_code,omitempty"`
 }
 
@@ -86,7 +167,7 @@ type Location struct {
 type WorkExperience struct {
 	Company               string   `json:"company"`
 	Title                 string   `json:"title"`
-	StartDate             string   `json:"start_date"` // YYYY-MM format
+	StartDate             string   `json:"start_date"` // YYYY-MM format (YYYY when only the year is known)
 	EndDate               string   `json:"end_date"`   // YYYY-MM or "Present"
 	DurationMonths        int      `json:"duration_months"`
 	DescriptionNormalized string   `json:"description_normalized"`
@@ -104,6 +185,7 @@ type Education struct {
 	Field                 string   `json:"field"`
 	GraduationDate        string   `json:"graduation_date"`
 	GPA                   *float64 `json:"gpa,omitempty"`
+	GPAScale              *float64 `json:"gpa_scale,omitempty"` // Best grade of the scale the GPA is on, e.g. 4 or 20; nil when not written
 	Honors                []string `json:"honors,omitempty"`
 	Coursework            []string `json:"coursework,omitempty"`
 	DescriptionNormalized string   `json:"description_normalized"`
@@ -168,6 +250,15 @@ type PersonalStatement struct {
 	WrittenAt      *time.Time `json:"written_at,omitempty"`
 }
 
+const (
+	// MaxEmbeddingDimension is the largest vector size pgvector can index with HNSW,
+	// which takes halfvec storage above MaxFullEmbeddingDimension
+	MaxEmbeddingDimension = 4000
+
+	// MaxFullEmbeddingDimension is the largest full-precision vector HNSW can index
+	MaxFullEmbeddingDimension = 2000
+)
+
 // ResumeEmbeddings - Multi-section embeddings for semantic search
 type ResumeEmbeddings struct {
 	ExperienceEmbedding        []float32 `json:"experience_embedding"`
@@ -178,8 +269,41 @@ type ResumeEmbeddings struct {
 	ModelUsed                  string    `json:"model_used"`
 	EmbeddingDim               int       `json:"embedding_dim"`
 	GeneratedAt                time.Time `json:"generated_at"`
+
+	// SectionHashes holds the content hash of the text each section vector was generated from
+	SectionHashes map[string]string `json:"section_hashes,omitempty"`
+
+	// Entries holds one vector per work experience and project
+	Entries []EntryEmbedding `json:"entries,omitempty"`
+}
+
+// EntryType identifies the resume list an entry embedding belongs to
+type EntryType string
+
+const (
+	EntryTypeWorkExperience EntryType = "work_experience"
+	EntryTypeProject        EntryType = "project"
+)
+
+// EntryEmbedding - Embedding of a single work experience or project
+type EntryEmbedding struct {
+	Type         EntryType `json:"type"`
+	Index        int       `json:"index"` // Position in WorkExperience or Projects
+	Title        string    `json:"title"`
+	Organization string    `json:"organization,omitempty"`
+	Embedding    []float32 `json:"embedding"`
+	ContentHash  string    `json:"content_hash"`
 }
 
+// Embedding section names, as used in SectionHashes
+const (
+	SectionExperience        = "experience"
+	SectionEducation         = "education"
+	SectionSkills            = "skills"
+	SectionLanguages         = "languages"
+	SectionPersonalStatement = "personal_statement"
+)
+
 // ============================================================================
 // Domain Methods
 // ============================================================================
@@ -227,15 +351,11 @@ func (r *Resume) TotalYearsOfExperience() float64 {
 	return float64(totalMonths) / 12.0
 }
 
-// HasSkill checks if resume has a specific skill
+// HasSkill checks if resume has a specific skill, ignoring case and spacing
 func (r *Resume) HasSkill(skillName string) bool {
-	for _, skill := range r.Skills.HardSkills {
-		if skill.Name == skillName {
-			return true
-		}
-	}
-	for _, skill := range r.Skills.SoftSkills {
-		if skill.Name == skillName {
+	key := skill.Normalize(skillName)
+	for _, name := range r.GetAllSkills() {
+		if skill.Normalize(name) == key {
 			return true
 		}
 	}
@@ -254,6 +374,97 @@ func (r *Resume) GetAllSkills() []string {
 	return skills
 }
 
+// MatchSkills returns the given skills that the resume has, without duplicates.
+// A skill matches when one of the resume's skills is among the names the expansions
+// accept for it (aliases and sub-skills); without expansions names are compared normalized.
+func (r *Resume) MatchSkills(skillNames []string, expansions SkillExpansions) []string {
+	owned := make(map[string]bool)
+	for _, name := range r.GetAllSkills() {
+		owned[skill.Normalize(name)] = true
+	}
+
+	matched := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, name := range skillNames {
+		key := skill.Normalize(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		for _, accepted := range expansions.Accepted(name) {
+			if owned[accepted] {
+				matched = append(matched, name)
+				seen[key] = true
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// AllSkillNames returns the names of the skills and of the skills used in work experience
+func (r *Resume) AllSkillNames() []string {
+	names := r.GetAllSkills()
+	for _, exp := range r.WorkExperience {
+		names = append(names, exp.SkillsUsed...)
+	}
+	return names
+}
+
+// CanonicalizeSkills renames skills to their canonical names and merges duplicates,
+// keeping the most years of experience and the first stated proficiency.
+// canonical maps original names to canonical names; unmapped names are kept.
+func (r *Resume) CanonicalizeSkills(canonical map[string]string) {
+	rename := func(name string) string {
+		if c, ok := canonical[name]; ok && c != "" {
+			return c
+		}
+		return strings.TrimSpace(name)
+	}
+
+	r.Skills.HardSkills = mergeSkills(r.Skills.HardSkills, rename)
+	r.Skills.SoftSkills = mergeSkills(r.Skills.SoftSkills, rename)
+
+	for i := range r.WorkExperience {
+		used := make([]string, 0, len(r.WorkExperience[i].SkillsUsed))
+		seen := make(map[string]bool)
+		for _, name := range r.WorkExperience[i].SkillsUsed {
+			name = rename(name)
+			if key := skill.Normalize(name); key != "" && !seen[key] {
+				seen[key] = true
+				used = append(used, name)
+			}
+		}
+		r.WorkExperience[i].SkillsUsed = used
+	}
+}
+
+func mergeSkills(skills []Skill, rename func(string) string) []Skill {
+	merged := make([]Skill, 0, len(skills))
+	index := make(map[string]int)
+	for _, s := range skills {
+		s.Name = rename(s.Name)
+		key := skill.Normalize(s.Name)
+		if key == "" {
+			continue
+		}
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, s)
+			continue
+		}
+
+		if s.YearsExperience != nil && (merged[i].YearsExperience == nil || *s.YearsExperience > *merged[i].YearsExperience) {
+			merged[i].YearsExperience = s.YearsExperience
+		}
+		if merged[i].ProficiencyLevel == "" {
+			merged[i].ProficiencyLevel = s.ProficiencyLevel
+		}
+	}
+	return merged
+}
+
 // HasCertification checks if resume has a specific certification
 func (r *Resume) HasCertification(certName string) bool {
 	for _, cert := range r.Certifications {
@@ -315,6 +526,97 @@ func (r *Resume) GetHighestEducation() *Education {
 	return &r.Education[0]
 }
 
+// CheckDimension verifies every section vector has the recorded dimension and can be indexed
+func (e ResumeEmbeddings) CheckDimension() error {
+	if e.EmbeddingDim <= 0 || e.EmbeddingDim > MaxEmbeddingDimension {
+		return ErrEmbeddingDimMismatch().
+			WithDetail("model", e.ModelUsed).
+			WithDetail("dimension", e.EmbeddingDim).
+			WithDetail("max", MaxEmbeddingDimension)
+	}
+
+	sections := map[string][]float32{
+		"experience":         e.ExperienceEmbedding,
+		"education":          e.EducationEmbedding,
+		"skills":             e.SkillsEmbedding,
+		"languages":          e.LanguagesEmbedding,
+		"personal_statement": e.PersonalStatementEmbedding,
+	}
+	for _, entry := range e.Entries {
+		sections[fmt.Sprintf("%s[%d]", entry.Type, entry.Index)] = entry.Embedding
+	}
+	for section, vector := range sections {
+		if len(vector) > 0 && len(vector) != e.EmbeddingDim {
+			return ErrEmbeddingDimMismatch().
+				WithDetail("section", section).
+				WithDetail("model", e.ModelUsed).
+				WithDetail("expected", e.EmbeddingDim).
+				WithDetail("actual", len(vector))
+		}
+	}
+	return nil
+}
+
+// Section returns the vector of a section, nil when missing
+func (e ResumeEmbeddings) Section(name string) []float32 {
+	switch name {
+	case SectionExperience:
+		return e.ExperienceEmbedding
+	case SectionEducation:
+		return e.EducationEmbedding
+	case SectionSkills:
+		return e.SkillsEmbedding
+	case SectionLanguages:
+		return e.LanguagesEmbedding
+	case SectionPersonalStatement:
+		return e.PersonalStatementEmbedding
+	}
+	return nil
+}
+
+// SetSection sets the vector of a section
+func (e *ResumeEmbeddings) SetSection(name string, vector []float32) {
+	switch name {
+	case SectionExperience:
+		e.ExperienceEmbedding = vector
+	case SectionEducation:
+		e.EducationEmbedding = vector
+	case SectionSkills:
+		e.SkillsEmbedding = vector
+	case SectionLanguages:
+		e.LanguagesEmbedding = vector
+	case SectionPersonalStatement:
+		e.PersonalStatementEmbedding = vector
+	}
+}
+
+// ReusableSection returns the stored vector of a section if it was generated by the
+// same model from text with the given hash
+func (e ResumeEmbeddings) ReusableSection(name, model string, dim int, hash string) ([]float32, bool) {
+	if e.ModelUsed != model || e.EmbeddingDim != dim || e.SectionHashes[name] != hash {
+		return nil, false
+	}
+	vector := e.Section(name)
+	if len(vector) != dim {
+		return nil, false
+	}
+	return vector, true
+}
+
+// ReusableEntry returns a stored entry vector generated by the same model from text with
+// the given hash, wherever the entry sits in the list
+func (e ResumeEmbeddings) ReusableEntry(entryType EntryType, model string, dim int, hash string) ([]float32, bool) {
+	if e.ModelUsed != model || e.EmbeddingDim != dim {
+		return nil, false
+	}
+	for _, entry := range e.Entries {
+		if entry.Type == entryType && entry.ContentHash == hash && len(entry.Embedding) == dim {
+			return entry.Embedding, true
+		}
+	}
+	return nil, false
+}
+
 // UpdateEmbeddings updates the embeddings for the resume
 func (r *Resume) UpdateEmbeddings(embeddings ResumeEmbeddings) {
 	r.Embeddings = embeddings
@@ -328,3 +630,95 @@ func (r *Resume) AddPersonalStatement(statement PersonalStatement) {
 	r.PersonalStatement = statement
 	r.LastUpdatedAt = now
 }
+
+// ============================================================================
+// Language & Education Ladders
+// ============================================================================
+
+// Language proficiency ranks, lowest to highest.
+// Must stay in sync with language_proficiency_rank() in migrations.
+const (
+	ProficiencyUnknown = iota
+	ProficiencyBasic
+	ProficiencyIntermediate
+	ProficiencyProfessional
+	ProficiencyFluent
+	ProficiencyNative
+)
+
+// Education level ranks, lowest to highest (bachelor < master < PhD).
+// Must stay in sync with education_level_rank() in migrations.
+const (
+	EducationUnknown = iota
+	EducationHighSchool
+	EducationAssociate
+	EducationBachelor
+	EducationMaster
+	EducationDoctorate
+)
+
+type rankPattern struct {
+	rank    int
+	pattern *regexp.Regexp
+}
+
+// Checked in order, so more specific levels come first
+var languageProficiencyPatterns = []rankPattern{
+	{ProficiencyNative, regexp.MustCompile(`(?i)native|nativo|bilingual|biling[uü]e|mother tongue|materna`)},
+	{ProficiencyFluent, regexp.MustCompile(`(?i)fluent|fluido|fluency|advanced|avanzado|\bc1\b|\bc2\b`)},
+	{ProficiencyProfessional, regexp.MustCompile(`(?i)professional|profesional|upper|\bb2\b`)},
+	{ProficiencyIntermediate, regexp.MustCompile(`(?i)intermediate|intermedio|conversational|\bb1\b`)},
+	{ProficiencyBasic, regexp.MustCompile(`(?i)basic|b[aá]sico|beginner|elementary|principiante|\ba1\b|\ba2\b`)},
+}
+
+var educationLevelPatterns = []rankPattern{
+	{EducationDoctorate, regexp.MustCompile(`(?i)ph\.?\s?d|doctor|doctorado`)},
+	{EducationMaster, regexp.MustCompile(`(?i)master|maestr[ií]a|mag[ií]ster|\bmba\b|\bm\.?sc?\b|\bm\.?a\b`)},
+	{EducationBachelor, regexp.MustCompile(`(?i)bachelor|bachiller|licenciad|licenciatura|ingenier|engineer|undergraduate|\bb\.?sc?\b|\bb\.?a\b`)},
+	{EducationAssociate, regexp.MustCompile(`(?i)associate|t[eé]cnico|technical|technician|diploma`)},
+	{EducationHighSchool, regexp.MustCompile(`(?i)high[ _-]?school|secondary|secundaria`)},
+}
+
+func matchRank(patterns []rankPattern, value string) int {
+	for _, p := range patterns {
+		if p.pattern.MatchString(value) {
+			return p.rank
+		}
+	}
+	return 0
+}
+
+// LanguageProficiencyRank maps a free-text proficiency (e.g. "Fluent", "C1", "Nativo") to its rank
+func LanguageProficiencyRank(proficiency string) int {
+	return matchRank(languageProficiencyPatterns, proficiency)
+}
+
+// EducationLevelRank maps a degree or level name (e.g. "MSc Computer Science", "phd") to its rank
+func EducationLevelRank(degree string) int {
+	return matchRank(educationLevelPatterns, degree)
+}
+
+// MeetsLanguageRequirement checks if the person speaks the language at least at the given proficiency
+func (r *Resume) MeetsLanguageRequirement(req LanguageRequirement) bool {
+	minRank := LanguageProficiencyRank(req.MinProficiency)
+	for _, language := range r.Languages {
+		if !strings.EqualFold(strings.TrimSpace(language.Language), strings.TrimSpace(req.Language)) {
+			continue
+		}
+		if minRank == ProficiencyUnknown || LanguageProficiencyRank(language.Proficiency) >= minRank {
+			return true
+		}
+	}
+	return false
+}
+
+// HighestEducationRank returns the highest education level rank across all entries
+func (r *Resume) HighestEducationRank() int {
+	highest := EducationUnknown
+	for _, edu := range r.Education {
+		if rank := EducationLevelRank(edu.Degree); rank > highest {
+			highest = rank
+		}
+	}
+	return highest
+}
@@ -3,6 +3,7 @@ package resumeapi
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/Abraxas-365/relay/pkg/fsx"
@@ -45,18 +46,47 @@ func (h *ResumeHandlers) RegisterRoutes(app *fiber.App, authMiddleware *auth.Uni
 	resumes.Post("/jobs/:job_id/cancel", h.CancelJob) // Cancel job
 	resumes.Post("/jobs/:job_id/retry", h.RetryJob)   // Retry failed job
 
+	// Review of Parsed Resumes
+	resumes.Put("/jobs/:job_id/draft", h.UpdateJobDraft)        // Correct a draft awaiting review
+	resumes.Post("/jobs/:job_id/approve", h.ApproveJob)         // Create the resume from the draft
+	resumes.Post("/jobs/:job_id/reject", h.RejectJob)           // Discard the draft
+	resumes.Get("/parse/review", h.GetParseReviewSettings)      // Get tenant review settings
+	resumes.Put("/parse/review", h.SetParseReviewSettings)      // Choose when parses are reviewed
+	resumes.Delete("/parse/review", h.ResetParseReviewSettings) // Reset review settings (off)
+
 	// Search & Stats
-	resumes.Post("/search", h.SearchResumes) // Semantic search
-	resumes.Get("/stats", h.GetStats)        // Get statistics
+	resumes.Post("/search", h.SearchResumes)                // Semantic search
+	resumes.Post("/search/interpret", h.InterpretQuery)     // Preview filters extracted from a query
+	resumes.Get("/search/weights", h.GetSearchWeights)      // Get tenant default section weights
+	resumes.Put("/search/weights", h.SetSearchWeights)      // Set tenant default section weights
+	resumes.Delete("/search/weights", h.ResetSearchWeights) // Reset to global default weights
+	resumes.Get("/search/rerank", h.GetRerankSettings)      // Get tenant re-ranking settings
+	resumes.Put("/search/rerank", h.SetRerankSettings)      // Enable or tune re-ranking
+	resumes.Delete("/search/rerank", h.ResetRerankSettings) // Reset re-ranking (disabled)
+	resumes.Get("/stats", h.GetStats)                       // Get statistics
 
 	// Resume Management
 	resumes.Put("/:id/default", h.SetDefaultResume)       // Set as default
 	resumes.Put("/:id/activate", h.ToggleActive)          // Toggle active status
 	resumes.Put("/:id/statement", h.AddPersonalStatement) // Add personal statement
+	resumes.Get("/:id/similar", h.FindSimilarResumes)     // Find similar resumes
+
+	// Duplicates & Merge
+	resumes.Post("/duplicates/scan", h.ScanDuplicates) // Scan tenant for duplicates
+	resumes.Get("/:id/duplicates", h.FindDuplicates)   // Possible duplicates of a resume
+	resumes.Post("/:id/merge", h.MergeResumes)         // Merge duplicates into this resume
+	resumes.Get("/:id/merges", h.ListMerges)           // Merge history
 
 	// Embeddings Management
 	resumes.Put("/:id/embeddings", h.UpdateEmbeddings)       // Update embeddings for one
 	resumes.Post("/embeddings/bulk", h.BulkUpdateEmbeddings) // Bulk update embeddings
+
+	// Embedding Model Migrations
+	resumes.Post("/embeddings/migrations", h.StartEmbeddingMigration)                         // Re-embed with another model
+	resumes.Get("/embeddings/migrations", h.ListEmbeddingMigrations)                          // List migrations
+	resumes.Get("/embeddings/migrations/:migration_id", h.GetEmbeddingMigration)              // Get migration progress
+	resumes.Post("/embeddings/migrations/:migration_id/cutover", h.CutoverEmbeddingMigration) // Switch search to the new model
+	resumes.Post("/embeddings/migrations/:migration_id/cleanup", h.CleanupEmbeddingMigration) // Delete old model vectors
 }
 
 // ============================================================================
@@ -130,7 +160,7 @@ func (h *ResumeHandlers) ParseResumeBulk(c *fiber.Ctx) error {
 			errors = append(errors, fiber.Map{
 				"file_name":       file.Filename,
 				"error":           "unsupported file type",
-				"supported_types": []string{"pdf", "jpg", "jpeg", "png"},
+				"supported_types": resume.SupportedFileTypes,
 				"detected_type":   file.Header.Get("Content-Type"),
 			})
 			failureCount++
@@ -282,7 +312,7 @@ func (h *ResumeHandlers) ParseResume(c *fiber.Ctx) error {
 	if fileType == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error":           "unsupported file type",
-			"supported_types": []string{"pdf", "jpg", "jpeg", "png"},
+			"supported_types": resume.SupportedFileTypes,
 			"detected_type":   file.Header.Get("Content-Type"),
 			"file_extension":  filepath.Ext(file.Filename),
 		})
@@ -376,7 +406,7 @@ func (h *ResumeHandlers) CreateResume(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
-// GetResume retrieves a resume by ID
+// GetResume retrieves a resume by ID, with its possible duplicates when include=duplicates
 // GET /api/v1/resumes/:id
 func (h *ResumeHandlers) GetResume(c *fiber.Ctx) error {
 	authCtx, ok := auth.GetAuthContext(c)
@@ -393,6 +423,14 @@ func (h *ResumeHandlers) GetResume(c *fiber.Ctx) error {
 		})
 	}
 
+	if c.Query("include") == "duplicates" {
+		response, err := h.service.GetResumeWithDuplicates(c.Context(), resumeID, authCtx.TenantID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(response)
+	}
+
 	response, err := h.service.GetResume(c.Context(), resumeID)
 	if err != nil {
 		return err
@@ -495,7 +533,7 @@ func (h *ResumeHandlers) DeleteResume(c *fiber.Ctx) error {
 }
 
 // ListResumes lists all resumes for the tenant
-// GET /api/v1/resumes?page=1&page_size=20&only_active=false
+// GET /api/v1/resumes?page_size=20&cursor=...&direction=desc&only_active=false&needs_review=true
 func (h *ResumeHandlers) ListResumes(c *fiber.Ctx) error {
 	authCtx, ok := auth.GetAuthContext(c)
 	if !ok {
@@ -507,10 +545,12 @@ func (h *ResumeHandlers) ListResumes(c *fiber.Ctx) error {
 	req := resume.ListResumesRequest{
 		TenantID:   authCtx.TenantID,
 		OnlyActive: c.QueryBool("only_active", false),
-		Pagination: kernel.PaginationOptions{
-			Page:     c.QueryInt("page", 1),
-			PageSize: c.QueryInt("page_size", 20),
-		},
+		Pagination: parsePaginationQuery(c, 20),
+		Facets:     parseFacetsQuery(c),
+	}
+	if c.Query("needs_review") != "" {
+		needsReview := c.QueryBool("needs_review", false)
+		req.NeedsReview = &needsReview
 	}
 
 	response, err := h.service.ListResumes(c.Context(), req)
@@ -558,7 +598,7 @@ func (h *ResumeHandlers) GetJobStatus(c *fiber.Ctx) error {
 }
 
 // ListJobs lists all processing jobs for the authenticated tenant
-// GET /api/v1/resumes/jobs?page=1&page_size=20
+// GET /api/v1/resumes/jobs?page_size=20&cursor=...&direction=desc
 func (h *ResumeHandlers) ListJobs(c *fiber.Ctx) error {
 	authCtx, ok := auth.GetAuthContext(c)
 	if !ok {
@@ -567,12 +607,7 @@ func (h *ResumeHandlers) ListJobs(c *fiber.Ctx) error {
 		})
 	}
 
-	pagination := kernel.PaginationOptions{
-		Page:     c.QueryInt("page", 1),
-		PageSize: c.QueryInt("page_size", 20),
-	}
-
-	jobs, err := h.service.ListJobsByTenant(c.Context(), authCtx.TenantID, pagination)
+	jobs, err := h.service.ListJobsByTenant(c.Context(), authCtx.TenantID, parsePaginationQuery(c, 20))
 	if err != nil {
 		return err
 	}
@@ -653,11 +688,180 @@ func (h *ResumeHandlers) RetryJob(c *fiber.Ctx) error {
 	})
 }
 
+// ============================================================================
+// Review Handlers
+// ============================================================================
+
+// UpdateJobDraft corrects the parsed draft of a job awaiting review
+// PUT /api/v1/resumes/jobs/:job_id/draft
+func (h *ResumeHandlers) UpdateJobDraft(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	jobID := kernel.JobID(c.Params("job_id"))
+	if jobID.IsEmpty() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid job ID",
+		})
+	}
+
+	var req resume.UpdateResumeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	draft, err := h.service.UpdateJobDraft(c.Context(), jobID, authCtx.TenantID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(draft)
+}
+
+// ApproveJob approves the draft of a job awaiting review, which creates the resume
+// POST /api/v1/resumes/jobs/:job_id/approve
+func (h *ResumeHandlers) ApproveJob(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	jobID := kernel.JobID(c.Params("job_id"))
+	if jobID.IsEmpty() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid job ID",
+		})
+	}
+
+	created, err := h.service.ApproveJob(c.Context(), jobID, authCtx.TenantID, authCtx.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "draft approved, resume created",
+		"job_id":  jobID,
+		"resume":  created,
+	})
+}
+
+// RejectJob rejects the draft of a job awaiting review; no resume is created
+// POST /api/v1/resumes/jobs/:job_id/reject
+func (h *ResumeHandlers) RejectJob(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	jobID := kernel.JobID(c.Params("job_id"))
+	if jobID.IsEmpty() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid job ID",
+		})
+	}
+
+	var req resume.RejectDraftRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+	if len(req.Reason) > 1000 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "reason must be at most 1000 characters",
+		})
+	}
+
+	jobStatus, err := h.service.RejectJob(c.Context(), jobID, authCtx.TenantID, authCtx.UserID, strings.TrimSpace(req.Reason))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "draft rejected",
+		"job":     jobStatus,
+	})
+}
+
+// GetParseReviewSettings gets the tenant's parse review settings
+// GET /api/v1/resumes/parse/review
+func (h *ResumeHandlers) GetParseReviewSettings(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	settings, err := h.service.GetParseReviewSettings(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(settings)
+}
+
+// SetParseReviewSettings chooses which parsed resumes wait for review before they are created
+// PUT /api/v1/resumes/parse/review
+func (h *ResumeHandlers) SetParseReviewSettings(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	settings := resume.DefaultParseReviewSettings()
+	if err := c.BodyParser(&settings); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	updated, err := h.service.SetParseReviewSettings(c.Context(), authCtx.TenantID, settings)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(updated)
+}
+
+// ResetParseReviewSettings resets the tenant's parse review settings to the default (off)
+// DELETE /api/v1/resumes/parse/review
+func (h *ResumeHandlers) ResetParseReviewSettings(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	settings, err := h.service.ResetParseReviewSettings(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(settings)
+}
+
 // ============================================================================
 // Search & Stats Handlers
 // ============================================================================
 
-// SearchResumes performs semantic search on resumes
+// SearchResumes performs semantic search on resumes. The cursors of a page read the
+// same ranked results until they expire, after which the search has to be run again.
 // POST /api/v1/resumes/search
 func (h *ResumeHandlers) SearchResumes(c *fiber.Ctx) error {
 	authCtx, ok := auth.GetAuthContext(c)
@@ -696,6 +900,194 @@ func (h *ResumeHandlers) SearchResumes(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// FindSimilarResumes finds resumes similar to an existing one using its stored embeddings
+// GET /api/v1/resumes/:id/similar?top_k=10&page_size=10&cursor=...&required_skills=go,sql&languages=english:fluent
+func (h *ResumeHandlers) FindSimilarResumes(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	resumeID := kernel.ResumeID(c.Params("id"))
+	if resumeID.IsEmpty() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid resume ID",
+		})
+	}
+
+	// Verify tenant ownership first
+	existing, err := h.service.GetResume(c.Context(), resumeID)
+	if err != nil {
+		return err
+	}
+
+	if existing.TenantID != authCtx.TenantID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "access denied",
+		})
+	}
+
+	req := parseSearchFiltersQuery(c)
+	req.TenantID = &authCtx.TenantID
+
+	response, err := h.service.FindSimilarResumes(c.Context(), resumeID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// InterpretQuery previews the filters a search would extract from its query
+// POST /api/v1/resumes/search/interpret
+func (h *ResumeHandlers) InterpretQuery(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	var req resume.InterpretQueryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	req.TenantID = authCtx.TenantID
+
+	response, err := h.service.InterpretQuery(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// GetSearchWeights gets the tenant's default section weights
+// GET /api/v1/resumes/search/weights
+func (h *ResumeHandlers) GetSearchWeights(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	weights, err := h.service.GetSearchWeights(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(weights)
+}
+
+// SetSearchWeights sets the tenant's default section weights
+// PUT /api/v1/resumes/search/weights
+func (h *ResumeHandlers) SetSearchWeights(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	var weights resume.SectionWeights
+	if err := c.BodyParser(&weights); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	updated, err := h.service.SetSearchWeights(c.Context(), authCtx.TenantID, weights)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(updated)
+}
+
+// ResetSearchWeights resets the tenant's section weights to the global default
+// DELETE /api/v1/resumes/search/weights
+func (h *ResumeHandlers) ResetSearchWeights(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	weights, err := h.service.ResetSearchWeights(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(weights)
+}
+
+// GetRerankSettings gets the tenant's search re-ranking settings
+// GET /api/v1/resumes/search/rerank
+func (h *ResumeHandlers) GetRerankSettings(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	settings, err := h.service.GetRerankSettings(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(settings)
+}
+
+// SetRerankSettings enables, disables or tunes search re-ranking for the tenant
+// PUT /api/v1/resumes/search/rerank
+func (h *ResumeHandlers) SetRerankSettings(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	settings := resume.DefaultRerankSettings()
+	if err := c.BodyParser(&settings); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	updated, err := h.service.SetRerankSettings(c.Context(), authCtx.TenantID, settings)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(updated)
+}
+
+// ResetRerankSettings resets the tenant's re-ranking settings to the default (disabled)
+// DELETE /api/v1/resumes/search/rerank
+func (h *ResumeHandlers) ResetRerankSettings(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	settings, err := h.service.ResetRerankSettings(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(settings)
+}
+
 // GetStats gets resume statistics for the tenant
 // GET /api/v1/resumes/stats
 func (h *ResumeHandlers) GetStats(c *fiber.Ctx) error {
@@ -915,20 +1307,338 @@ func (h *ResumeHandlers) BulkUpdateEmbeddings(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// ============================================================================
+// Embedding Model Migration Handlers
+// ============================================================================
+
+// StartEmbeddingMigration schedules re-embedding of the tenant's resumes with another model
+// POST /api/v1/resumes/embeddings/migrations
+func (h *ResumeHandlers) StartEmbeddingMigration(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	var req resume.StartEmbeddingMigrationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	if req.TargetModel == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "target_model is required",
+		})
+	}
+
+	req.TenantID = authCtx.TenantID
+
+	response, err := h.service.StartEmbeddingMigration(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+// ListEmbeddingMigrations lists the tenant's embedding migrations
+// GET /api/v1/resumes/embeddings/migrations
+func (h *ResumeHandlers) ListEmbeddingMigrations(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	migrations, err := h.service.ListEmbeddingMigrations(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"migrations": migrations,
+		"total":      len(migrations),
+	})
+}
+
+// GetEmbeddingMigration returns the progress of an embedding migration
+// GET /api/v1/resumes/embeddings/migrations/:migration_id
+func (h *ResumeHandlers) GetEmbeddingMigration(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	migrationID := kernel.EmbeddingMigrationID(c.Params("migration_id"))
+
+	response, err := h.service.GetEmbeddingMigration(c.Context(), authCtx.TenantID, migrationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// CutoverEmbeddingMigration switches the tenant's search to the migration target model
+// POST /api/v1/resumes/embeddings/migrations/:migration_id/cutover
+func (h *ResumeHandlers) CutoverEmbeddingMigration(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	migrationID := kernel.EmbeddingMigrationID(c.Params("migration_id"))
+
+	response, err := h.service.CutoverEmbeddingMigration(c.Context(), authCtx.TenantID, migrationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// CleanupEmbeddingMigration deletes the vectors of the model replaced by a migration
+// POST /api/v1/resumes/embeddings/migrations/:migration_id/cleanup
+func (h *ResumeHandlers) CleanupEmbeddingMigration(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	migrationID := kernel.EmbeddingMigrationID(c.Params("migration_id"))
+
+	response, err := h.service.CleanupEmbeddingMigration(c.Context(), authCtx.TenantID, migrationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// ============================================================================
+// Duplicates & Merge Handlers
+// ============================================================================
+
+// FindDuplicates lists resumes that may belong to the same person
+// GET /api/v1/resumes/:id/duplicates
+func (h *ResumeHandlers) FindDuplicates(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	resumeID := kernel.ResumeID(c.Params("id"))
+	if resumeID.IsEmpty() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid resume ID",
+		})
+	}
+
+	duplicates, err := h.service.FindPossibleDuplicates(c.Context(), resumeID, authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"possible_duplicates": duplicates,
+		"total":               len(duplicates),
+	})
+}
+
+// ScanDuplicates runs a duplicate pass over all of the tenant's resumes
+// POST /api/v1/resumes/duplicates/scan
+func (h *ResumeHandlers) ScanDuplicates(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	response, err := h.service.ScanDuplicates(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// MergeResumes folds duplicate resumes into this one
+// POST /api/v1/resumes/:id/merge
+func (h *ResumeHandlers) MergeResumes(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	resumeID := kernel.ResumeID(c.Params("id"))
+	if resumeID.IsEmpty() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid resume ID",
+		})
+	}
+
+	var req resume.MergeResumesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	req.TenantID = authCtx.TenantID
+	req.SurvivorID = resumeID
+	req.MergedBy = authCtx.UserID
+
+	response, err := h.service.MergeResumes(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// ListMerges lists the merges folded into a resume
+// GET /api/v1/resumes/:id/merges
+func (h *ResumeHandlers) ListMerges(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	resumeID := kernel.ResumeID(c.Params("id"))
+	if resumeID.IsEmpty() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid resume ID",
+		})
+	}
+
+	merges, err := h.service.ListResumeMerges(c.Context(), resumeID, authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"merges": merges,
+		"total":  len(merges),
+	})
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
 
+// parseSearchFiltersQuery reads SearchResumesRequest filters from query parameters.
+// List values are comma-separated; languages accept "language" or "language:min_proficiency".
+func parseSearchFiltersQuery(c *fiber.Ctx) resume.SearchResumesRequest {
+	req := resume.SearchResumesRequest{
+		TopK:            c.QueryInt("top_k", 10),
+		RequiredSkills:  splitQueryList(c.Query("required_skills")),
+		PreferredSkills: splitQueryList(c.Query("preferred_skills")),
+		Locations:       splitQueryList(c.Query("locations")),
+		Industries:      splitQueryList(c.Query("industries")),
+		OnlyActive:      c.QueryBool("only_active", false),
+		EmbeddingModel:  c.Query("embedding_model"),
+		Facets:          parseFacetsQuery(c),
+		Pagination:      parsePaginationQuery(c, 0), // Defaults to a single page of top_k hits
+	}
+
+	if c.Query("min_years_experience") != "" {
+		minYears := c.QueryFloat("min_years_experience", 0)
+		req.MinYearsExperience = &minYears
+	}
+	if c.Query("max_years_experience") != "" {
+		maxYears := c.QueryFloat("max_years_experience", 0)
+		req.MaxYearsExperience = &maxYears
+	}
+	if level := strings.TrimSpace(c.Query("education_level")); level != "" {
+		req.EducationLevel = &level
+	}
+
+	for _, lang := range splitQueryList(c.Query("languages")) {
+		name, minProficiency, _ := strings.Cut(lang, ":")
+		req.Languages = append(req.Languages, resume.LanguageRequirement{
+			Language:       strings.TrimSpace(name),
+			MinProficiency: strings.TrimSpace(minProficiency),
+		})
+	}
+
+	return req
+}
+
+// parsePaginationQuery reads page, page_size, cursor and direction. A cursor from a
+// previous response takes precedence over the page number.
+func parsePaginationQuery(c *fiber.Ctx, defaultPageSize int) kernel.PaginationOptions {
+	return kernel.PaginationOptions{
+		Page:      c.QueryInt("page", 1),
+		PageSize:  c.QueryInt("page_size", defaultPageSize),
+		Cursor:    c.Query("cursor"),
+		Direction: kernel.SortDirection(strings.ToLower(c.Query("direction"))),
+	}
+}
+
+// parseFacetsQuery reads the comma-separated facets query parameter
+func parseFacetsQuery(c *fiber.Ctx) []resume.Facet {
+	facets := []resume.Facet{}
+	for _, name := range splitQueryList(c.Query("facets")) {
+		facets = append(facets, resume.Facet(strings.ToLower(name)))
+	}
+	return facets
+}
+
+// splitQueryList splits a comma-separated query value, dropping empty items
+func splitQueryList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // determineFileType determines the file type from filename and content type
 func determineFileType(filename, contentType string) string {
-	// First try content type
-	switch contentType {
+	// First try content type, without parameters such as the charset
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.ToLower(strings.TrimSpace(mediaType)) {
 	case "application/pdf":
 		return "pdf"
 	case "image/jpeg", "image/jpg":
 		return "jpg"
 	case "image/png":
 		return "png"
+	case "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+		return "docx"
+	case "application/msword":
+		return "doc"
+	case "application/vnd.oasis.opendocument.text":
+		return "odt"
+	case "application/rtf", "text/rtf":
+		return "rtf"
+	case "text/plain":
+		return "txt"
+	case "text/html", "application/xhtml+xml":
+		return "html"
 	}
 
 	// Fallback to file extension
@@ -937,7 +1647,7 @@ func determineFileType(filename, contentType string) string {
 		return ""
 	}
 
-	ext = ext[1:] // Remove leading dot
+	ext = strings.ToLower(ext[1:]) // Remove leading dot
 	switch ext {
 	case "pdf":
 		return "pdf"
@@ -945,6 +1655,12 @@ func determineFileType(filename, contentType string) string {
 		return "jpg"
 	case "png":
 		return "png"
+	case "docx", "doc", "odt", "rtf", "txt", "html":
+		return ext
+	case "htm", "xhtml":
+		return "html"
+	case "text":
+		return "txt"
 	default:
 		return ""
 	}
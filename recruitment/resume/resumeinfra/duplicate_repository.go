@@ -0,0 +1,307 @@
+package resumeinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type PostgresDuplicateRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresDuplicateRepository(db *sqlx.DB) resume.DuplicateRepository {
+	return &PostgresDuplicateRepository{db: db}
+}
+
+// duplicateRow flags which contact details two resumes share
+type duplicateRow struct {
+	ResumeID      string `db:"resume_id"`
+	DuplicateID   string `db:"duplicate_id"`
+	EmailMatch    bool   `db:"email_match"`
+	PhoneMatch    bool   `db:"phone_match"`
+	LinkedInMatch bool   `db:"linkedin_match"`
+}
+
+func (r duplicateRow) reasons() []resume.DuplicateReason {
+	reasons := []resume.DuplicateReason{}
+	if r.EmailMatch {
+		reasons = append(reasons, resume.DuplicateReasonEmail)
+	}
+	if r.PhoneMatch {
+		reasons = append(reasons, resume.DuplicateReasonPhone)
+	}
+	if r.LinkedInMatch {
+		reasons = append(reasons, resume.DuplicateReasonLinkedIn)
+	}
+	return reasons
+}
+
+// contactMatchColumns compares the contact keys of resumes a and b
+const contactMatchColumns = `
+	COALESCE(normalize_email(a.personal_info->>'email') = normalize_email(b.personal_info->>'email'), false) AS email_match,
+	COALESCE(normalize_phone(a.personal_info->>'phone') = normalize_phone(b.personal_info->>'phone'), false) AS phone_match,
+	COALESCE(normalize_linkedin(a.personal_info->>'linkedin') = normalize_linkedin(b.personal_info->>'linkedin'), false) AS linkedin_match`
+
+const contactMatchCondition = `(
+	normalize_email(a.personal_info->>'email') = normalize_email(b.personal_info->>'email')
+	OR normalize_phone(a.personal_info->>'phone') = normalize_phone(b.personal_info->>'phone')
+	OR normalize_linkedin(a.personal_info->>'linkedin') = normalize_linkedin(b.personal_info->>'linkedin')
+)`
+
+// FindExactDuplicates returns the tenant's other resumes sharing the normalized email, phone or LinkedIn profile
+func (r *PostgresDuplicateRepository) FindExactDuplicates(ctx context.Context, rm *resume.Resume) ([]resume.DuplicateMatch, error) {
+	personalInfo, err := json.Marshal(rm.PersonalInfo)
+	if err != nil {
+		return nil, fmt.Errorf("marshal personal info: %w", err)
+	}
+
+	query := `
+		SELECT $3::text AS resume_id, b.id AS duplicate_id, ` + contactMatchColumns + `
+		FROM (SELECT $1::jsonb AS personal_info) a
+		JOIN resumes b ON b.tenant_id = $2 AND b.id <> $3
+		WHERE ` + contactMatchCondition + `
+		ORDER BY b.created_at`
+
+	rows := []duplicateRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, personalInfo, rm.TenantID, rm.ID); err != nil {
+		return nil, fmt.Errorf("find exact duplicates: %w", err)
+	}
+
+	matches := make([]resume.DuplicateMatch, len(rows))
+	for i, row := range rows {
+		matches[i] = resume.DuplicateMatch{
+			ResumeID: kernel.ResumeID(row.DuplicateID),
+			Reasons:  row.reasons(),
+		}
+	}
+	return matches, nil
+}
+
+// ListExactDuplicatePairs returns every pair of the tenant's resumes sharing contact details
+func (r *PostgresDuplicateRepository) ListExactDuplicatePairs(ctx context.Context, tenantID kernel.TenantID) ([]resume.DuplicatePair, error) {
+	query := `
+		SELECT a.id AS resume_id, b.id AS duplicate_id, ` + contactMatchColumns + `
+		FROM resumes a
+		JOIN resumes b ON b.tenant_id = a.tenant_id AND a.id < b.id
+		WHERE a.tenant_id = $1 AND ` + contactMatchCondition + `
+		ORDER BY a.id, b.id`
+
+	rows := []duplicateRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, tenantID); err != nil {
+		return nil, fmt.Errorf("list exact duplicate pairs: %w", err)
+	}
+
+	pairs := make([]resume.DuplicatePair, len(rows))
+	for i, row := range rows {
+		pairs[i] = resume.DuplicatePair{
+			ResumeID:    kernel.ResumeID(row.ResumeID),
+			DuplicateID: kernel.ResumeID(row.DuplicateID),
+			Reasons:     row.reasons(),
+		}
+	}
+	return pairs, nil
+}
+
+// nearDuplicateRow is a pair of resumes with similar embeddings
+type nearDuplicateRow struct {
+	ResumeID    string  `db:"resume_id"`
+	DuplicateID string  `db:"duplicate_id"`
+	Similarity  float64 `db:"similarity"`
+}
+
+// ListNearDuplicatePairs compares every pair of the tenant's resumes in one self-join.
+// Sections are compared one by one and combined with the default search weights, as a
+// search for one of the resumes would, so the scan needs no per-resume vector search.
+func (r *PostgresDuplicateRepository) ListNearDuplicatePairs(ctx context.Context, tenantID kernel.TenantID, model string, threshold float64, limit int) ([]resume.DuplicatePair, error) {
+	sections := searchSectionsFor(resume.DefaultSectionWeights())
+	sectionScores := make([]string, len(sections))
+	for i, section := range sections {
+		sectionScores[i] = fmt.Sprintf("1 - (a.%s <=> b.%s) AS %s_score", section.column, section.column, section.name)
+	}
+
+	query := fmt.Sprintf(`
+		WITH embedded AS (
+			SELECT e.*
+			FROM resume_embeddings e
+			JOIN resumes r ON r.id = e.resume_id
+			WHERE r.tenant_id = $1 AND e.model_used = $2
+		),
+		scored AS (
+			SELECT a.resume_id, b.resume_id AS duplicate_id,
+				%s
+			FROM embedded a
+			JOIN embedded b ON a.resume_id < b.resume_id AND a.embedding_dim = b.embedding_dim
+		)
+		SELECT resume_id, duplicate_id, similarity
+		FROM (SELECT resume_id, duplicate_id, %s AS similarity FROM scored) pairs
+		WHERE similarity >= $3
+		ORDER BY similarity DESC, resume_id, duplicate_id
+		LIMIT $4`,
+		strings.Join(sectionScores, ",\n\t\t\t\t"),
+		weightedScoreExpr(sections),
+	)
+
+	rows := []nearDuplicateRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, tenantID, model, threshold, limit); err != nil {
+		return nil, fmt.Errorf("list near duplicate pairs: %w", err)
+	}
+
+	pairs := make([]resume.DuplicatePair, len(rows))
+	for i, row := range rows {
+		pairs[i] = resume.DuplicatePair{
+			ResumeID:    kernel.ResumeID(row.ResumeID),
+			DuplicateID: kernel.ResumeID(row.DuplicateID),
+			Reasons:     []resume.DuplicateReason{resume.DuplicateReasonEmbedding},
+			Similarity:  row.Similarity,
+		}
+	}
+	return pairs, nil
+}
+
+// CompleteMerge atomically saves the merged survivor, re-points the merged resumes'
+// jobs to it, records the merge and deletes the merged resumes
+func (r *PostgresDuplicateRepository) CompleteMerge(ctx context.Context, merge *resume.ResumeMerge, survivor *resume.Resume) error {
+	mergedIDs := make([]string, len(merge.MergedResumeIDs))
+	for i, id := range merge.MergedResumeIDs {
+		mergedIDs[i] = id.String()
+	}
+
+	fieldSources, err := json.Marshal(merge.FieldSources)
+	if err != nil {
+		return fmt.Errorf("marshal field sources: %w", err)
+	}
+	snapshots, err := json.Marshal(merge.MergedResumes)
+	if err != nil {
+		return fmt.Errorf("marshal merged resumes: %w", err)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin merge: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateResume(ctx, tx, survivor.ID, survivor); err != nil {
+		return err
+	}
+
+	// Jobs must move before the delete, which would otherwise null their resume_id
+	result, err := tx.ExecContext(ctx, `
+		UPDATE resume_processing_jobs SET resume_id = $1
+		WHERE resume_id = ANY($2::text[])`, merge.SurvivorID, pq.Array(mergedIDs))
+	if err != nil {
+		return fmt.Errorf("re-point processing jobs: %w", err)
+	}
+	moved, _ := result.RowsAffected()
+	merge.MovedJobs = int(moved)
+
+	// Earlier merges into the merged resumes now belong to the survivor
+	_, err = tx.ExecContext(ctx, `
+		UPDATE resume_merges SET survivor_id = $1
+		WHERE survivor_id = ANY($2::text[])`, merge.SurvivorID, pq.Array(mergedIDs))
+	if err != nil {
+		return fmt.Errorf("re-point earlier merges: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO resume_merges (
+			id, tenant_id, survivor_id, merged_resume_ids, field_sources,
+			merged_resumes, moved_jobs, merged_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		merge.ID, merge.TenantID, merge.SurvivorID, pq.Array(mergedIDs), fieldSources,
+		snapshots, merge.MovedJobs, merge.MergedBy, merge.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert resume merge: %w", err)
+	}
+
+	result, err = tx.ExecContext(ctx, `
+		DELETE FROM resumes WHERE id = ANY($1::text[]) AND tenant_id = $2`, pq.Array(mergedIDs), merge.TenantID)
+	if err != nil {
+		return fmt.Errorf("delete merged resumes: %w", err)
+	}
+	if deleted, _ := result.RowsAffected(); int(deleted) != len(mergedIDs) {
+		return resume.ErrResumeNotFound().
+			WithDetail("merged_resume_ids", merge.MergedResumeIDs).
+			WithDetail("reason", "a merged resume was deleted concurrently")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit merge: %w", err)
+	}
+	return nil
+}
+
+// resumeMergeRow is the database representation of a merge record
+type resumeMergeRow struct {
+	ID              string         `db:"id"`
+	TenantID        string         `db:"tenant_id"`
+	SurvivorID      string         `db:"survivor_id"`
+	MergedResumeIDs pq.StringArray `db:"merged_resume_ids"`
+	FieldSources    []byte         `db:"field_sources"`
+	MergedResumes   []byte         `db:"merged_resumes"`
+	MovedJobs       int            `db:"moved_jobs"`
+	MergedBy        sql.NullString `db:"merged_by"`
+	CreatedAt       time.Time      `db:"created_at"`
+}
+
+func (row resumeMergeRow) toDomain() (*resume.ResumeMerge, error) {
+	merge := &resume.ResumeMerge{
+		ID:              kernel.NewResumeMergeID(row.ID),
+		TenantID:        kernel.TenantID(row.TenantID),
+		SurvivorID:      kernel.ResumeID(row.SurvivorID),
+		MergedResumeIDs: make([]kernel.ResumeID, len(row.MergedResumeIDs)),
+		MovedJobs:       row.MovedJobs,
+		CreatedAt:       row.CreatedAt,
+	}
+	for i, id := range row.MergedResumeIDs {
+		merge.MergedResumeIDs[i] = kernel.ResumeID(id)
+	}
+	if row.MergedBy.Valid {
+		mergedBy := kernel.NewUserID(row.MergedBy.String)
+		merge.MergedBy = &mergedBy
+	}
+
+	if err := json.Unmarshal(row.FieldSources, &merge.FieldSources); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal field_sources: %w", err)
+	}
+	if err := json.Unmarshal(row.MergedResumes, &merge.MergedResumes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal merged_resumes: %w", err)
+	}
+
+	return merge, nil
+}
+
+// ListMerges retrieves the merges into a resume, newest first
+func (r *PostgresDuplicateRepository) ListMerges(ctx context.Context, survivorID kernel.ResumeID) ([]*resume.ResumeMerge, error) {
+	query := `
+		SELECT id, tenant_id, survivor_id, merged_resume_ids, field_sources,
+			merged_resumes, moved_jobs, merged_by, created_at
+		FROM resume_merges
+		WHERE survivor_id = $1
+		ORDER BY created_at DESC`
+
+	rows := []resumeMergeRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, survivorID); err != nil {
+		return nil, fmt.Errorf("list resume merges: %w", err)
+	}
+
+	merges := make([]*resume.ResumeMerge, 0, len(rows))
+	for _, row := range rows {
+		merge, err := row.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		merges = append(merges, merge)
+	}
+	return merges, nil
+}
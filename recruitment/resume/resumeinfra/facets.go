@@ -0,0 +1,119 @@
+package resumeinfra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+// facetRow is one bucket of a facet
+type facetRow struct {
+	Value string `db:"value"`
+	Count int    `db:"count"`
+}
+
+// Facets counts the resumes matching the hard filters of a search by each requested facet.
+// Every resume passing the filters is counted, not only the top results.
+func (r *PostgresResumeRepository) Facets(ctx context.Context, req resume.SearchResumesRequest) (resume.SearchFacets, error) {
+	facets := make(resume.SearchFacets)
+
+	for _, facet := range resume.UniqueFacets(req.Facets) {
+		args := queryArgs{}
+		conditions := append([]string{"TRUE"}, buildSearchFilters(req, &args)...)
+
+		query := fmt.Sprintf(`
+			WITH filtered AS (
+				SELECT r.id, r.personal_info, r.work_experience, r.skills, r.languages
+				FROM resumes r
+				WHERE %s
+			)
+			%s`,
+			strings.Join(conditions, " AND "),
+			facetQuery(facet, &args),
+		)
+
+		rows := []facetRow{}
+		if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+			return nil, resume.ErrRegistry.NewWithCause(resume.CodeSearchFailed, err).
+				WithDetail("facet", facet).
+				WithDetail("operation", "facets")
+		}
+
+		buckets := make([]resume.FacetBucket, len(rows))
+		for i, row := range rows {
+			buckets[i] = resume.FacetBucket{Value: row.Value, Count: row.Count}
+		}
+		facets[facet] = buckets
+	}
+
+	return facets, nil
+}
+
+// facetQuery selects the value and count of each bucket of a facet over the filtered CTE
+func facetQuery(facet resume.Facet, args *queryArgs) string {
+	limit := args.add(resume.FacetBucketLimit)
+
+	switch facet {
+	case resume.FacetSkills:
+		// extract_normalized_skills already de-duplicates the skills of each resume
+		return fmt.Sprintf(`
+			SELECT s AS value, COUNT(*) AS count
+			FROM filtered f, unnest(extract_normalized_skills(f.skills)) s
+			GROUP BY s
+			ORDER BY count DESC, value
+			LIMIT %s`, limit)
+
+	case resume.FacetCities:
+		// Spellings of the same city are grouped, showing the first one alphabetically
+		return fmt.Sprintf(`
+			SELECT min(city) AS value, COUNT(*) AS count
+			FROM (
+				SELECT NULLIF(btrim(f.personal_info->'location'->>'city'), '') AS city
+				FROM filtered f
+			) c
+			WHERE city IS NOT NULL
+			GROUP BY lower(city)
+			ORDER BY count DESC, value
+			LIMIT %s`, limit)
+
+	case resume.FacetLanguages:
+		return fmt.Sprintf(`
+			SELECT min(btrim(lang->>'language')) AS value, COUNT(DISTINCT f.id) AS count
+			FROM filtered f, jsonb_array_elements(COALESCE(f.languages, '[]'::jsonb)) lang
+			WHERE NULLIF(btrim(lang->>'language'), '') IS NOT NULL
+			GROUP BY lower(btrim(lang->>'language'))
+			ORDER BY count DESC, value
+			LIMIT %s`, limit)
+
+	case resume.FacetExperience:
+		// Buckets keep the ladder order instead of being sorted by count
+		return fmt.Sprintf(`
+			SELECT bucket AS value, COUNT(*) AS count
+			FROM (
+				SELECT %s AS bucket, months
+				FROM (SELECT calculate_total_experience_months(f.work_experience) AS months FROM filtered f) m
+			) b
+			GROUP BY bucket
+			ORDER BY min(months)
+			LIMIT %s`, experienceBucketExpr(args), limit)
+	}
+
+	return "SELECT NULL::text AS value, 0 AS count WHERE FALSE"
+}
+
+// experienceBucketExpr labels total experience months with their resume.ExperienceBuckets range
+func experienceBucketExpr(args *queryArgs) string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, bucket := range resume.ExperienceBuckets {
+		if bucket.MaxYears > 0 {
+			fmt.Fprintf(&b, " WHEN months < %d THEN %s::text", bucket.MaxYears*12, args.add(bucket.Label))
+		} else {
+			fmt.Fprintf(&b, " ELSE %s::text", args.add(bucket.Label))
+		}
+	}
+	b.WriteString(" END")
+	return b.String()
+}
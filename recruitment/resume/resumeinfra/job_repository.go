@@ -50,6 +50,11 @@ type dbJob struct {
 	NextRetryAt *time.Time `db:"next_retry_at"`
 
 	RequestPayload string `db:"request_payload"`
+
+	Draft      sql.NullString `db:"draft"`
+	ReviewedBy *string        `db:"reviewed_by"`
+	ReviewedAt *time.Time     `db:"reviewed_at"`
+	ReviewNote string         `db:"review_note"`
 }
 
 // Create creates a new job record
@@ -60,13 +65,15 @@ func (r *PostgresJobRepository) Create(ctx context.Context, job *resume.ResumePr
 			attempt_count, max_attempts, error_message, error_details,
 			current_step, progress_percentage,
 			created_at, started_at, completed_at, failed_at, next_retry_at,
-			request_payload
+			request_payload,
+			draft, reviewed_by, reviewed_at, review_note
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8,
 			$9, $10, $11, $12,
 			$13, $14,
 			$15, $16, $17, $18, $19,
-			$20
+			$20,
+			$21, $22, $23, $24
 		)
 	`
 
@@ -82,6 +89,7 @@ func (r *PostgresJobRepository) Create(ctx context.Context, job *resume.ResumePr
 		dbJob.CurrentStep, dbJob.ProgressPercentage,
 		dbJob.CreatedAt, dbJob.StartedAt, dbJob.CompletedAt, dbJob.FailedAt, dbJob.NextRetryAt,
 		dbJob.RequestPayload,
+		dbJob.Draft, dbJob.ReviewedBy, dbJob.ReviewedAt, dbJob.ReviewNote,
 	)
 
 	if err != nil {
@@ -97,6 +105,55 @@ func (r *PostgresJobRepository) Create(ctx context.Context, job *resume.ResumePr
 
 // Update updates an existing job
 func (r *PostgresJobRepository) Update(ctx context.Context, job *resume.ResumeProcessingJob) error {
+	rows, err := r.update(ctx, r.db, job, "")
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("job not found: %s", job.ID)
+	}
+
+	return nil
+}
+
+// UpdateAwaitingReview updates a job only while it is still awaiting review
+func (r *PostgresJobRepository) UpdateAwaitingReview(ctx context.Context, job *resume.ResumeProcessingJob) (bool, error) {
+	rows, err := r.update(ctx, r.db, job, resume.JobStatusAwaitingReview)
+	return rows > 0, err
+}
+
+// ApproveDraft claims a job awaiting review by completing it, and creates the resume
+// in the same transaction. The claim locks the job row, so of two concurrent approvals
+// the second finds the job completed and creates nothing.
+func (r *PostgresJobRepository) ApproveDraft(ctx context.Context, job *resume.ResumeProcessingJob, resumeModel *resume.Resume) (bool, error) {
+	claimed := false
+	err := withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		rows, err := r.update(ctx, tx, job, resume.JobStatusAwaitingReview)
+		if err != nil || rows == 0 {
+			return err
+		}
+		claimed = true
+
+		if resumeModel.IsDefault {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE resumes SET is_default = false
+				WHERE tenant_id = $1 AND is_default = true`, resumeModel.TenantID); err != nil {
+				return fmt.Errorf("unset default resumes: %w", err)
+			}
+		}
+
+		return insertResume(ctx, tx, resumeModel)
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+// update writes every mutable column of a job, only while it has requiredStatus when one
+// is given, and returns the number of rows written
+func (r *PostgresJobRepository) update(ctx context.Context, db sqlx.ExecerContext, job *resume.ResumeProcessingJob, requiredStatus resume.JobStatus) (int64, error) {
 	query := `
 		UPDATE resume_processing_jobs SET
 			resume_id = $2,
@@ -110,16 +167,20 @@ func (r *PostgresJobRepository) Update(ctx context.Context, job *resume.ResumePr
 			completed_at = $10,
 			failed_at = $11,
 			next_retry_at = $12,
-			request_payload = $13
-		WHERE id = $1
+			request_payload = $13,
+			draft = $14,
+			reviewed_by = $15,
+			reviewed_at = $16,
+			review_note = $17
+		WHERE id = $1 AND ($18 = '' OR status = $18)
 	`
 
 	dbJob, err := r.toDBJob(job)
 	if err != nil {
-		return fmt.Errorf("convert to db job: %w", err)
+		return 0, fmt.Errorf("convert to db job: %w", err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := db.ExecContext(ctx, query,
 		dbJob.ID,
 		dbJob.ResumeID,
 		dbJob.Status,
@@ -133,22 +194,23 @@ func (r *PostgresJobRepository) Update(ctx context.Context, job *resume.ResumePr
 		dbJob.FailedAt,
 		dbJob.NextRetryAt,
 		dbJob.RequestPayload,
+		dbJob.Draft,
+		dbJob.ReviewedBy,
+		dbJob.ReviewedAt,
+		dbJob.ReviewNote,
+		string(requiredStatus),
 	)
 
 	if err != nil {
-		return fmt.Errorf("update job: %w", err)
+		return 0, fmt.Errorf("update job: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("get rows affected: %w", err)
+		return 0, fmt.Errorf("get rows affected: %w", err)
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("job not found: %s", job.ID)
-	}
-
-	return nil
+	return rows, nil
 }
 
 // GetByID retrieves a job by ID
@@ -159,7 +221,8 @@ func (r *PostgresJobRepository) GetByID(ctx context.Context, jobID kernel.JobID)
 			attempt_count, max_attempts, error_message, error_details,
 			current_step, progress_percentage,
 			created_at, started_at, completed_at, failed_at, next_retry_at,
-			request_payload
+			request_payload,
+			draft, reviewed_by, reviewed_at, review_note
 		FROM resume_processing_jobs
 		WHERE id = $1
 	`
@@ -188,24 +251,31 @@ func (r *PostgresJobRepository) GetByTenantID(
 		return nil, fmt.Errorf("count jobs: %w", err)
 	}
 
-	offset := (pagination.Page - 1) * pagination.PageSize
+	args := queryArgs{tenantID.String()}
+	page, err := newTimeKeysetPage(pagination, "created_at", "id", &args)
+	if err != nil {
+		return nil, resume.ErrInvalidPagination().
+			WithDetail("cursor", pagination.Cursor)
+	}
+
 	query := `
 		SELECT 
 			id, tenant_id, resume_id, status, file_path, file_name, file_type, title,
 			attempt_count, max_attempts, error_message, error_details,
 			current_step, progress_percentage,
 			created_at, started_at, completed_at, failed_at, next_retry_at,
-			request_payload
+			request_payload,
+			draft, reviewed_by, reviewed_at, review_note
 		FROM resume_processing_jobs
-		WHERE tenant_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`
+		` + page.where("tenant_id = $1") + `
+		ORDER BY ` + page.orderBy + `
+		` + page.limit
 
 	var dbJobs []dbJob
-	if err := r.db.SelectContext(ctx, &dbJobs, query, tenantID.String(), pagination.PageSize, offset); err != nil {
+	if err := r.db.SelectContext(ctx, &dbJobs, query, args...); err != nil {
 		return nil, fmt.Errorf("get jobs: %w", err)
 	}
+	dbJobs, hasMore := trimKeysetRows(page, dbJobs)
 
 	jobs := make([]resume.ResumeProcessingJob, 0, len(dbJobs))
 	for _, dbJob := range dbJobs {
@@ -217,14 +287,13 @@ func (r *PostgresJobRepository) GetByTenantID(
 		jobs = append(jobs, *job)
 	}
 
-	return &kernel.Paginated[resume.ResumeProcessingJob]{
-		Items: jobs,
-		Page: kernel.Page{
-			Number: pagination.Page,
-			Size:   pagination.PageSize,
-			Total:  total,
-		},
-	}, nil
+	paginated := kernel.NewCursorPaginated(jobs, pagination, page.cursor, total, hasMore, jobSortKey)
+	return &paginated, nil
+}
+
+// jobSortKey is the keyset position of a job in creation order
+func jobSortKey(job resume.ResumeProcessingJob) (string, string) {
+	return kernel.TimeSortKey(job.CreatedAt), job.ID.String()
 }
 
 // GetFailedJobsForRetry retrieves failed jobs that are ready for retry
@@ -235,7 +304,8 @@ func (r *PostgresJobRepository) GetFailedJobsForRetry(ctx context.Context, limit
 			attempt_count, max_attempts, error_message, error_details,
 			current_step, progress_percentage,
 			created_at, started_at, completed_at, failed_at, next_retry_at,
-			request_payload
+			request_payload,
+			draft, reviewed_by, reviewed_at, review_note
 		FROM resume_processing_jobs
 		WHERE status = $1 
 			AND next_retry_at IS NOT NULL 
@@ -467,6 +537,24 @@ func (r *PostgresJobRepository) toDBJob(job *resume.ResumeProcessingJob) (*dbJob
 		resumeID = &idStr
 	}
 
+	var draft sql.NullString
+	if job.Draft != nil {
+		draftJSON, err := json.Marshal(job.Draft)
+		if err != nil {
+			return nil, fmt.Errorf("marshal draft: %w", err)
+		}
+		draft = sql.NullString{
+			String: string(draftJSON),
+			Valid:  true,
+		}
+	}
+
+	var reviewedBy *string
+	if job.ReviewedBy != nil {
+		idStr := job.ReviewedBy.String()
+		reviewedBy = &idStr
+	}
+
 	return &dbJob{
 		ID:                 job.ID.String(),
 		TenantID:           job.TenantID.String(),
@@ -488,6 +576,10 @@ func (r *PostgresJobRepository) toDBJob(job *resume.ResumeProcessingJob) (*dbJob
 		FailedAt:           job.FailedAt,
 		NextRetryAt:        job.NextRetryAt,
 		RequestPayload:     string(requestPayloadJSON),
+		Draft:              draft,
+		ReviewedBy:         reviewedBy,
+		ReviewedAt:         job.ReviewedAt,
+		ReviewNote:         job.ReviewNote,
 	}, nil
 }
 
@@ -518,6 +610,20 @@ func (r *PostgresJobRepository) toDomainJob(dbJob *dbJob) (*resume.ResumeProcess
 		resumeID = &id
 	}
 
+	var draft *resume.Resume
+	if dbJob.Draft.Valid && dbJob.Draft.String != "" {
+		draft = &resume.Resume{}
+		if err := json.Unmarshal([]byte(dbJob.Draft.String), draft); err != nil {
+			return nil, fmt.Errorf("unmarshal draft: %w", err)
+		}
+	}
+
+	var reviewedBy *kernel.UserID
+	if dbJob.ReviewedBy != nil {
+		id := kernel.UserID(*dbJob.ReviewedBy)
+		reviewedBy = &id
+	}
+
 	return &resume.ResumeProcessingJob{
 		ID:                 kernel.JobID(dbJob.ID),
 		TenantID:           kernel.TenantID(dbJob.TenantID),
@@ -539,5 +645,9 @@ func (r *PostgresJobRepository) toDomainJob(dbJob *dbJob) (*resume.ResumeProcess
 		FailedAt:           dbJob.FailedAt,
 		NextRetryAt:        dbJob.NextRetryAt,
 		RequestPayload:     requestPayload,
+		Draft:              draft,
+		ReviewedBy:         reviewedBy,
+		ReviewedAt:         dbJob.ReviewedAt,
+		ReviewNote:         dbJob.ReviewNote,
 	}, nil
 }
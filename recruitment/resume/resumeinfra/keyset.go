@@ -0,0 +1,88 @@
+package resumeinfra
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+)
+
+// keysetPage is the part of a list query that selects one page: a condition
+// (empty on the first page), the ORDER BY and the LIMIT/OFFSET clauses
+type keysetPage struct {
+	cursor    *kernel.Cursor
+	size      int
+	condition string
+	orderBy   string
+	limit     string
+}
+
+// newTimeKeysetPage pages a list ordered by a timestamp column with the id column as
+// tie-breaker. With a cursor the page starts right after (or before) the cursor row,
+// which an index on (column, id) resolves directly however deep the page is.
+// Without one it falls back to the page number, so existing clients keep working.
+func newTimeKeysetPage(pagination kernel.PaginationOptions, column, idColumn string, args *queryArgs) (*keysetPage, error) {
+	cursor, err := pagination.DecodeCursor()
+	if err != nil {
+		return nil, err
+	}
+
+	page := &keysetPage{
+		cursor: cursor,
+		size:   max(pagination.PageSize, 1),
+	}
+
+	ascending := pagination.SortDirection() == kernel.SortAsc
+	if cursor != nil {
+		at, err := cursor.TimeKey()
+		if err != nil {
+			return nil, err
+		}
+		ascending = cursor.Ascending()
+
+		op := "<"
+		if ascending {
+			op = ">"
+		}
+		page.condition = fmt.Sprintf("(%s, %s) %s (%s, %s)", column, idColumn, op, args.add(at), args.add(cursor.ID))
+	}
+
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+	page.orderBy = fmt.Sprintf("%s %s, %s %s", column, order, idColumn, order)
+
+	// One extra row tells whether another page follows
+	page.limit = "LIMIT " + args.add(page.size+1)
+	if cursor == nil && pagination.Page > 1 {
+		page.limit += " OFFSET " + args.add((pagination.Page-1)*page.size)
+	}
+
+	return page, nil
+}
+
+// where returns the page condition joined to the other conditions of the query
+func (p *keysetPage) where(conditions ...string) string {
+	if p.condition != "" {
+		conditions = append(conditions, p.condition)
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// trimKeysetRows drops the look-ahead row and restores list order on backward pages.
+// It reports whether more rows follow in the reading direction.
+func trimKeysetRows[T any](p *keysetPage, rows []T) ([]T, bool) {
+	hasMore := len(rows) > p.size
+	if hasMore {
+		rows = rows[:p.size]
+	}
+	if p.cursor != nil && p.cursor.Before {
+		slices.Reverse(rows)
+	}
+	return rows, hasMore
+}
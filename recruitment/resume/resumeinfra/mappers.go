@@ -35,6 +35,8 @@ type resumeRow struct {
 	ParsedAt            time.Time      `db:"parsed_at"`
 	LastUpdatedAt       time.Time      `db:"last_updated_at"`
 	CreatedAt           time.Time      `db:"created_at"`
+	ParseReport         []byte         `db:"parse_report"`
+	NeedsReview         bool           `db:"needs_review"`
 }
 
 // ToDomain converts a resumeRow to a resume.Resume domain model
@@ -52,6 +54,7 @@ func (r *resumeRow) ToDomain() (*resume.Resume, error) {
 		ParsedAt:      r.ParsedAt,
 		LastUpdatedAt: r.LastUpdatedAt,
 		CreatedAt:     r.CreatedAt,
+		NeedsReview:   r.NeedsReview,
 	}
 
 	// Unmarshal JSONB fields
@@ -99,36 +102,130 @@ func (r *resumeRow) ToDomain() (*resume.Resume, error) {
 		resumeModel.ProfessionalSummary = r.ProfessionalSummary.String
 	}
 
+	if len(r.ParseReport) > 0 {
+		if err := json.Unmarshal(r.ParseReport, &resumeModel.ParseReport); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal parse_report: %w", err)
+		}
+	}
+
 	return resumeModel, nil
 }
 
-// embeddingsRow represents a row from the resume_embeddings table
+// embeddingsRow represents a row from the resume_embeddings table.
+// Section vectors are nullable: sections without content are stored as NULL.
 type embeddingsRow struct {
-	ExperienceEmbedding        string         `db:"experience_embedding"`
-	EducationEmbedding         string         `db:"education_embedding"`
-	SkillsEmbedding            string         `db:"skills_embedding"`
-	LanguagesEmbedding         string         `db:"languages_embedding"`
+	ExperienceEmbedding        sql.NullString `db:"experience_embedding"`
+	EducationEmbedding         sql.NullString `db:"education_embedding"`
+	SkillsEmbedding            sql.NullString `db:"skills_embedding"`
+	LanguagesEmbedding         sql.NullString `db:"languages_embedding"`
 	PersonalStatementEmbedding sql.NullString `db:"personal_statement_embedding"`
 	ModelUsed                  string         `db:"model_used"`
 	EmbeddingDim               int            `db:"embedding_dim"`
 	GeneratedAt                time.Time      `db:"generated_at"`
+	SectionHashes              []byte         `db:"section_hashes"`
 }
 
 // ToDomain converts an embeddingsRow to resume.ResumeEmbeddings
 func (e *embeddingsRow) ToDomain() *resume.ResumeEmbeddings {
-	embeddings := &resume.ResumeEmbeddings{
-		ExperienceEmbedding: vectorToFloat32Slice(e.ExperienceEmbedding),
-		EducationEmbedding:  vectorToFloat32Slice(e.EducationEmbedding),
-		SkillsEmbedding:     vectorToFloat32Slice(e.SkillsEmbedding),
-		LanguagesEmbedding:  vectorToFloat32Slice(e.LanguagesEmbedding),
-		ModelUsed:           e.ModelUsed,
-		EmbeddingDim:        e.EmbeddingDim,
-		GeneratedAt:         e.GeneratedAt,
+	return &resume.ResumeEmbeddings{
+		ExperienceEmbedding:        nullVectorToFloat32Slice(e.ExperienceEmbedding),
+		EducationEmbedding:         nullVectorToFloat32Slice(e.EducationEmbedding),
+		SkillsEmbedding:            nullVectorToFloat32Slice(e.SkillsEmbedding),
+		LanguagesEmbedding:         nullVectorToFloat32Slice(e.LanguagesEmbedding),
+		PersonalStatementEmbedding: nullVectorToFloat32Slice(e.PersonalStatementEmbedding),
+		ModelUsed:                  e.ModelUsed,
+		EmbeddingDim:               e.EmbeddingDim,
+		GeneratedAt:                e.GeneratedAt,
+		SectionHashes:              unmarshalSectionHashes(e.SectionHashes),
 	}
+}
 
-	if e.PersonalStatementEmbedding.Valid {
-		embeddings.PersonalStatementEmbedding = vectorToFloat32Slice(e.PersonalStatementEmbedding.String)
+// unmarshalSectionHashes decodes section_hashes; unreadable hashes only disable vector reuse
+func unmarshalSectionHashes(data []byte) map[string]string {
+	hashes := map[string]string{}
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &hashes)
 	}
+	return hashes
+}
 
-	return embeddings
+// nullVectorToFloat32Slice converts a nullable pgvector text value, returning nil for NULL
+func nullVectorToFloat32Slice(v sql.NullString) kernel.ResumeEmbedding {
+	if !v.Valid {
+		return nil
+	}
+	return vectorToFloat32Slice(v.String)
+}
+
+// resumeMatchRow represents a resume row returned by semantic search with its scores
+type resumeMatchRow struct {
+	resumeRow
+	ExperienceScore float64 `db:"experience_score"`
+	EducationScore  float64 `db:"education_score"`
+	SkillsScore     float64 `db:"skills_score"`
+	LanguagesScore  float64 `db:"languages_score"`
+	StatementScore  float64 `db:"personal_statement_score"`
+	PreferredBoost  float64 `db:"preferred_skills_boost"`
+	SimilarityScore float64 `db:"similarity_score"`
+
+	BestEntryType         sql.NullString  `db:"best_entry_type"`
+	BestEntryIndex        sql.NullInt64   `db:"best_entry_index"`
+	BestEntryTitle        sql.NullString  `db:"best_entry_title"`
+	BestEntryOrganization sql.NullString  `db:"best_entry_organization"`
+	BestEntryScore        sql.NullFloat64 `db:"best_entry_score"`
+}
+
+// bestEntry returns the best-matching work experience or project, nil when the resume has none
+func (r *resumeMatchRow) bestEntry() *resume.MatchedEntry {
+	if !r.BestEntryType.Valid {
+		return nil
+	}
+	return &resume.MatchedEntry{
+		Type:         resume.EntryType(r.BestEntryType.String),
+		Index:        int(r.BestEntryIndex.Int64),
+		Title:        r.BestEntryTitle.String,
+		Organization: r.BestEntryOrganization.String,
+		Score:        r.BestEntryScore.Float64,
+	}
+}
+
+// sections returns the per-section similarity scores of the row
+func (r *resumeMatchRow) sections() resume.MatchedSections {
+	return resume.MatchedSections{
+		ExperienceScore:        r.ExperienceScore,
+		EducationScore:         r.EducationScore,
+		SkillsScore:            r.SkillsScore,
+		LanguagesScore:         r.LanguagesScore,
+		PersonalStatementScore: r.StatementScore,
+		PreferredSkillsBoost:   r.PreferredBoost,
+	}
+}
+
+// resumeKeywordRow represents a resume row returned by keyword search with its rank
+type resumeKeywordRow struct {
+	resumeRow
+	KeywordScore   float64 `db:"keyword_score"`
+	PreferredBoost float64 `db:"preferred_skills_boost"`
+}
+
+// entryEmbeddingRow represents a row from the resume_entry_embeddings table
+type entryEmbeddingRow struct {
+	EntryType    string `db:"entry_type"`
+	EntryIndex   int    `db:"entry_index"`
+	Title        string `db:"title"`
+	Organization string `db:"organization"`
+	Embedding    string `db:"embedding"`
+	ContentHash  string `db:"content_hash"`
+}
+
+// ToDomain converts an entryEmbeddingRow to resume.EntryEmbedding
+func (e *entryEmbeddingRow) ToDomain() resume.EntryEmbedding {
+	return resume.EntryEmbedding{
+		Type:         resume.EntryType(e.EntryType),
+		Index:        e.EntryIndex,
+		Title:        e.Title,
+		Organization: e.Organization,
+		Embedding:    vectorToFloat32Slice(e.Embedding),
+		ContentHash:  e.ContentHash,
+	}
 }
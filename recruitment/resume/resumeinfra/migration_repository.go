@@ -0,0 +1,273 @@
+package resumeinfra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type PostgresEmbeddingMigrationRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresEmbeddingMigrationRepository(db *sqlx.DB) resume.EmbeddingMigrationRepository {
+	return &PostgresEmbeddingMigrationRepository{db: db}
+}
+
+const migrationColumns = `
+	id, tenant_id, source_model, target_model, target_dim, status,
+	total, processed, failed_count, COALESCE(error_message, '') AS error_message,
+	heartbeat_at, created_at, started_at, completed_at, cutover_at, cleaned_at`
+
+// Create creates a pending migration
+func (r *PostgresEmbeddingMigrationRepository) Create(ctx context.Context, m *resume.EmbeddingMigration) error {
+	query := `
+		INSERT INTO embedding_migrations (
+			id, tenant_id, source_model, target_model, target_dim, status,
+			total, processed, failed_count, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		m.ID, m.TenantID, m.SourceModel, m.TargetModel, m.TargetDim, m.Status,
+		m.Total, m.Processed, m.FailedCount, m.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert embedding migration: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a migration by ID
+func (r *PostgresEmbeddingMigrationRepository) GetByID(ctx context.Context, id kernel.EmbeddingMigrationID) (*resume.EmbeddingMigration, error) {
+	query := `SELECT ` + migrationColumns + ` FROM embedding_migrations WHERE id = $1`
+
+	m := &resume.EmbeddingMigration{}
+	if err := r.db.GetContext(ctx, m, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, resume.ErrMigrationNotFound().WithDetail("migration_id", id)
+		}
+		return nil, fmt.Errorf("get embedding migration: %w", err)
+	}
+
+	return m, nil
+}
+
+// ListByTenant retrieves the migrations of a tenant, newest first
+func (r *PostgresEmbeddingMigrationRepository) ListByTenant(ctx context.Context, tenantID kernel.TenantID) ([]*resume.EmbeddingMigration, error) {
+	query := `SELECT ` + migrationColumns + ` FROM embedding_migrations WHERE tenant_id = $1 ORDER BY created_at DESC`
+
+	migrations := []*resume.EmbeddingMigration{}
+	if err := r.db.SelectContext(ctx, &migrations, query, tenantID); err != nil {
+		return nil, fmt.Errorf("list embedding migrations: %w", err)
+	}
+
+	return migrations, nil
+}
+
+// ClaimNext marks the next pending (or abandoned running) migration as running and returns it
+func (r *PostgresEmbeddingMigrationRepository) ClaimNext(ctx context.Context, staleAfter time.Duration) (*resume.EmbeddingMigration, error) {
+	query := `
+		UPDATE embedding_migrations SET
+			status = 'running',
+			started_at = COALESCE(started_at, NOW()),
+			heartbeat_at = NOW()
+		WHERE id = (
+			SELECT id FROM embedding_migrations
+			WHERE status = 'pending'
+			   OR (status = 'running' AND (heartbeat_at IS NULL OR heartbeat_at < NOW() - $1 * INTERVAL '1 second'))
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + migrationColumns
+
+	m := &resume.EmbeddingMigration{}
+	if err := r.db.GetContext(ctx, m, query, staleAfter.Seconds()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim embedding migration: %w", err)
+	}
+
+	return m, nil
+}
+
+// UpdateProgress stores the counters and refreshes the heartbeat
+func (r *PostgresEmbeddingMigrationRepository) UpdateProgress(ctx context.Context, m *resume.EmbeddingMigration) error {
+	query := `
+		UPDATE embedding_migrations SET
+			total = $2, processed = $3, failed_count = $4, heartbeat_at = NOW()
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, m.ID, m.Total, m.Processed, m.FailedCount); err != nil {
+		return fmt.Errorf("update embedding migration progress: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus stores the status, error message and lifecycle timestamps
+func (r *PostgresEmbeddingMigrationRepository) UpdateStatus(ctx context.Context, m *resume.EmbeddingMigration) error {
+	query := `
+		UPDATE embedding_migrations SET
+			status = $2, error_message = NULLIF($3, ''),
+			total = $4, processed = $5, failed_count = $6,
+			completed_at = $7, cleaned_at = $8
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query,
+		m.ID, m.Status, m.ErrorMessage,
+		m.Total, m.Processed, m.FailedCount,
+		m.CompletedAt, m.CleanedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update embedding migration status: %w", err)
+	}
+
+	return nil
+}
+
+// pendingResumesQuery selects the tenant's resumes without vectors for the model, or with vectors
+// generated before the resume was last updated
+const pendingResumesQuery = `
+	FROM resumes r
+	LEFT JOIN resume_embeddings e ON e.resume_id = r.id AND e.model_used = $2
+	WHERE r.tenant_id = $1
+	  AND (e.id IS NULL OR e.generated_at < r.last_updated_at)`
+
+// CountPending counts the tenant's resumes without up-to-date vectors for the model
+func (r *PostgresEmbeddingMigrationRepository) CountPending(ctx context.Context, tenantID kernel.TenantID, model string) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) `+pendingResumesQuery, tenantID, model); err != nil {
+		return 0, fmt.Errorf("count pending resumes: %w", err)
+	}
+	return count, nil
+}
+
+// ListPendingResumeIDs returns resumes without up-to-date vectors for the model, skipping excluded IDs
+func (r *PostgresEmbeddingMigrationRepository) ListPendingResumeIDs(ctx context.Context, tenantID kernel.TenantID, model string, exclude []kernel.ResumeID, limit int) ([]kernel.ResumeID, error) {
+	excluded := make([]string, len(exclude))
+	for i, id := range exclude {
+		excluded[i] = id.String()
+	}
+
+	query := `SELECT r.id ` + pendingResumesQuery + `
+	  AND NOT (r.id = ANY($3::text[]))
+	ORDER BY r.created_at
+	LIMIT $4`
+
+	ids := []kernel.ResumeID{}
+	if err := r.db.SelectContext(ctx, &ids, query, tenantID, model, pq.Array(excluded), limit); err != nil {
+		return nil, fmt.Errorf("list pending resumes: %w", err)
+	}
+	return ids, nil
+}
+
+// GetActiveModel returns the tenant's active embedding model; empty when the default applies
+func (r *PostgresEmbeddingMigrationRepository) GetActiveModel(ctx context.Context, tenantID kernel.TenantID) (string, error) {
+	var model string
+	err := r.db.GetContext(ctx, &model, `SELECT active_model FROM resume_embedding_models WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("get active embedding model: %w", err)
+	}
+	return model, nil
+}
+
+// EnsureActiveModel records model as the tenant's active model unless one is already set
+func (r *PostgresEmbeddingMigrationRepository) EnsureActiveModel(ctx context.Context, tenantID kernel.TenantID, model string) error {
+	query := `
+		INSERT INTO resume_embedding_models (tenant_id, active_model, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (tenant_id) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, tenantID, model); err != nil {
+		return fmt.Errorf("ensure active embedding model: %w", err)
+	}
+	return nil
+}
+
+// Cutover atomically makes the target model active and marks the migration as cut over
+func (r *PostgresEmbeddingMigrationRepository) Cutover(ctx context.Context, m *resume.EmbeddingMigration) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin cutover: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE embedding_migrations SET status = 'cutover', cutover_at = NOW()
+		WHERE id = $1 AND status = 'completed'`, m.ID)
+	if err != nil {
+		return fmt.Errorf("mark migration cut over: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return resume.ErrInvalidMigrationState().
+			WithDetail("migration_id", m.ID).
+			WithDetail("reason", "migration is no longer completed")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO resume_embedding_models (tenant_id, active_model, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			active_model = EXCLUDED.active_model,
+			updated_at = EXCLUDED.updated_at`, m.TenantID, m.TargetModel)
+	if err != nil {
+		return fmt.Errorf("switch active embedding model: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit cutover: %w", err)
+	}
+	return nil
+}
+
+// DeleteModelEmbeddings deletes the tenant's vectors of a model and returns how many rows were removed
+func (r *PostgresEmbeddingMigrationRepository) DeleteModelEmbeddings(ctx context.Context, tenantID kernel.TenantID, model string) (int64, error) {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM resume_entry_embeddings ee
+		USING resumes r
+		WHERE ee.resume_id = r.id AND r.tenant_id = $1 AND ee.model_used = $2`, tenantID, model)
+	if err != nil {
+		return 0, fmt.Errorf("delete model entry embeddings: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM resume_embeddings e
+		USING resumes r
+		WHERE e.resume_id = r.id AND r.tenant_id = $1 AND e.model_used = $2`, tenantID, model)
+	if err != nil {
+		return 0, fmt.Errorf("delete model embeddings: %w", err)
+	}
+
+	deleted, _ := result.RowsAffected()
+	return deleted, nil
+}
+
+// EnsureVectorIndexes creates the vector indexes for a dimension if missing
+func (r *PostgresEmbeddingMigrationRepository) EnsureVectorIndexes(ctx context.Context, dim int) error {
+	if _, err := r.db.ExecContext(ctx, `SELECT ensure_resume_embedding_indexes($1)`, dim); err != nil {
+		return fmt.Errorf("ensure vector indexes: %w", err)
+	}
+	return nil
+}
+
+// GetVectorIndexSettings returns the HNSW parameters and vector storage in use
+func (r *PostgresEmbeddingMigrationRepository) GetVectorIndexSettings(ctx context.Context) (*resume.VectorIndexSettings, error) {
+	var settings resume.VectorIndexSettings
+	err := r.db.GetContext(ctx, &settings, `SELECT storage, m, ef_construction, ef_search FROM vector_index_settings`)
+	if err != nil {
+		return nil, fmt.Errorf("get vector index settings: %w", err)
+	}
+	return &settings, nil
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Abraxas-365/relay/internal/normalize"
 	"github.com/Abraxas-365/relay/pkg/kernel"
 	"github.com/Abraxas-365/relay/pkg/logx"
 	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/Abraxas-365/relay/recruitment/skill"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/pgvector/pgvector-go"
@@ -27,8 +29,16 @@ func NewPostgresResumeRepository(db *sqlx.DB) *PostgresResumeRepository {
 // CRUD Operations
 // ============================================================================
 
-// Create creates a new resume
+// Create creates a new resume with its embeddings
 func (r *PostgresResumeRepository) Create(ctx context.Context, resumeModel *resume.Resume) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return insertResume(ctx, tx, resumeModel)
+	})
+}
+
+// insertResume inserts a resume and its embeddings within a transaction, so that other
+// repositories can create a resume atomically with their own changes
+func insertResume(ctx context.Context, tx *sqlx.Tx, resumeModel *resume.Resume) error {
 	query := `
 		INSERT INTO resumes (
 			id, tenant_id, title, is_active, is_default, version,
@@ -36,14 +46,16 @@ func (r *PostgresResumeRepository) Create(ctx context.Context, resumeModel *resu
 			certifications, projects, achievements, volunteer_work,
 			professional_summary, personal_statement,
 			file_url, file_name, file_type,
-			parsed_at, last_updated_at, created_at
+			parsed_at, last_updated_at, created_at,
+			parse_report, needs_review
 		) VALUES (
 			$1, $2, $3, $4, $5, $6,
 			$7, $8, $9, $10, $11,
 			$12, $13, $14, $15,
 			$16, $17,
 			$18, $19, $20,
-			$21, $22, $23
+			$21, $22, $23,
+			$24, $25
 		)`
 
 	// Marshal JSONB fields
@@ -137,13 +149,27 @@ func (r *PostgresResumeRepository) Create(ctx context.Context, resumeModel *resu
 			})
 	}
 
-	_, err = r.db.ExecContext(ctx, query,
+	// NULL for resumes that were not parsed from a file
+	var parseReport []byte
+	if resumeModel.ParseReport != nil {
+		parseReport, err = json.Marshal(resumeModel.ParseReport)
+		if err != nil {
+			return resume.ErrInvalidResumeData().
+				WithDetail("field", "parse_report").
+				WithDetails(map[string]any{
+					"error": err.Error(),
+				})
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, query,
 		resumeModel.ID, resumeModel.TenantID, resumeModel.Title, resumeModel.IsActive, resumeModel.IsDefault, resumeModel.Version,
 		personalInfo, workExperience, education, skills, languages,
 		certifications, projects, achievements, volunteerWork,
 		resumeModel.ProfessionalSummary, personalStatement,
 		resumeModel.FileURL, resumeModel.FileName, resumeModel.FileType,
 		resumeModel.ParsedAt, resumeModel.LastUpdatedAt, resumeModel.CreatedAt,
+		parseReport, resumeModel.NeedsReview,
 	)
 	if err != nil {
 		// Check for duplicate key error
@@ -160,7 +186,7 @@ func (r *PostgresResumeRepository) Create(ctx context.Context, resumeModel *resu
 	// Create embeddings if they exist
 	logx.Infof("Checking for embeddings to insert for resume ID: %v", resumeModel.HasEmbeddings())
 	if resumeModel.HasEmbeddings() {
-		if err := r.insertEmbeddings(ctx, resumeModel); err != nil {
+		if err := insertEmbeddings(ctx, tx, resumeModel); err != nil {
 			return err
 		}
 	}
@@ -168,8 +194,15 @@ func (r *PostgresResumeRepository) Create(ctx context.Context, resumeModel *resu
 	return nil
 }
 
-// Update updates an existing resume
+// Update updates an existing resume and its embeddings
 func (r *PostgresResumeRepository) Update(ctx context.Context, id kernel.ResumeID, resumeModel *resume.Resume) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return updateResume(ctx, tx, id, resumeModel)
+	})
+}
+
+// updateResume updates a resume and its embeddings within a transaction
+func updateResume(ctx context.Context, tx *sqlx.Tx, id kernel.ResumeID, resumeModel *resume.Resume) error {
 	query := `
 		UPDATE resumes SET
 			title = $1,
@@ -187,8 +220,9 @@ func (r *PostgresResumeRepository) Update(ctx context.Context, id kernel.ResumeI
 			volunteer_work = $13,
 			professional_summary = $14,
 			personal_statement = $15,
-			last_updated_at = $16
-		WHERE id = $17`
+			last_updated_at = $16,
+			needs_review = $17
+		WHERE id = $18`
 
 	// Marshal JSONB fields
 	personalInfo, _ := json.Marshal(resumeModel.PersonalInfo)
@@ -202,12 +236,12 @@ func (r *PostgresResumeRepository) Update(ctx context.Context, id kernel.ResumeI
 	volunteerWork, _ := json.Marshal(resumeModel.VolunteerWork)
 	personalStatement, _ := json.Marshal(resumeModel.PersonalStatement)
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		resumeModel.Title, resumeModel.IsActive, resumeModel.IsDefault, resumeModel.Version,
 		personalInfo, workExperience, education, skills, languages,
 		certifications, projects, achievements, volunteerWork,
 		resumeModel.ProfessionalSummary, personalStatement,
-		resumeModel.LastUpdatedAt, id,
+		resumeModel.LastUpdatedAt, resumeModel.NeedsReview, id,
 	)
 	if err != nil {
 		return resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
@@ -227,7 +261,7 @@ func (r *PostgresResumeRepository) Update(ctx context.Context, id kernel.ResumeI
 
 	// Update embeddings if they exist
 	if resumeModel.HasEmbeddings() {
-		if err := r.updateEmbeddingsInternal(ctx, id, resumeModel.Embeddings); err != nil {
+		if err := upsertEmbeddings(ctx, tx, id, resumeModel.Embeddings); err != nil {
 			return err
 		}
 	}
@@ -244,7 +278,7 @@ func (r *PostgresResumeRepository) GetByID(ctx context.Context, id kernel.Resume
 			certifications, projects, achievements, volunteer_work,
 			professional_summary, personal_statement,
 			file_url, file_name, file_type,
-			parsed_at, last_updated_at, created_at
+			parsed_at, last_updated_at, created_at, parse_report, needs_review
 		FROM resumes
 		WHERE id = $1`
 
@@ -271,13 +305,54 @@ func (r *PostgresResumeRepository) GetByID(ctx context.Context, id kernel.Resume
 
 	// Load embeddings
 	embeddings, err := r.getEmbeddings(ctx, id)
-	if err == nil {
+	if err == nil && embeddings != nil {
 		resumeModel.Embeddings = *embeddings
 	}
 
 	return resumeModel, nil
 }
 
+// ListByIDs retrieves the resumes with the given IDs, without their embeddings
+func (r *PostgresResumeRepository) ListByIDs(ctx context.Context, ids []kernel.ResumeID) ([]*resume.Resume, error) {
+	if len(ids) == 0 {
+		return []*resume.Resume{}, nil
+	}
+
+	query := `
+		SELECT 
+			id, tenant_id, title, is_active, is_default, version,
+			personal_info, work_experience, education, skills, languages,
+			certifications, projects, achievements, volunteer_work,
+			professional_summary, personal_statement,
+			file_url, file_name, file_type,
+			parsed_at, last_updated_at, created_at, parse_report, needs_review
+		FROM resumes
+		WHERE id = ANY($1::text[])`
+
+	rows := []resumeRow{}
+	err := r.db.SelectContext(ctx, &rows, query, pq.Array(ids))
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
+			WithDetail("resume_ids", ids).
+			WithDetail("operation", "list_by_ids")
+	}
+
+	resumes := make([]*resume.Resume, len(rows))
+	for i, row := range rows {
+		resumeModel, err := row.ToDomain()
+		if err != nil {
+			return nil, resume.ErrInvalidResumeData().
+				WithDetail("resume_id", row.ID).
+				WithDetails(map[string]any{
+					"error": err.Error(),
+				})
+		}
+		resumes[i] = resumeModel
+	}
+
+	return resumes, nil
+}
+
 // GetByTenantID retrieves the default resume for a tenant
 func (r *PostgresResumeRepository) GetByTenantID(ctx context.Context, tenantID kernel.TenantID) (*resume.Resume, error) {
 	return r.GetDefaultByTenantID(ctx, tenantID)
@@ -292,7 +367,7 @@ func (r *PostgresResumeRepository) ListByTenantID(ctx context.Context, tenantID
 			certifications, projects, achievements, volunteer_work,
 			professional_summary, personal_statement,
 			file_url, file_name, file_type,
-			parsed_at, last_updated_at, created_at
+			parsed_at, last_updated_at, created_at, parse_report, needs_review
 		FROM resumes
 		WHERE tenant_id = $1
 		ORDER BY created_at DESC`
@@ -319,7 +394,7 @@ func (r *PostgresResumeRepository) ListByTenantID(ctx context.Context, tenantID
 
 		// Load embeddings
 		embeddings, err := r.getEmbeddings(ctx, resumeModel.ID)
-		if err == nil {
+		if err == nil && embeddings != nil {
 			resumeModel.Embeddings = *embeddings
 		}
 
@@ -338,7 +413,7 @@ func (r *PostgresResumeRepository) GetActiveByTenantID(ctx context.Context, tena
 			certifications, projects, achievements, volunteer_work,
 			professional_summary, personal_statement,
 			file_url, file_name, file_type,
-			parsed_at, last_updated_at, created_at
+			parsed_at, last_updated_at, created_at, parse_report, needs_review
 		FROM resumes
 		WHERE tenant_id = $1 AND is_active = true
 		ORDER BY created_at DESC`
@@ -365,7 +440,7 @@ func (r *PostgresResumeRepository) GetActiveByTenantID(ctx context.Context, tena
 
 		// Load embeddings
 		embeddings, err := r.getEmbeddings(ctx, resumeModel.ID)
-		if err == nil {
+		if err == nil && embeddings != nil {
 			resumeModel.Embeddings = *embeddings
 		}
 
@@ -384,7 +459,7 @@ func (r *PostgresResumeRepository) GetDefaultByTenantID(ctx context.Context, ten
 			certifications, projects, achievements, volunteer_work,
 			professional_summary, personal_statement,
 			file_url, file_name, file_type,
-			parsed_at, last_updated_at, created_at
+			parsed_at, last_updated_at, created_at, parse_report, needs_review
 		FROM resumes
 		WHERE tenant_id = $1 AND is_default = true
 		LIMIT 1`
@@ -413,7 +488,7 @@ func (r *PostgresResumeRepository) GetDefaultByTenantID(ctx context.Context, ten
 
 	// Load embeddings
 	embeddings, err := r.getEmbeddings(ctx, resumeModel.ID)
-	if err == nil {
+	if err == nil && embeddings != nil {
 		resumeModel.Embeddings = *embeddings
 	}
 
@@ -555,86 +630,48 @@ func (r *PostgresResumeRepository) CountByTenantID(ctx context.Context, tenantID
 
 // List retrieves all resumes with pagination
 func (r *PostgresResumeRepository) List(ctx context.Context, pagination kernel.PaginationOptions) (*kernel.Paginated[resume.Resume], error) {
-	// Count total
-	var total int
-	err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM resumes`)
-	if err != nil {
-		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
-			WithDetail("operation", "count_all")
-	}
-
-	// Calculate offset
-	offset := (pagination.Page - 1) * pagination.PageSize
+	return r.listPage(ctx, nil, resume.ResumeListFilter{}, pagination)
+}
 
-	// Get paginated results
-	query := `
-		SELECT 
-			id, tenant_id, title, is_active, is_default, version,
-			personal_info, work_experience, education, skills, languages,
-			certifications, projects, achievements, volunteer_work,
-			professional_summary, personal_statement,
-			file_url, file_name, file_type,
-			parsed_at, last_updated_at, created_at
-		FROM resumes
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2`
+// ListByTenantIDWithPagination retrieves resumes for a tenant with pagination,
+// optionally only the active ones or those that do or do not need review
+func (r *PostgresResumeRepository) ListByTenantIDWithPagination(ctx context.Context, tenantID kernel.TenantID, filter resume.ResumeListFilter, pagination kernel.PaginationOptions) (*kernel.Paginated[resume.Resume], error) {
+	return r.listPage(ctx, &tenantID, filter, pagination)
+}
 
-	rows := []resumeRow{}
-	err = r.db.SelectContext(ctx, &rows, query, pagination.PageSize, offset)
-	if err != nil {
-		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
-			WithDetail("operation", "list_paginated").
-			WithDetails(map[string]any{
-				"page":      pagination.Page,
-				"page_size": pagination.PageSize,
-			})
+// listPage retrieves a page of resumes ordered by creation time, optionally for one
+// tenant and narrowed by the filter
+func (r *PostgresResumeRepository) listPage(ctx context.Context, tenantID *kernel.TenantID, filter resume.ResumeListFilter, pagination kernel.PaginationOptions) (*kernel.Paginated[resume.Resume], error) {
+	args := queryArgs{}
+	conditions := []string{}
+	if tenantID != nil {
+		conditions = append(conditions, "tenant_id = "+args.add(*tenantID))
 	}
-
-	resumes := make([]resume.Resume, len(rows))
-	for i, row := range rows {
-		resumeModel, err := row.ToDomain()
-		if err != nil {
-			return nil, resume.ErrInvalidResumeData().
-				WithDetail("row_index", i).
-				WithDetails(map[string]any{
-					"error": err.Error(),
-				})
-		}
-
-		// Load embeddings
-		embeddings, err := r.getEmbeddings(ctx, resumeModel.ID)
-		if err == nil {
-			resumeModel.Embeddings = *embeddings
-		}
-
-		resumes[i] = *resumeModel
+	if filter.OnlyActive {
+		conditions = append(conditions, "is_active = true")
+	}
+	if filter.NeedsReview != nil {
+		conditions = append(conditions, "needs_review = "+args.add(*filter.NeedsReview))
 	}
 
-	return &kernel.Paginated[resume.Resume]{
-		Items: resumes,
-		Page: kernel.Page{
-			Number: pagination.Page,
-			Size:   pagination.PageSize,
-			Total:  total,
-			Pages:  (total + pagination.PageSize - 1) / pagination.PageSize,
-		},
-		Empty: len(resumes) == 0,
-	}, nil
-}
-
-// ListByTenantIDWithPagination retrieves resumes for a tenant with pagination
-func (r *PostgresResumeRepository) ListByTenantIDWithPagination(ctx context.Context, tenantID kernel.TenantID, pagination kernel.PaginationOptions) (*kernel.Paginated[resume.Resume], error) {
 	// Count total
 	var total int
-	err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM resumes WHERE tenant_id = $1`, tenantID)
+	countQuery := `SELECT COUNT(*) FROM resumes`
+	if len(conditions) > 0 {
+		countQuery += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	err := r.db.GetContext(ctx, &total, countQuery, args...)
 	if err != nil {
 		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
 			WithDetail("tenant_id", tenantID).
 			WithDetail("operation", "count")
 	}
 
-	// Calculate offset
-	offset := (pagination.Page - 1) * pagination.PageSize
+	page, err := newTimeKeysetPage(pagination, "created_at", "id", &args)
+	if err != nil {
+		return nil, resume.ErrInvalidPagination().
+			WithDetail("cursor", pagination.Cursor)
+	}
 
 	// Get paginated results
 	query := `
@@ -644,14 +681,14 @@ func (r *PostgresResumeRepository) ListByTenantIDWithPagination(ctx context.Cont
 			certifications, projects, achievements, volunteer_work,
 			professional_summary, personal_statement,
 			file_url, file_name, file_type,
-			parsed_at, last_updated_at, created_at
+			parsed_at, last_updated_at, created_at, parse_report, needs_review
 		FROM resumes
-		WHERE tenant_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3`
+		` + page.where(conditions...) + `
+		ORDER BY ` + page.orderBy + `
+		` + page.limit
 
 	rows := []resumeRow{}
-	err = r.db.SelectContext(ctx, &rows, query, tenantID, pagination.PageSize, offset)
+	err = r.db.SelectContext(ctx, &rows, query, args...)
 	if err != nil {
 		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
 			WithDetail("tenant_id", tenantID).
@@ -659,8 +696,10 @@ func (r *PostgresResumeRepository) ListByTenantIDWithPagination(ctx context.Cont
 			WithDetails(map[string]any{
 				"page":      pagination.Page,
 				"page_size": pagination.PageSize,
+				"cursor":    pagination.Cursor,
 			})
 	}
+	rows, hasMore := trimKeysetRows(page, rows)
 
 	resumes := make([]resume.Resume, len(rows))
 	for i, row := range rows {
@@ -676,70 +715,73 @@ func (r *PostgresResumeRepository) ListByTenantIDWithPagination(ctx context.Cont
 
 		// Load embeddings
 		embeddings, err := r.getEmbeddings(ctx, resumeModel.ID)
-		if err == nil {
+		if err == nil && embeddings != nil {
 			resumeModel.Embeddings = *embeddings
 		}
 
 		resumes[i] = *resumeModel
 	}
 
-	return &kernel.Paginated[resume.Resume]{
-		Items: resumes,
-		Page: kernel.Page{
-			Number: pagination.Page,
-			Size:   pagination.PageSize,
-			Total:  total,
-			Pages:  (total + pagination.PageSize - 1) / pagination.PageSize,
-		},
-		Empty: len(resumes) == 0,
-	}, nil
+	paginated := kernel.NewCursorPaginated(resumes, pagination, page.cursor, total, hasMore, resumeSortKey)
+	return &paginated, nil
+}
+
+// resumeSortKey is the keyset position of a resume in creation order
+func resumeSortKey(r resume.Resume) (string, string) {
+	return kernel.TimeSortKey(r.CreatedAt), r.ID.String()
 }
 
 // ============================================================================
 // Semantic Search with pgvector
 // ============================================================================
 
-// SemanticSearch performs vector similarity search using pgvector
-func (r *PostgresResumeRepository) SemanticSearch(ctx context.Context, req resume.SearchResumesRequest) ([]resume.ResumeMatchResult, error) {
-	// First, we need to generate the query embedding
-	// This should be done in the service layer and passed here
-	// For now, this is a placeholder that returns an error
+// searchSection describes one embedding column and its weight in the combined score
+type searchSection struct {
+	name   string
+	column string
+	weight float64
+}
 
-	// Build the base query with vector similarity
-	baseQuery := `
-		SELECT 
-			r.id, r.tenant_id, r.title, r.is_active, r.is_default, r.version,
-			r.personal_info, r.work_experience, r.education, r.skills, r.languages,
-			r.certifications, r.projects, r.achievements, r.volunteer_work,
-			r.professional_summary, r.personal_statement,
-			r.file_url, r.file_name, r.file_type,
-			r.parsed_at, r.last_updated_at, r.created_at,
-			-- Weighted similarity scores (cosine distance)
-			(1 - (e.experience_embedding <=> $1)) * 0.4 AS experience_score,
-			(1 - (e.education_embedding <=> $1)) * 0.2 AS education_score,
-			(1 - (e.skills_embedding <=> $1)) * 0.3 AS skills_score,
-			(1 - (e.languages_embedding <=> $1)) * 0.1 AS languages_score,
-			-- Combined similarity score
-			(
-				(1 - (e.experience_embedding <=> $1)) * 0.4 +
-				(1 - (e.education_embedding <=> $1)) * 0.2 +
-				(1 - (e.skills_embedding <=> $1)) * 0.3 +
-				(1 - (e.languages_embedding <=> $1)) * 0.1
-			) AS similarity_score
-		FROM resumes r
-		INNER JOIN resume_embeddings e ON r.id = e.resume_id
-		WHERE 1=1`
-
-	// Build WHERE clauses and args
+// searchSectionsFor returns the resume sections scored by SemanticSearch with the given weights
+func searchSectionsFor(w resume.SectionWeights) []searchSection {
+	return []searchSection{
+		{name: "experience", column: "experience_embedding", weight: w.Experience},
+		{name: "education", column: "education_embedding", weight: w.Education},
+		{name: "skills", column: "skills_embedding", weight: w.Skills},
+		{name: "languages", column: "languages_embedding", weight: w.Languages},
+		{name: "personal_statement", column: "personal_statement_embedding", weight: w.PersonalStatement},
+	}
+}
+
+// preferredSkillsMaxBoost is added to the score of a resume that has every preferred skill
+const preferredSkillsMaxBoost = 0.1
+
+// queryArgs collects positional query arguments
+type queryArgs []any
+
+// add appends a value and returns its placeholder
+func (a *queryArgs) add(v any) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// buildSearchFilters translates the hard filters of a search request into
+// conditions over resumes r. Preferred skills are not a filter; see SemanticSearch.
+func buildSearchFilters(req resume.SearchResumesRequest, args *queryArgs) []string {
 	conditions := []string{}
-	args := []any{} // Will start with query embedding
-	argPos := 2     // Start at $2 since $1 is the query embedding
 
 	// Add tenant filter if specified
 	if req.TenantID != nil {
-		conditions = append(conditions, fmt.Sprintf("r.tenant_id = $%d", argPos))
-		args = append(args, *req.TenantID)
-		argPos++
+		conditions = append(conditions, "r.tenant_id = "+args.add(*req.TenantID))
+	}
+
+	// Restrict to specific resumes (e.g. scoring a new resume against a saved search)
+	if len(req.ResumeIDs) > 0 {
+		ids := make([]string, len(req.ResumeIDs))
+		for i, id := range req.ResumeIDs {
+			ids[i] = id.String()
+		}
+		conditions = append(conditions, "r.id = ANY("+args.add(pq.Array(ids))+"::text[])")
 	}
 
 	// Add active filter
@@ -749,67 +791,375 @@ func (r *PostgresResumeRepository) SemanticSearch(ctx context.Context, req resum
 
 	// Add years of experience filter
 	if req.MinYearsExperience != nil {
-		conditions = append(conditions, fmt.Sprintf("calculate_total_experience_months(r.work_experience) >= $%d", argPos))
-		args = append(args, int(*req.MinYearsExperience*12))
-		argPos++
+		conditions = append(conditions, "calculate_total_experience_months(r.work_experience) >= "+args.add(int(*req.MinYearsExperience*12)))
 	}
 
 	if req.MaxYearsExperience != nil {
-		conditions = append(conditions, fmt.Sprintf("calculate_total_experience_months(r.work_experience) <= $%d", argPos))
-		args = append(args, int(*req.MaxYearsExperience*12))
-		argPos++
+		conditions = append(conditions, "calculate_total_experience_months(r.work_experience) <= "+args.add(int(*req.MaxYearsExperience*12)))
 	}
 
-	// Add skills filter (required skills)
-	if len(req.RequiredSkills) > 0 {
-		conditions = append(conditions, fmt.Sprintf("extract_all_skills(r.skills) @> $%d::text[]", argPos))
-		args = append(args, pq.Array(req.RequiredSkills))
-		argPos++
+	// Add skills filter: every required skill, satisfied by any of its aliases or sub-skills
+	for _, required := range uniqueSkills(req.RequiredSkills) {
+		conditions = append(conditions, fmt.Sprintf("extract_normalized_skills(r.skills) && %s::text[]",
+			args.add(pq.Array(req.SkillExpansions.Accepted(required)))))
 	}
 
 	// Add location filter
 	if len(req.Locations) > 0 {
 		locationConditions := []string{}
 		for _, loc := range req.Locations {
-			locationConditions = append(locationConditions, fmt.Sprintf("r.personal_info->>'location' ILIKE $%d", argPos))
-			args = append(args, "%"+loc+"%")
-			argPos++
+			locationConditions = append(locationConditions, "r.personal_info->>'location' ILIKE "+args.add("%"+loc+"%"))
+			// Normalized locations store the country as its ISO code, so "Peru" has to match "PE"
+			if code, ok := normalize.CountryCode(loc); ok {
+				locationConditions = append(locationConditions, "r.personal_info->'location'->>'country' = "+args.add(code))
+			}
+		}
+		conditions = append(conditions, "("+strings.Join(locationConditions, " OR ")+")")
+	}
+
+	// Add education filter: at least the requested level on the ladder
+	if req.EducationLevel != nil && *req.EducationLevel != "" {
+		conditions = append(conditions, "highest_education_rank(r.education) >= "+args.add(resume.EducationLevelRank(*req.EducationLevel)))
+	}
+
+	// Add languages filter: every language is required, each with an optional minimum proficiency
+	for _, lang := range req.Languages {
+		langCondition := "lower(lang->>'language') = lower(" + args.add(strings.TrimSpace(lang.Language)) + ")"
+		if rank := resume.LanguageProficiencyRank(lang.MinProficiency); rank > resume.ProficiencyUnknown {
+			langCondition += " AND language_proficiency_rank(lang->>'proficiency') >= " + args.add(rank)
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM jsonb_array_elements(r.languages) lang WHERE %s)", langCondition))
+	}
+
+	// Add industries filter: any work experience in any of the industries
+	if len(req.Industries) > 0 {
+		patterns := make([]string, len(req.Industries))
+		for i, industry := range req.Industries {
+			patterns[i] = "%" + strings.TrimSpace(industry) + "%"
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM jsonb_array_elements(r.work_experience) w WHERE w->>'industry' ILIKE ANY(%s::text[]))",
+			args.add(pq.Array(patterns))))
+	}
+
+	return conditions
+}
+
+// uniqueSkills drops empty and repeated skill names (compared normalized), keeping the requested spelling
+func uniqueSkills(values []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, v := range values {
+		key := skill.Normalize(v)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// SemanticSearch performs vector similarity search using pgvector
+func (r *PostgresResumeRepository) SemanticSearch(ctx context.Context, queryEmbedding []float32, req resume.SearchResumesRequest) ([]resume.ResumeMatchResult, error) {
+	if len(queryEmbedding) == 0 {
+		return nil, resume.ErrSearchFailed().
+			WithDetail("reason", "query embedding is empty").
+			WithDetail("query", req.Query)
+	}
+
+	// $1 is the query embedding
+	args := queryArgs{pgvector.NewVector(queryEmbedding)}
+	return r.vectorSearch(ctx, req, args, vectorSearchSource{
+		vector: func(column string) string { return "$1" },
+	}, "semantic_search")
+}
+
+// FindSimilar finds resumes whose stored section embeddings are closest to those of the given resume
+func (r *PostgresResumeRepository) FindSimilar(ctx context.Context, id kernel.ResumeID, req resume.SearchResumesRequest) ([]resume.ResumeMatchResult, error) {
+	// $1 is the source resume ID; each section is compared to the same section of the source
+	args := queryArgs{id}
+	sourceFilter := "resume_id = $1"
+	if req.EmbeddingModel != "" {
+		sourceFilter += " AND model_used = " + args.add(req.EmbeddingModel)
+	}
+	return r.vectorSearch(ctx, req, args, vectorSearchSource{
+		join:       fmt.Sprintf("CROSS JOIN (SELECT * FROM resume_embeddings WHERE %s ORDER BY generated_at DESC LIMIT 1) src", sourceFilter),
+		conditions: []string{"r.id <> $1", "e.model_used = src.model_used"},
+		vector:     func(column string) string { return "src." + column },
+	}, "find_similar")
+}
+
+// vectorSearchSource describes what candidate section embeddings are compared against
+type vectorSearchSource struct {
+	join       string                     // Extra FROM clause, e.g. the source resume embeddings
+	conditions []string                   // Extra WHERE conditions
+	vector     func(column string) string // SQL expression of the vector to compare the column with
+}
+
+// vectorSearch runs a weighted section-similarity search over resumes r and resume_embeddings e
+func (r *PostgresResumeRepository) vectorSearch(ctx context.Context, req resume.SearchResumesRequest, args queryArgs, source vectorSearchSource, operation string) ([]resume.ResumeMatchResult, error) {
+	weights := resume.DefaultSectionWeights()
+	if req.Weights != nil {
+		weights = *req.Weights
+	}
+	sections := searchSectionsFor(weights)
+
+	// Casting to the model dimension lets the planner use the per-dimension HNSW indexes
+	cast := ""
+	if req.EmbeddingDim > 0 {
+		storage := req.VectorStorage
+		if storage == "" {
+			storage = resume.VectorStorageFull
 		}
-		if len(locationConditions) > 0 {
-			conditions = append(conditions, "("+strings.Join(locationConditions, " OR ")+")")
+		cast = storage.Cast(req.EmbeddingDim)
+	}
+
+	// Per-section cosine similarity; NULL when either side has no vector for that section.
+	// Experience is scored by the best-matching work experience or project (max-sim),
+	// falling back to the combined vector for resumes without entry embeddings.
+	entryVector := source.vector("experience_embedding") + cast
+	sectionScores := make([]string, len(sections))
+	for i, section := range sections {
+		score := fmt.Sprintf("1 - (e.%s%s <=> %s%s)", section.column, cast, source.vector(section.column), cast)
+		if section.name == "experience" {
+			score = fmt.Sprintf("COALESCE(best_entry.score, %s)", score)
 		}
+		sectionScores[i] = fmt.Sprintf("%s AS %s_score", score, section.name)
+	}
+
+	conditions := append(append([]string{}, source.conditions...), buildSearchFilters(req, &args)...)
+	if req.EmbeddingModel != "" {
+		conditions = append(conditions, "e.model_used = "+args.add(req.EmbeddingModel))
+	}
+	if req.EmbeddingDim > 0 {
+		conditions = append(conditions, fmt.Sprintf("e.embedding_dim = %d", req.EmbeddingDim))
 	}
 
-	// Append all conditions to query
+	where := ""
 	if len(conditions) > 0 {
-		baseQuery += " AND " + strings.Join(conditions, " AND ")
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	preferredBoost := preferredSkillsBoostExpr(req, &args)
+
+	query := fmt.Sprintf(`
+		WITH scored AS (
+			SELECT
+				r.id, r.tenant_id, r.title, r.is_active, r.is_default, r.version,
+				r.personal_info, r.work_experience, r.education, r.skills, r.languages,
+				r.certifications, r.projects, r.achievements, r.volunteer_work,
+				r.professional_summary, r.personal_statement,
+				r.file_url, r.file_name, r.file_type,
+				r.parsed_at, r.last_updated_at, r.created_at, r.needs_review,
+				%s,
+				%s AS preferred_skills_boost,
+				best_entry.entry_type AS best_entry_type,
+				best_entry.entry_index AS best_entry_index,
+				best_entry.title AS best_entry_title,
+				best_entry.organization AS best_entry_organization,
+				best_entry.score AS best_entry_score
+			FROM resumes r
+			INNER JOIN resume_embeddings e ON r.id = e.resume_id
+			%s
+			LEFT JOIN LATERAL (
+				SELECT ee.entry_type, ee.entry_index, ee.title, ee.organization,
+					1 - (ee.embedding%s <=> %s) AS score
+				FROM resume_entry_embeddings ee
+				WHERE ee.resume_id = r.id AND ee.model_used = e.model_used
+				ORDER BY ee.embedding%s <=> %s
+				LIMIT 1
+			) best_entry ON TRUE
+			%s
+		)
+		SELECT
+			id, tenant_id, title, is_active, is_default, version,
+			personal_info, work_experience, education, skills, languages,
+			certifications, projects, achievements, volunteer_work,
+			professional_summary, personal_statement,
+			file_url, file_name, file_type,
+			parsed_at, last_updated_at, created_at, needs_review,
+			%s,
+			preferred_skills_boost,
+			best_entry_type, best_entry_index, best_entry_title, best_entry_organization, best_entry_score,
+			%s + preferred_skills_boost AS similarity_score
+		FROM scored
+		ORDER BY similarity_score DESC, created_at DESC
+		LIMIT %s`,
+		strings.Join(sectionScores, ",\n\t\t\t\t"),
+		preferredBoost,
+		source.join,
+		cast, entryVector,
+		cast, entryVector,
+		where,
+		coalescedSectionScores(sections),
+		weightedScoreExpr(sections),
+		args.add(req.TopK),
+	)
+
+	rows := []resumeMatchRow{}
+	if err := r.selectWithEfSearch(ctx, req.EfSearch, req.TopK, &rows, query, args...); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeSearchFailed, err).
+			WithDetail("query", req.Query).
+			WithDetail("operation", operation)
+	}
+
+	results := make([]resume.ResumeMatchResult, 0, len(rows))
+	for i, row := range rows {
+		resumeModel, err := row.ToDomain()
+		if err != nil {
+			return nil, resume.ErrInvalidResumeData().
+				WithDetail("row_index", i).
+				WithDetails(map[string]any{
+					"error": err.Error(),
+				})
+		}
+
+		result := resume.NewResumeMatchResult(resumeModel, row.SimilarityScore, row.sections(), req)
+		result.SemanticScore = row.SimilarityScore
+		result.SetBestMatchingEntry(row.bestEntry())
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// selectWithEfSearch runs a vector query with the HNSW candidate list size set for its
+// transaction only. An index scan returns at most ef_search rows, so it is raised to
+// limit when smaller. Queries the session default serves run without a transaction.
+func (r *PostgresResumeRepository) selectWithEfSearch(ctx context.Context, efSearch *int, limit int, dest any, query string, args ...any) error {
+	size := resume.DefaultEfSearch
+	if efSearch != nil {
+		size = *efSearch
+	}
+	size = min(max(size, limit), resume.MaxEfSearch)
+	if size == resume.DefaultEfSearch {
+		return r.db.SelectContext(ctx, dest, query, args...)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("begin search: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL hnsw.ef_search = %d", size)); err != nil {
+		return fmt.Errorf("set ef_search: %w", err)
+	}
+	if err := tx.SelectContext(ctx, dest, query, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// KeywordSearch performs full-text search over the resumes search_document
+func (r *PostgresResumeRepository) KeywordSearch(ctx context.Context, req resume.SearchResumesRequest) ([]resume.ResumeMatchResult, error) {
+	// $1 is the query text
+	args := queryArgs{req.Query}
+	conditions := append([]string{"r.search_document @@ q"}, buildSearchFilters(req, &args)...)
+	preferredBoost := preferredSkillsBoostExpr(req, &args)
+
+	// ts_rank_cd normalization 32 maps the rank into [0, 1)
+	query := fmt.Sprintf(`
+		SELECT
+			r.id, r.tenant_id, r.title, r.is_active, r.is_default, r.version,
+			r.personal_info, r.work_experience, r.education, r.skills, r.languages,
+			r.certifications, r.projects, r.achievements, r.volunteer_work,
+			r.professional_summary, r.personal_statement,
+			r.file_url, r.file_name, r.file_type,
+			r.parsed_at, r.last_updated_at, r.created_at, r.needs_review,
+			ts_rank_cd(r.search_document, q, 32) AS keyword_score,
+			%s AS preferred_skills_boost
+		FROM resumes r, websearch_to_tsquery('simple', $1) q
+		WHERE %s
+		ORDER BY ts_rank_cd(r.search_document, q, 32) + (%s) DESC, r.created_at DESC
+		LIMIT %s`,
+		preferredBoost,
+		strings.Join(conditions, " AND "),
+		preferredBoost,
+		args.add(req.TopK),
+	)
+
+	rows := []resumeKeywordRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeSearchFailed, err).
+			WithDetail("query", req.Query).
+			WithDetail("operation", "keyword_search")
+	}
+
+	results := make([]resume.ResumeMatchResult, 0, len(rows))
+	for i, row := range rows {
+		resumeModel, err := row.ToDomain()
+		if err != nil {
+			return nil, resume.ErrInvalidResumeData().
+				WithDetail("row_index", i).
+				WithDetails(map[string]any{
+					"error": err.Error(),
+				})
+		}
+
+		sections := resume.MatchedSections{PreferredSkillsBoost: row.PreferredBoost}
+		result := resume.NewResumeMatchResult(resumeModel, row.KeywordScore+row.PreferredBoost, sections, req)
+		result.KeywordScore = row.KeywordScore
+		if result.MatchExplanation == "" {
+			result.MatchExplanation = fmt.Sprintf("Keyword match (%.2f)", row.KeywordScore)
+		} else {
+			result.MatchExplanation = fmt.Sprintf("Keyword match (%.2f); %s", row.KeywordScore, result.MatchExplanation)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// preferredSkillsBoostExpr scores preferred skills as a boost instead of a filter:
+// a resume with every preferred skill gets preferredSkillsMaxBoost on top of its score.
+// A preferred skill counts when any of its aliases or sub-skills is present.
+func preferredSkillsBoostExpr(req resume.SearchResumesRequest, args *queryArgs) string {
+	preferred := uniqueSkills(req.PreferredSkills)
+	if len(preferred) == 0 {
+		return "0::float8"
 	}
 
-	// Add ORDER BY and LIMIT
-	baseQuery += fmt.Sprintf(`
-		ORDER BY similarity_score DESC
-		LIMIT $%d`, argPos)
-	args = append(args, req.TopK)
+	matches := make([]string, len(preferred))
+	for i, name := range preferred {
+		matches[i] = fmt.Sprintf("(extract_normalized_skills(r.skills) && %s::text[])::int",
+			args.add(pq.Array(req.SkillExpansions.Accepted(name))))
+	}
 
-	// NOTE: This is a placeholder since we don't have the query embedding yet
-	// The embedding should be generated by the service layer
-	return []resume.ResumeMatchResult{}, resume.ErrSearchFailed().
-		WithDetail("reason", "query embedding must be generated by service layer").
-		WithDetail("query", req.Query).
-		WithDetails(map[string]any{
-			"note": "implement embedding generation in service layer first",
-		})
+	return fmt.Sprintf("%g * (%s)::float8 / %d", preferredSkillsMaxBoost, strings.Join(matches, " + "), len(preferred))
+}
 
-	// When implemented with embedding:
-	// queryEmbedding := pgvector.NewVector(embeddingFloats)
-	// args = append([]any{queryEmbedding}, args...)
-	// ... execute query and return results
+// coalescedSectionScores selects each section score from the scored CTE, replacing NULL with 0
+func coalescedSectionScores(sections []searchSection) string {
+	parts := make([]string, len(sections))
+	for i, section := range sections {
+		parts[i] = fmt.Sprintf("COALESCE(%s_score, 0) AS %s_score", section.name, section.name)
+	}
+	return strings.Join(parts, ",\n\t\t\t")
+}
+
+// weightedScoreExpr builds the combined similarity score from the scored CTE.
+// Sections without an embedding are left out and the remaining weights are
+// re-normalized, so a resume is not penalized (or dropped) for an empty section.
+func weightedScoreExpr(sections []searchSection) string {
+	numerator := make([]string, len(sections))
+	denominator := make([]string, len(sections))
+	for i, section := range sections {
+		numerator[i] = fmt.Sprintf("COALESCE(%s_score, 0) * %g", section.name, section.weight)
+		denominator[i] = fmt.Sprintf("CASE WHEN %s_score IS NULL THEN 0 ELSE %g END", section.name, section.weight)
+	}
+	return fmt.Sprintf("COALESCE((%s) / NULLIF(%s, 0), 0)",
+		strings.Join(numerator, " + "),
+		strings.Join(denominator, " + "),
+	)
 }
 
 // SearchByTenant performs semantic search within a specific tenant
-func (r *PostgresResumeRepository) SearchByTenant(ctx context.Context, tenantID kernel.TenantID, req resume.SearchResumesRequest) ([]resume.ResumeMatchResult, error) {
+func (r *PostgresResumeRepository) SearchByTenant(ctx context.Context, tenantID kernel.TenantID, queryEmbedding []float32, req resume.SearchResumesRequest) ([]resume.ResumeMatchResult, error) {
 	req.TenantID = &tenantID
-	return r.SemanticSearch(ctx, req)
+	return r.SemanticSearch(ctx, queryEmbedding, req)
 }
 
 // ============================================================================
@@ -818,31 +1168,33 @@ func (r *PostgresResumeRepository) SearchByTenant(ctx context.Context, tenantID
 
 // UpdateEmbeddings updates only the embeddings for a resume
 func (r *PostgresResumeRepository) UpdateEmbeddings(ctx context.Context, id kernel.ResumeID, embeddings resume.ResumeEmbeddings) error {
-	return r.updateEmbeddingsInternal(ctx, id, embeddings)
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return upsertEmbeddings(ctx, tx, id, embeddings)
+	})
 }
 
 // ============================================================================
 // Private Helper Methods
 // ============================================================================
 
-func (r *PostgresResumeRepository) insertEmbeddings(ctx context.Context, resumeModel *resume.Resume) error {
+func insertEmbeddings(ctx context.Context, tx *sqlx.Tx, resumeModel *resume.Resume) error {
 	query := `
 		INSERT INTO resume_embeddings (
 			resume_id, experience_embedding, education_embedding,
 			skills_embedding, languages_embedding, personal_statement_embedding,
-			model_used, embedding_dim, generated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		ON CONFLICT (resume_id) DO UPDATE SET
+			model_used, embedding_dim, generated_at, section_hashes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (resume_id, model_used) DO UPDATE SET
 			experience_embedding = COALESCE(EXCLUDED.experience_embedding, resume_embeddings.experience_embedding),
 			education_embedding = COALESCE(EXCLUDED.education_embedding, resume_embeddings.education_embedding),
 			skills_embedding = COALESCE(EXCLUDED.skills_embedding, resume_embeddings.skills_embedding),
 			languages_embedding = COALESCE(EXCLUDED.languages_embedding, resume_embeddings.languages_embedding),
 			personal_statement_embedding = COALESCE(EXCLUDED.personal_statement_embedding, resume_embeddings.personal_statement_embedding),
-			model_used = EXCLUDED.model_used,
 			embedding_dim = EXCLUDED.embedding_dim,
-			generated_at = EXCLUDED.generated_at`
+			generated_at = EXCLUDED.generated_at,
+			section_hashes = resume_embeddings.section_hashes || EXCLUDED.section_hashes`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := tx.ExecContext(ctx, query,
 		resumeModel.ID,
 		float32SliceToVectorOrNil(resumeModel.Embeddings.ExperienceEmbedding),
 		float32SliceToVectorOrNil(resumeModel.Embeddings.EducationEmbedding),
@@ -852,6 +1204,7 @@ func (r *PostgresResumeRepository) insertEmbeddings(ctx context.Context, resumeM
 		resumeModel.Embeddings.ModelUsed,
 		resumeModel.Embeddings.EmbeddingDim,
 		resumeModel.Embeddings.GeneratedAt,
+		sectionHashesJSON(resumeModel.Embeddings.SectionHashes),
 	)
 
 	if err != nil {
@@ -864,10 +1217,41 @@ func (r *PostgresResumeRepository) insertEmbeddings(ctx context.Context, resumeM
 			})
 	}
 
+	if err := replaceEntryEmbeddings(ctx, tx, resumeModel.ID, resumeModel.Embeddings); err != nil {
+		return err
+	}
+
 	logx.Infof("Successfully inserted embeddings for resume %s", resumeModel.ID)
 	return nil
 }
 
+// withTx runs fn in a transaction, committed when fn succeeds
+func withTx(ctx context.Context, db *sqlx.DB, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// sectionHashesJSON encodes section hashes for the section_hashes column
+func sectionHashesJSON(hashes map[string]string) []byte {
+	if hashes == nil {
+		hashes = map[string]string{}
+	}
+	data, _ := json.Marshal(hashes)
+	return data
+}
+
 // Helper function
 func float32SliceToVectorOrNil(slice []float32) interface{} {
 	if len(slice) == 0 {
@@ -876,33 +1260,34 @@ func float32SliceToVectorOrNil(slice []float32) interface{} {
 	return pgvector.NewVector(slice)
 }
 
-func (r *PostgresResumeRepository) updateEmbeddingsInternal(ctx context.Context, id kernel.ResumeID, embeddings resume.ResumeEmbeddings) error {
+func upsertEmbeddings(ctx context.Context, tx *sqlx.Tx, id kernel.ResumeID, embeddings resume.ResumeEmbeddings) error {
 	query := `
 		INSERT INTO resume_embeddings (
 			resume_id, experience_embedding, education_embedding,
 			skills_embedding, languages_embedding, personal_statement_embedding,
-			model_used, embedding_dim, generated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		ON CONFLICT (resume_id) DO UPDATE SET
+			model_used, embedding_dim, generated_at, section_hashes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (resume_id, model_used) DO UPDATE SET
 			experience_embedding = EXCLUDED.experience_embedding,
 			education_embedding = EXCLUDED.education_embedding,
 			skills_embedding = EXCLUDED.skills_embedding,
 			languages_embedding = EXCLUDED.languages_embedding,
 			personal_statement_embedding = EXCLUDED.personal_statement_embedding,
-			model_used = EXCLUDED.model_used,
 			embedding_dim = EXCLUDED.embedding_dim,
-			generated_at = EXCLUDED.generated_at`
+			generated_at = EXCLUDED.generated_at,
+			section_hashes = EXCLUDED.section_hashes`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := tx.ExecContext(ctx, query,
 		id,
-		float32SliceToVector(embeddings.ExperienceEmbedding),
-		float32SliceToVector(embeddings.EducationEmbedding),
-		float32SliceToVector(embeddings.SkillsEmbedding),
-		float32SliceToVector(embeddings.LanguagesEmbedding),
-		float32SliceToVector(embeddings.PersonalStatementEmbedding),
+		float32SliceToVectorOrNil(embeddings.ExperienceEmbedding),
+		float32SliceToVectorOrNil(embeddings.EducationEmbedding),
+		float32SliceToVectorOrNil(embeddings.SkillsEmbedding),
+		float32SliceToVectorOrNil(embeddings.LanguagesEmbedding),
+		float32SliceToVectorOrNil(embeddings.PersonalStatementEmbedding),
 		embeddings.ModelUsed,
 		embeddings.EmbeddingDim,
 		embeddings.GeneratedAt,
+		sectionHashesJSON(embeddings.SectionHashes),
 	)
 
 	if err != nil {
@@ -914,18 +1299,84 @@ func (r *PostgresResumeRepository) updateEmbeddingsInternal(ctx context.Context,
 			})
 	}
 
+	return replaceEntryEmbeddings(ctx, tx, id, embeddings)
+}
+
+// replaceEntryEmbeddings replaces the work experience and project vectors of a resume for the embeddings model
+func replaceEntryEmbeddings(ctx context.Context, tx *sqlx.Tx, id kernel.ResumeID, embeddings resume.ResumeEmbeddings) error {
+	fail := func(err error) error {
+		return resume.ErrEmbeddingGenerationFailed().
+			WithDetail("resume_id", id).
+			WithDetail("operation", "replace_entry_embeddings").
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM resume_entry_embeddings WHERE resume_id = $1 AND model_used = $2`,
+		id, embeddings.ModelUsed,
+	); err != nil {
+		return fail(err)
+	}
+
+	query := `
+		INSERT INTO resume_entry_embeddings (
+			resume_id, entry_type, entry_index, title, organization,
+			embedding, model_used, embedding_dim, content_hash, generated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	for _, entry := range embeddings.Entries {
+		if len(entry.Embedding) == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, query,
+			id, entry.Type, entry.Index, entry.Title, entry.Organization,
+			pgvector.NewVector(entry.Embedding), embeddings.ModelUsed, embeddings.EmbeddingDim,
+			entry.ContentHash, embeddings.GeneratedAt,
+		); err != nil {
+			return fail(err)
+		}
+	}
 	return nil
 }
 
+// getEntryEmbeddings loads the work experience and project vectors of a resume for a model
+func (r *PostgresResumeRepository) getEntryEmbeddings(ctx context.Context, resumeID kernel.ResumeID, model string) ([]resume.EntryEmbedding, error) {
+	query := `
+		SELECT entry_type, entry_index, title, organization, embedding::text, content_hash
+		FROM resume_entry_embeddings
+		WHERE resume_id = $1 AND model_used = $2
+		ORDER BY entry_type, entry_index`
+
+	rows := []entryEmbeddingRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, resumeID, model); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeEmbeddingGenerationFailed, err).
+			WithDetail("resume_id", resumeID).
+			WithDetail("operation", "get_entry_embeddings")
+	}
+
+	entries := make([]resume.EntryEmbedding, len(rows))
+	for i, row := range rows {
+		entries[i] = row.ToDomain()
+	}
+	return entries, nil
+}
+
 func (r *PostgresResumeRepository) getEmbeddings(ctx context.Context, resumeID kernel.ResumeID) (*resume.ResumeEmbeddings, error) {
+	// A resume may have vectors of several models during a migration; prefer the tenant's active model
 	query := `
 		SELECT 
-			experience_embedding::text, education_embedding::text,
-			skills_embedding::text, languages_embedding::text, 
-			personal_statement_embedding::text,
-			model_used, embedding_dim, generated_at
-		FROM resume_embeddings
-		WHERE resume_id = $1`
+			e.experience_embedding::text, e.education_embedding::text,
+			e.skills_embedding::text, e.languages_embedding::text, 
+			e.personal_statement_embedding::text,
+			e.model_used, e.embedding_dim, e.generated_at, e.section_hashes
+		FROM resume_embeddings e
+		INNER JOIN resumes r ON r.id = e.resume_id
+		LEFT JOIN resume_embedding_models m ON m.tenant_id = r.tenant_id
+		WHERE e.resume_id = $1
+		ORDER BY (e.model_used = m.active_model) DESC NULLS LAST, e.generated_at DESC
+		LIMIT 1`
 
 	row := &embeddingsRow{}
 	err := r.db.GetContext(ctx, row, query, resumeID)
@@ -939,7 +1390,13 @@ func (r *PostgresResumeRepository) getEmbeddings(ctx context.Context, resumeID k
 			WithDetail("operation", "get_embeddings")
 	}
 
-	return row.ToDomain(), nil
+	embeddings := row.ToDomain()
+	embeddings.Entries, err = r.getEntryEmbeddings(ctx, resumeID, embeddings.ModelUsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings, nil
 }
 
 // ============================================================================
@@ -0,0 +1,62 @@
+package resumeinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisSearchCache stores ranked searches in Redis as JSON
+type RedisSearchCache struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisSearchCache creates a Redis-backed search cache; searches expire after ttl
+func NewRedisSearchCache(client *redis.Client, ttl time.Duration) *RedisSearchCache {
+	return &RedisSearchCache{
+		client: client,
+		prefix: "resume:search",
+		ttl:    ttl,
+	}
+}
+
+func (c *RedisSearchCache) key(token string) string {
+	return fmt.Sprintf("%s:%s", c.prefix, token)
+}
+
+// Save stores a search under a token
+func (c *RedisSearchCache) Save(ctx context.Context, token string, snapshot *resume.SearchSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("marshal search snapshot: %w", err)
+	}
+
+	if err := c.client.Set(ctx, c.key(token), data, c.ttl).Err(); err != nil {
+		return fmt.Errorf("write search cache: %w", err)
+	}
+	return nil
+}
+
+// Get returns the search stored under a token, or nil when it has expired
+func (c *RedisSearchCache) Get(ctx context.Context, token string) (*resume.SearchSnapshot, error) {
+	data, err := c.client.Get(ctx, c.key(token)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read search cache: %w", err)
+	}
+
+	var snapshot resume.SearchSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("unmarshal search snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,297 @@
+package resumeinfra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/jmoiron/sqlx"
+	"github.com/pgvector/pgvector-go"
+)
+
+// EntriesSection names the per-entry work experience and project vectors in a benchmark
+const EntriesSection = "entries"
+
+// VectorBenchmarkConfig selects what a vector benchmark measures
+type VectorBenchmarkConfig struct {
+	Model    string   // Optional: defaults to the model with the most stored vectors
+	Sections []string // Optional: defaults to every section and the entry vectors
+	K        int      // Nearest neighbours compared per query
+	Samples  int      // Stored vectors used as queries
+	EfSearch []int    // HNSW candidate list sizes to measure
+}
+
+// LatencyStats summarizes query durations
+type LatencyStats struct {
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P95  time.Duration `json:"p95"`
+}
+
+// EfSearchResult is the quality and cost of index scans with one candidate list size
+type EfSearchResult struct {
+	EfSearch int          `json:"ef_search"`
+	Recall   float64      `json:"recall"` // Share of the exact nearest neighbours the index scan found
+	Latency  LatencyStats `json:"latency"`
+}
+
+// SectionBenchmark is the measurement of one vector column
+type SectionBenchmark struct {
+	Section        string           `json:"section"`
+	Vectors        int              `json:"vectors"`
+	AvgVectorBytes float64          `json:"avg_vector_bytes"`
+	IndexName      string           `json:"index_name"`
+	IndexBytes     int64            `json:"index_bytes"`
+	IndexUsed      bool             `json:"index_used"` // False when the planner scans the table; recall is then exact
+	Exact          LatencyStats     `json:"exact"`
+	Results        []EfSearchResult `json:"results"`
+}
+
+// VectorBenchmarkReport compares HNSW index scans with exact search for one model
+type VectorBenchmarkReport struct {
+	Model    string                     `json:"model"`
+	Dim      int                        `json:"dim"`
+	Settings resume.VectorIndexSettings `json:"settings"`
+	K        int                        `json:"k"`
+	Samples  int                        `json:"samples"`
+	Sections []SectionBenchmark         `json:"sections"`
+}
+
+// VectorBenchmark measures how closely HNSW index scans over the stored vectors
+// follow exact nearest-neighbour search, and how long both take. Stored vectors
+// serve as queries, so no embedding provider is called.
+type VectorBenchmark struct {
+	db *sqlx.DB
+}
+
+func NewVectorBenchmark(db *sqlx.DB) *VectorBenchmark {
+	return &VectorBenchmark{db: db}
+}
+
+// benchmarkTarget is a vector column and the HNSW index built over it
+type benchmarkTarget struct {
+	section string
+	table   string
+	column  string
+	id      string
+	index   string
+}
+
+// benchmarkTargets returns the vector columns of a dimension, by section name
+func benchmarkTargets(dim int) map[string]benchmarkTarget {
+	targets := map[string]benchmarkTarget{
+		EntriesSection: {
+			section: EntriesSection,
+			table:   "resume_entry_embeddings",
+			column:  "embedding",
+			id:      "id",
+			index:   fmt.Sprintf("idx_resume_entry_embeddings_%d", dim),
+		},
+	}
+	for _, section := range searchSectionsFor(resume.DefaultSectionWeights()) {
+		targets[section.name] = benchmarkTarget{
+			section: section.name,
+			table:   "resume_embeddings",
+			column:  section.column,
+			id:      "resume_id",
+			index:   fmt.Sprintf("idx_resume_embeddings_%s_%d", section.name, dim),
+		}
+	}
+	return targets
+}
+
+// Run measures every selected section
+func (b *VectorBenchmark) Run(ctx context.Context, cfg VectorBenchmarkConfig) (*VectorBenchmarkReport, error) {
+	if cfg.K < 1 || cfg.Samples < 1 || len(cfg.EfSearch) == 0 {
+		return nil, fmt.Errorf("k, samples and at least one ef_search value are required")
+	}
+	for _, ef := range cfg.EfSearch {
+		if ef < 1 || ef > resume.MaxEfSearch {
+			return nil, fmt.Errorf("ef_search %d is out of range (1-%d)", ef, resume.MaxEfSearch)
+		}
+	}
+
+	report := &VectorBenchmarkReport{K: cfg.K, Samples: cfg.Samples}
+	if err := b.db.GetContext(ctx, &report.Settings, `SELECT storage, m, ef_construction, ef_search FROM vector_index_settings`); err != nil {
+		return nil, fmt.Errorf("get vector index settings: %w", err)
+	}
+
+	// The model and dimension with the most vectors, unless a model is given
+	var model struct {
+		Name string `db:"model_used"`
+		Dim  int    `db:"embedding_dim"`
+	}
+	err := b.db.GetContext(ctx, &model, `
+		SELECT model_used, embedding_dim
+		FROM resume_embeddings
+		WHERE $1 = '' OR model_used = $1
+		GROUP BY model_used, embedding_dim
+		ORDER BY COUNT(*) DESC
+		LIMIT 1`, cfg.Model)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no stored vectors for model %q", cfg.Model)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find model: %w", err)
+	}
+	report.Model, report.Dim = model.Name, model.Dim
+
+	targets := benchmarkTargets(report.Dim)
+	sections := cfg.Sections
+	if len(sections) == 0 {
+		sections = []string{"experience", "education", "skills", "languages", "personal_statement", EntriesSection}
+	}
+
+	for _, name := range sections {
+		target, ok := targets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown section %q", name)
+		}
+
+		section, err := b.benchmarkSection(ctx, report, target, cfg.EfSearch)
+		if err != nil {
+			return nil, fmt.Errorf("benchmark %s: %w", name, err)
+		}
+		report.Sections = append(report.Sections, *section)
+	}
+
+	return report, nil
+}
+
+// benchmarkSection compares index scans with exact search on one vector column
+func (b *VectorBenchmark) benchmarkSection(ctx context.Context, report *VectorBenchmarkReport, target benchmarkTarget, efSearch []int) (*SectionBenchmark, error) {
+	result := &SectionBenchmark{Section: target.section, IndexName: target.index}
+	filter := fmt.Sprintf("model_used = $1 AND embedding_dim = %d AND %s IS NOT NULL", report.Dim, target.column)
+
+	var stats struct {
+		Vectors  int     `db:"vectors"`
+		AvgBytes float64 `db:"avg_bytes"`
+	}
+	err := b.db.GetContext(ctx, &stats, fmt.Sprintf(`
+		SELECT COUNT(*) AS vectors, COALESCE(AVG(pg_column_size(%s)), 0) AS avg_bytes
+		FROM %s WHERE %s`, target.column, target.table, filter), report.Model)
+	if err != nil {
+		return nil, fmt.Errorf("count vectors: %w", err)
+	}
+	result.Vectors, result.AvgVectorBytes = stats.Vectors, stats.AvgBytes
+
+	if err := b.db.GetContext(ctx, &result.IndexBytes, `SELECT COALESCE(pg_relation_size(to_regclass($1)), 0)`, target.index); err != nil {
+		return nil, fmt.Errorf("get index size: %w", err)
+	}
+
+	queries := []pgvector.Vector{}
+	err = b.db.SelectContext(ctx, &queries, fmt.Sprintf(`
+		SELECT %s FROM %s WHERE %s ORDER BY random() LIMIT $2`, target.column, target.table, filter), report.Model, report.Samples)
+	if err != nil {
+		return nil, fmt.Errorf("sample query vectors: %w", err)
+	}
+	if len(queries) == 0 {
+		return result, nil
+	}
+
+	// Same shape as the search queries: cast to the indexed type, filtered by model
+	cast := report.Settings.Storage.Cast(report.Dim)
+	knn := fmt.Sprintf(`
+		SELECT %s FROM %s WHERE %s
+		ORDER BY %s%s <=> $2%s
+		LIMIT %d`, target.id, target.table, filter, target.column, cast, cast, report.K)
+
+	var plan []string
+	if err := b.db.SelectContext(ctx, &plan, "EXPLAIN "+knn, report.Model, queries[0]); err != nil {
+		return nil, fmt.Errorf("explain: %w", err)
+	}
+	result.IndexUsed = strings.Contains(strings.Join(plan, "\n"), target.index)
+
+	exact := make([][]string, len(queries))
+	durations := make([]time.Duration, len(queries))
+	for i, query := range queries {
+		exact[i], durations[i], err = b.nearest(ctx, "SET LOCAL enable_indexscan = off", knn, report.Model, query)
+		if err != nil {
+			return nil, fmt.Errorf("exact search: %w", err)
+		}
+	}
+	result.Exact = latencyStats(durations)
+
+	for _, ef := range efSearch {
+		recall := 0.0
+		for i, query := range queries {
+			found, elapsed, err := b.nearest(ctx, fmt.Sprintf("SET LOCAL hnsw.ef_search = %d", ef), knn, report.Model, query)
+			if err != nil {
+				return nil, fmt.Errorf("index search: %w", err)
+			}
+			recall += overlap(exact[i], found)
+			durations[i] = elapsed
+		}
+
+		result.Results = append(result.Results, EfSearchResult{
+			EfSearch: ef,
+			Recall:   recall / float64(len(queries)),
+			Latency:  latencyStats(durations),
+		})
+	}
+
+	return result, nil
+}
+
+// nearest runs a nearest-neighbour query under a planner setting and times it
+func (b *VectorBenchmark) nearest(ctx context.Context, setting, query string, args ...any) ([]string, time.Duration, error) {
+	tx, err := b.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, setting); err != nil {
+		return nil, 0, err
+	}
+
+	ids := []string{}
+	start := time.Now()
+	if err := tx.SelectContext(ctx, &ids, query, args...); err != nil {
+		return nil, 0, err
+	}
+	return ids, time.Since(start), nil
+}
+
+// overlap returns the share of the exact results also found
+func overlap(exact, found []string) float64 {
+	if len(exact) == 0 {
+		return 1
+	}
+	hits := 0
+	for _, id := range exact {
+		if slices.Contains(found, id) {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(exact))
+}
+
+// latencyStats summarizes durations
+func latencyStats(durations []time.Duration) LatencyStats {
+	if len(durations) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	percentile := func(p int) time.Duration {
+		return sorted[min((len(sorted)*p+99)/100, len(sorted))-1]
+	}
+
+	return LatencyStats{
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(50),
+		P95:  percentile(95),
+	}
+}
@@ -2,6 +2,7 @@ package resumesrv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -107,11 +108,14 @@ func (s *Service) ProcessResumeJob(ctx context.Context, job *resume.ResumeProces
 
 	// Parse resume
 	var parsedData *resumeparser.ResumeData
+	var parseReport *resume.ParseReport
 	switch job.FileType {
 	case "pdf":
-		parsedData, err = s.parsePDFResume(ctx, fileData)
+		parsedData, parseReport, err = s.parsePagedResume(ctx, fileData)
 	case "jpg", "jpeg", "png":
-		parsedData, err = s.parseImageResume(ctx, fileData)
+		parsedData, parseReport, err = s.parseImageResume(ctx, fileData)
+	case "docx", "doc", "odt", "rtf", "txt", "html":
+		parsedData, parseReport, err = s.parseDocumentResume(ctx, job.FileType, fileData)
 	default:
 		return s.handleJobError(ctx, job, "invalid_file_type",
 			fmt.Errorf("unsupported file type: %s", job.FileType))
@@ -126,6 +130,18 @@ func (s *Service) ProcessResumeJob(ctx context.Context, job *resume.ResumeProces
 
 	// Convert to domain model
 	resumeModel := s.convertParsedDataToDomain(parsedData, job.RequestPayload)
+	attachParseReport(resumeModel, parseReport, parsedData)
+	normalizeResume(resumeModel)
+	s.canonicalizeSkills(ctx, resumeModel)
+
+	// Hold the parsed resume as a draft when the tenant reviews parses first
+	review, err := s.requiresReview(ctx, job.TenantID, resumeModel)
+	if err != nil {
+		return s.handleJobError(ctx, job, "review_settings_failed", err)
+	}
+	if review {
+		return s.holdForReview(ctx, job, resumeModel)
+	}
 
 	// Generate embeddings
 	embeddings, err := s.generateResumeEmbeddings(ctx, resumeModel)
@@ -156,9 +172,49 @@ func (s *Service) ProcessResumeJob(ctx context.Context, job *resume.ResumeProces
 	_ = s.jobRepo.UpdateProgress(ctx, job.ID, resume.StepSaving, 100)
 
 	logx.Infof("Job completed successfully: JobID=%s, ResumeID=%s", job.ID, resumeModel.ID)
+
+	s.notifyProcessed(resumeModel)
 	return nil
 }
 
+// processedListenerTimeout bounds each listener run after a job completes
+const processedListenerTimeout = 2 * time.Minute
+
+// AddProcessedListener registers a listener told about every resume that finishes processing
+func (s *Service) AddProcessedListener(listener resume.ProcessedListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// notifyProcessed runs the listeners in the background so they never delay or fail the job.
+// The runs are tracked so that shutdown can wait for them (see WaitForListeners).
+func (s *Service) notifyProcessed(r *resume.Resume) {
+	for _, listener := range s.listeners {
+		s.listenerRuns.Add(1)
+		go func(listener resume.ProcessedListener) {
+			defer s.listenerRuns.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), processedListenerTimeout)
+			defer cancel()
+			listener.ResumeProcessed(ctx, r)
+		}(listener)
+	}
+}
+
+// WaitForListeners waits for the running listeners to finish, or until ctx is done
+func (s *Service) WaitForListeners(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.listenerRuns.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // handleJobError handles job processing errors with retry logic
 func (s *Service) handleJobError(ctx context.Context, job *resume.ResumeProcessingJob, errorType string, err error) error {
 	job.AttemptCount++
@@ -172,6 +228,28 @@ func (s *Service) handleJobError(ctx context.Context, job *resume.ResumeProcessi
 		"file_name":    job.FileName,
 	}
 
+	// Parser errors say whether sending the same file again can succeed
+	retryable := true
+	var parseErr *resumeparser.ParseError
+	if errors.As(err, &parseErr) {
+		errorDetails["failure_reason"] = parseErr.Reason
+		errorDetails["transient"] = parseErr.Transient()
+		retryable = parseErr.Transient()
+	}
+
+	if !retryable {
+		logx.Errorf("Job failed with a permanent error: JobID=%s, Error=%s, Reason=%s",
+			job.ID, errorType, parseErr.Reason)
+
+		_ = s.jobRepo.MarkAsFailed(ctx, job.ID, errorType, errorDetails)
+
+		return resume.ErrJobFailed().
+			WithDetail("job_id", job.ID).
+			WithDetail("error_type", errorType).
+			WithDetail("will_retry", false).
+			WithDetails(errorDetails)
+	}
+
 	// Check if we should retry
 	if job.AttemptCount < job.MaxAttempts {
 		// Calculate exponential backoff: 2^attempt minutes
@@ -264,6 +342,7 @@ func (s *Service) GetJobStatus(ctx context.Context, jobID kernel.JobID) (*resume
 		response.Message = "Resume processed successfully"
 		response.ResumeID = job.ResumeID
 		response.CompletedAt = job.CompletedAt
+		response.ReviewedAt = job.ReviewedAt // Set when a reviewer approved the draft
 
 	case resume.JobStatusFailed:
 		response.Message = job.ErrorMessage
@@ -273,6 +352,18 @@ func (s *Service) GetJobStatus(ctx context.Context, jobID kernel.JobID) (*resume
 		}
 		response.FailedAt = job.FailedAt
 		response.AttemptCount = job.AttemptCount
+
+	case resume.JobStatusAwaitingReview:
+		response.Message = "Resume parsed, waiting for review"
+		response.CurrentStep = job.CurrentStep
+		if job.Draft != nil {
+			response.Draft = resume.ToResumeResponse(job.Draft)
+		}
+
+	case resume.JobStatusRejected:
+		response.Message = "Parsed resume rejected by reviewer"
+		response.ReviewedAt = job.ReviewedAt
+		response.ReviewNote = job.ReviewNote
 	}
 
 	return response, nil
@@ -280,6 +371,10 @@ func (s *Service) GetJobStatus(ctx context.Context, jobID kernel.JobID) (*resume
 
 // ListJobsByTenant retrieves all jobs for a tenant
 func (s *Service) ListJobsByTenant(ctx context.Context, tenantID kernel.TenantID, pagination kernel.PaginationOptions) (*kernel.Paginated[resume.ResumeProcessingJob], error) {
+	if err := validatePagination(pagination); err != nil {
+		return nil, err
+	}
+
 	jobs, err := s.jobRepo.GetByTenantID(ctx, tenantID, pagination)
 	if err != nil {
 		return nil, resume.ErrRegistry.NewWithCause(resume.CodeJobNotFound, err).
@@ -311,6 +406,12 @@ func (s *Service) CancelJob(ctx context.Context, jobID kernel.JobID, tenantID ke
 			WithDetail("job_id", jobID)
 	}
 
+	if job.Status == resume.JobStatusRejected {
+		return resume.ErrInvalidJobStatus().
+			WithDetail("job_id", jobID).
+			WithDetail("current_status", job.Status)
+	}
+
 	if job.Status == resume.JobStatusProcessing {
 		// Note: This won't stop an actively running job, just marks it
 		logx.Warnf("Attempting to cancel job that is currently processing: %s", jobID)
@@ -321,6 +422,7 @@ func (s *Service) CancelJob(ctx context.Context, jobID kernel.JobID, tenantID ke
 	job.Status = resume.JobStatusFailed
 	job.FailedAt = &now
 	job.ErrorMessage = "Job cancelled by user"
+	job.Draft = nil // A draft awaiting review is discarded
 	job.ErrorDetails = map[string]any{
 		"cancelled_at": now,
 		"tenant_id":    tenantID,
@@ -448,6 +550,10 @@ func (s *Service) GetJobStats(ctx context.Context, tenantID kernel.TenantID) (*r
 			}
 		case resume.JobStatusFailed:
 			stats.FailedJobs++
+		case resume.JobStatusAwaitingReview:
+			stats.AwaitingReviewJobs++
+		case resume.JobStatusRejected:
+			stats.RejectedJobs++
 		}
 
 		totalProgress += job.ProgressPercentage
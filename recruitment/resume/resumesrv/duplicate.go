@@ -0,0 +1,313 @@
+package resumesrv
+
+import (
+	"context"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/google/uuid"
+)
+
+// duplicateCandidates is how many similar resumes are checked against the similarity threshold
+const duplicateCandidates = 10
+
+// maxScannedNearDuplicates caps the near-duplicate pairs a tenant-wide scan returns
+const maxScannedNearDuplicates = 500
+
+// ============================================================================
+// Duplicate Detection
+// ============================================================================
+
+// FindPossibleDuplicates returns the tenant's other resumes that may belong to the same
+// person: same normalized email, phone or LinkedIn profile, or near-identical content
+func (s *Service) FindPossibleDuplicates(ctx context.Context, id kernel.ResumeID, tenantID kernel.TenantID) ([]resume.PossibleDuplicate, error) {
+	source, err := s.getTenantResume(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.possibleDuplicates(ctx, source)
+}
+
+// GetResumeWithDuplicates retrieves a tenant's resume along with its possible duplicates.
+// Duplicate hints are informational, so the resume is returned without them on failure.
+func (s *Service) GetResumeWithDuplicates(ctx context.Context, id kernel.ResumeID, tenantID kernel.TenantID) (*resume.ResumeResponse, error) {
+	resumeModel, err := s.getTenantResume(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := resume.ToResumeResponse(resumeModel)
+
+	duplicates, err := s.possibleDuplicates(ctx, resumeModel)
+	if err != nil {
+		logx.Warnf("Failed to find possible duplicates for resume %s: %v", id, err)
+	} else {
+		response.PossibleDuplicates = duplicates
+	}
+
+	return response, nil
+}
+
+func (s *Service) possibleDuplicates(ctx context.Context, source *resume.Resume) ([]resume.PossibleDuplicate, error) {
+	exact, err := s.duplicates.FindExactDuplicates(ctx, source)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeDuplicateScanFailed, err).
+			WithDetail("resume_id", source.ID)
+	}
+
+	duplicates := []resume.PossibleDuplicate{}
+	index := make(map[kernel.ResumeID]int)
+
+	for _, match := range exact {
+		other, err := s.repo.GetByID(ctx, match.ResumeID)
+		if err != nil {
+			continue // Deleted since the match was found
+		}
+		index[match.ResumeID] = len(duplicates)
+		duplicates = append(duplicates, resume.PossibleDuplicate{
+			Resume:  *resume.ToResumeSummaryResponse(other),
+			Reasons: match.Reasons,
+		})
+	}
+
+	similar, err := s.nearDuplicates(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range similar {
+		if i, ok := index[match.Resume.ID]; ok {
+			duplicates[i].Reasons = append(duplicates[i].Reasons, resume.DuplicateReasonEmbedding)
+			duplicates[i].Similarity = match.SimilarityScore
+			continue
+		}
+		index[match.Resume.ID] = len(duplicates)
+		duplicates = append(duplicates, resume.PossibleDuplicate{
+			Resume:     match.Resume,
+			Reasons:    []resume.DuplicateReason{resume.DuplicateReasonEmbedding},
+			Similarity: match.SimilarityScore,
+		})
+	}
+
+	return duplicates, nil
+}
+
+// nearDuplicates returns the resumes whose content is at least DuplicateSimilarityThreshold
+// similar to the source, using its stored embeddings
+func (s *Service) nearDuplicates(ctx context.Context, source *resume.Resume) ([]resume.ResumeMatchResult, error) {
+	if !source.HasEmbeddings() {
+		return nil, nil
+	}
+
+	response, err := s.FindSimilarResumes(ctx, source.ID, resume.SearchResumesRequest{
+		TopK:     duplicateCandidates,
+		TenantID: &source.TenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []resume.ResumeMatchResult{}
+	for _, match := range response.Results.Items {
+		if match.SimilarityScore >= resume.DuplicateSimilarityThreshold {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// ScanDuplicates runs a duplicate pass over all of a tenant's resumes. Contact and
+// embedding matches are each found with a single query.
+func (s *Service) ScanDuplicates(ctx context.Context, tenantID kernel.TenantID) (*resume.ScanDuplicatesResponse, error) {
+	exact, err := s.duplicates.ListExactDuplicatePairs(ctx, tenantID)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeDuplicateScanFailed, err).
+			WithDetail("tenant_id", tenantID)
+	}
+
+	model, err := s.activeModel(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	near, err := s.duplicates.ListNearDuplicatePairs(ctx, tenantID, model, resume.DuplicateSimilarityThreshold, maxScannedNearDuplicates)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeDuplicateScanFailed, err).
+			WithDetail("tenant_id", tenantID).
+			WithDetail("model", model)
+	}
+
+	scanned, err := s.repo.CountByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each pair is keyed with the smaller ID first so both directions land on it
+	pairs := []resume.DuplicatePair{}
+	index := make(map[[2]kernel.ResumeID]int)
+	pairIndex := func(a, b kernel.ResumeID) int {
+		if b < a {
+			a, b = b, a
+		}
+		key := [2]kernel.ResumeID{a, b}
+		if i, ok := index[key]; ok {
+			return i
+		}
+		index[key] = len(pairs)
+		pairs = append(pairs, resume.DuplicatePair{ResumeID: a, DuplicateID: b, Reasons: []resume.DuplicateReason{}})
+		return index[key]
+	}
+
+	for _, pair := range exact {
+		i := pairIndex(pair.ResumeID, pair.DuplicateID)
+		for _, reason := range pair.Reasons {
+			pairs[i].AddReason(reason)
+		}
+	}
+
+	for _, pair := range near {
+		i := pairIndex(pair.ResumeID, pair.DuplicateID)
+		pairs[i].AddReason(resume.DuplicateReasonEmbedding)
+		pairs[i].Similarity = pair.Similarity
+	}
+
+	return &resume.ScanDuplicatesResponse{
+		Pairs:          pairs,
+		Total:          len(pairs),
+		ScannedResumes: int(scanned),
+		Truncated:      len(near) == maxScannedNearDuplicates,
+	}, nil
+}
+
+// ============================================================================
+// Merge
+// ============================================================================
+
+// MergeResumes folds duplicate resumes into a surviving one. Each field is taken
+// from the resume picked in the request (by default the survivor's value, or the
+// first non-empty source value), processing jobs move to the survivor, and the
+// merged resumes are deleted with a snapshot kept in the merge record.
+func (s *Service) MergeResumes(ctx context.Context, req resume.MergeResumesRequest) (*resume.MergeResumesResponse, error) {
+	if len(req.SourceIDs) == 0 {
+		return nil, resume.ErrInvalidMergeRequest().
+			WithDetail("field", "source_ids").
+			WithDetail("reason", "at least one resume to merge is required")
+	}
+
+	survivor, err := s.getTenantResume(ctx, req.SurvivorID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]*resume.Resume, 0, len(req.SourceIDs))
+	involved := map[kernel.ResumeID]bool{survivor.ID: true}
+	for _, id := range req.SourceIDs {
+		if involved[id] {
+			return nil, resume.ErrInvalidMergeRequest().
+				WithDetail("resume_id", id).
+				WithDetail("reason", "each resume can appear only once and the survivor cannot be merged into itself")
+		}
+		involved[id] = true
+
+		source, err := s.getTenantResume(ctx, id, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	for field, id := range req.FieldSources {
+		if !field.IsValid() {
+			return nil, resume.ErrInvalidMergeRequest().
+				WithDetail("field", field).
+				WithDetail("allowed_fields", resume.MergeFields)
+		}
+		if !involved[id] {
+			return nil, resume.ErrInvalidMergeRequest().
+				WithDetail("field", field).
+				WithDetail("resume_id", id).
+				WithDetail("reason", "fields can only come from the survivor or the merged resumes")
+		}
+	}
+
+	// Snapshot the merged resumes before they are deleted
+	snapshots := make([]resume.ResumeResponse, len(sources))
+	mergedIDs := make([]kernel.ResumeID, len(sources))
+	for i, source := range sources {
+		snapshots[i] = *resume.ToResumeResponse(source)
+		mergedIDs[i] = source.ID
+		if source.IsDefault {
+			survivor.SetAsDefault()
+		}
+	}
+
+	fieldSources := survivor.MergeFrom(sources, req.FieldSources)
+	s.canonicalizeSkills(ctx, survivor)
+	survivor.Version++
+	survivor.LastUpdatedAt = time.Now()
+
+	embeddings, err := s.generateResumeEmbeddings(ctx, survivor)
+	if err != nil {
+		return nil, resume.ErrEmbeddingGenerationFailed().
+			WithDetail("resume_id", survivor.ID).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+	survivor.Embeddings = *embeddings
+
+	// The survivor is only saved together with the rest of the merge
+	merge := &resume.ResumeMerge{
+		ID:              kernel.NewResumeMergeID(uuid.NewString()),
+		TenantID:        req.TenantID,
+		SurvivorID:      survivor.ID,
+		MergedResumeIDs: mergedIDs,
+		FieldSources:    fieldSources,
+		MergedResumes:   snapshots,
+		MergedBy:        req.MergedBy,
+		CreatedAt:       time.Now(),
+	}
+	if err := s.duplicates.CompleteMerge(ctx, merge, survivor); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMergeFailed, err).
+			WithDetail("resume_id", survivor.ID).
+			WithDetail("merged_resume_ids", mergedIDs).
+			WithDetail("step", "complete_merge")
+	}
+
+	logx.Infof("Merged %d resumes into %s (moved %d jobs)", len(mergedIDs), survivor.ID, merge.MovedJobs)
+
+	return &resume.MergeResumesResponse{
+		Resume: *resume.ToResumeResponse(survivor),
+		Merge:  *merge,
+	}, nil
+}
+
+// ListResumeMerges returns the merges folded into a resume, newest first
+func (s *Service) ListResumeMerges(ctx context.Context, id kernel.ResumeID, tenantID kernel.TenantID) ([]*resume.ResumeMerge, error) {
+	if _, err := s.getTenantResume(ctx, id, tenantID); err != nil {
+		return nil, err
+	}
+
+	merges, err := s.duplicates.ListMerges(ctx, id)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMergeFailed, err).
+			WithDetail("resume_id", id)
+	}
+	return merges, nil
+}
+
+// getTenantResume retrieves a resume that belongs to the tenant
+func (s *Service) getTenantResume(ctx context.Context, id kernel.ResumeID, tenantID kernel.TenantID) (*resume.Resume, error) {
+	r, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, resume.ErrResumeNotFound().
+			WithDetail("resume_id", id)
+	}
+	if r.TenantID != tenantID {
+		return nil, resume.ErrTenantMismatch().
+			WithDetail("resume_id", id)
+	}
+	return r, nil
+}
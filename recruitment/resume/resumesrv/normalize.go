@@ -0,0 +1,106 @@
+package resumesrv
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/internal/normalize"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+// ============================================================================
+// Normalization of Contact Details and Dates
+// ============================================================================
+
+// normalizeResume rewrites the location, phone and dates of a resume in their canonical
+// forms. Values that cannot be read are kept as written and returned as issues, which
+// are also recorded on the parse report when the resume has one.
+func normalizeResume(r *resume.Resume) []resume.NormalizationIssue {
+	var issues []resume.NormalizationIssue
+	report := func(field, value, reason string) {
+		issues = append(issues, resume.NormalizationIssue{Field: field, Value: value, Reason: reason})
+	}
+
+	// Location first, its country is the default for the phone
+	loc := r.PersonalInfo.Location
+	if written := strings.TrimSpace(strings.Join([]string{loc.City, loc.State, loc.Country}, " ")); written != "" {
+		normalized, ok := normalize.NormalizeLocation(normalize.Location{
+			City:    loc.City,
+			State:   loc.State,
+			Country: loc.Country,
+			ZipCode: loc.ZipCode,
+		})
+		if ok {
+			r.PersonalInfo.Location = resume.Location{
+				City:    normalized.City,
+				State:   normalized.State,
+				Country: normalized.Country,
+				ZipCode: normalized.ZipCode,
+			}
+		} else {
+			report("personal_info.location", formatLocation(loc), "country not recognized")
+		}
+	}
+
+	if phone := strings.TrimSpace(r.PersonalInfo.Phone); phone != "" {
+		if formatted, ok := normalize.FormatPhone(phone, r.PersonalInfo.Location.Country); ok {
+			r.PersonalInfo.Phone = formatted
+		} else {
+			report("personal_info.phone", phone, "not a valid phone number for the country")
+		}
+	}
+
+	date := func(field string, value *string) bool {
+		if strings.TrimSpace(*value) == "" {
+			return true
+		}
+		normalized, ok := normalize.ParseDate(*value)
+		if !ok {
+			report(field, *value, "unrecognized date")
+			return false
+		}
+		*value = normalized
+		return true
+	}
+
+	now := time.Now()
+	for i := range r.WorkExperience {
+		exp := &r.WorkExperience[i]
+		prefix := fmt.Sprintf("work_experience[%d].", i)
+		startOK := date(prefix+"start_date", &exp.StartDate)
+		endOK := date(prefix+"end_date", &exp.EndDate)
+		if startOK && endOK {
+			if months, ok := normalize.MonthsBetween(exp.StartDate, exp.EndDate, now); ok {
+				exp.DurationMonths = months
+			}
+		}
+	}
+	for i := range r.Education {
+		date(fmt.Sprintf("education[%d].graduation_date", i), &r.Education[i].GraduationDate)
+	}
+	for i := range r.VolunteerWork {
+		date(fmt.Sprintf("volunteer_work[%d].start_date", i), &r.VolunteerWork[i].StartDate)
+		date(fmt.Sprintf("volunteer_work[%d].end_date", i), &r.VolunteerWork[i].EndDate)
+	}
+	for i := range r.Certifications {
+		date(fmt.Sprintf("certifications[%d].issue_date", i), &r.Certifications[i].IssueDate)
+		date(fmt.Sprintf("certifications[%d].expiration_date", i), &r.Certifications[i].ExpirationDate)
+	}
+
+	if r.ParseReport != nil {
+		r.ParseReport.Unnormalized = issues
+	}
+	return issues
+}
+
+// formatLocation writes a location's parts as they were filled in, for reporting
+func formatLocation(loc resume.Location) string {
+	parts := []string{}
+	for _, part := range []string{loc.City, loc.State, loc.Country, loc.ZipCode} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
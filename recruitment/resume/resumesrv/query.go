@@ -0,0 +1,105 @@
+package resumesrv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/internal/ai/queryparser"
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+// queryInterpretTimeout bounds the language model call; slower answers fall back to the rules
+const queryInterpretTimeout = 5 * time.Second
+
+// ============================================================================
+// Query Understanding
+// ============================================================================
+
+// InterpretQuery previews the filters a search would extract from its query
+func (s *Service) InterpretQuery(ctx context.Context, req resume.InterpretQueryRequest) (*resume.QueryInterpretation, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, resume.ErrInvalidSearchRequest().
+			WithDetail("field", "query").
+			WithDetail("reason", "search query is required")
+	}
+
+	return s.interpretQuery(ctx, &req.TenantID, query), nil
+}
+
+// interpretQuery extracts filters from a query with the language model, falling back
+// to the deterministic rules when it is not configured, fails or times out
+func (s *Service) interpretQuery(ctx context.Context, tenantID *kernel.TenantID, query string) *resume.QueryInterpretation {
+	if s.queryParser != nil {
+		llmCtx, cancel := context.WithTimeout(ctx, queryInterpretTimeout)
+		defer cancel()
+
+		parsed, err := s.queryParser.ParseQuery(llmCtx, query)
+		if err == nil {
+			return convertParsedQuery(query, parsed)
+		}
+		logx.Warnf("Query interpretation failed, using rules: %v", err)
+	}
+
+	return resume.InterpretQueryRules(query, s.querySkills(ctx, tenantID, query))
+}
+
+// querySkills maps the phrases of a query that name a skill to their canonical names
+func (s *Service) querySkills(ctx context.Context, tenantID *kernel.TenantID, query string) map[string]string {
+	if s.skills == nil || tenantID == nil {
+		return map[string]string{}
+	}
+
+	canonical, err := s.skills.Canonicalize(ctx, *tenantID, resume.SkillCandidates(query))
+	if err != nil {
+		logx.Warnf("Failed to resolve query skills: %v", err)
+		return map[string]string{}
+	}
+	return canonical
+}
+
+// convertParsedQuery converts the language model output, dropping values that cannot be used as filters
+func convertParsedQuery(query string, parsed *queryparser.ParsedQuery) *resume.QueryInterpretation {
+	interpretation := &resume.QueryInterpretation{
+		OriginalQuery:  query,
+		SemanticQuery:  strings.TrimSpace(parsed.SemanticQuery),
+		Locations:      []string{},
+		Languages:      []resume.LanguageRequirement{},
+		RequiredSkills: []string{},
+		InterpretedBy:  resume.QueryInterpreterLLM,
+	}
+
+	if parsed.MinYearsExperience != nil && *parsed.MinYearsExperience > 0 {
+		years := *parsed.MinYearsExperience
+		interpretation.MinYearsExperience = &years
+	}
+
+	for _, location := range parsed.Locations {
+		if location = strings.TrimSpace(location); location != "" {
+			interpretation.Locations = append(interpretation.Locations, location)
+		}
+	}
+
+	for _, name := range parsed.RequiredSkills {
+		if name = strings.TrimSpace(name); name != "" {
+			interpretation.RequiredSkills = append(interpretation.RequiredSkills, name)
+		}
+	}
+
+	for _, lang := range parsed.Languages {
+		language := strings.TrimSpace(lang.Language)
+		if language == "" {
+			continue
+		}
+		requirement := resume.LanguageRequirement{Language: language}
+		if resume.LanguageProficiencyRank(lang.MinProficiency) > resume.ProficiencyUnknown {
+			requirement.MinProficiency = lang.MinProficiency
+		}
+		interpretation.Languages = append(interpretation.Languages, requirement)
+	}
+
+	return interpretation
+}
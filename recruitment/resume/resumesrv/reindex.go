@@ -0,0 +1,372 @@
+package resumesrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Abraxas-365/relay/internal/ai/embeddings"
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/google/uuid"
+)
+
+const (
+	// ReindexBatchSize is how many resumes are re-embedded between progress updates
+	ReindexBatchSize = 25
+
+	// maxCutoverCatchUp bounds the resumes re-embedded synchronously during cutover
+	maxCutoverCatchUp = 200
+)
+
+// ============================================================================
+// Embedding Model Migrations
+// ============================================================================
+
+// StartEmbeddingMigration schedules re-embedding of the tenant's resumes with another model.
+// Search keeps using the current model until the migration is cut over.
+func (s *Service) StartEmbeddingMigration(ctx context.Context, req resume.StartEmbeddingMigrationRequest) (*resume.EmbeddingMigrationResponse, error) {
+	if s.migrations == nil {
+		return nil, resume.ErrMigrationFailed().
+			WithDetail("reason", "embedding migrations are not configured")
+	}
+
+	target, ok := s.embedders.Get(req.TargetModel)
+	if !ok {
+		return nil, resume.ErrEmbeddingModelUnavailable().
+			WithDetail("model", req.TargetModel).
+			WithDetail("available", s.embedders.Models())
+	}
+
+	settings, err := s.vectorIndexSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if target.Dimension() > settings.Storage.MaxDimension() {
+		return nil, resume.ErrEmbeddingDimMismatch().
+			WithDetail("model", target.Model()).
+			WithDetail("dimension", target.Dimension()).
+			WithDetail("storage", settings.Storage).
+			WithDetail("max", settings.Storage.MaxDimension())
+	}
+
+	source, err := s.activeModel(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if source == target.Model() {
+		return nil, resume.ErrInvalidMigrationState().
+			WithDetail("model", source).
+			WithDetail("reason", "model is already active for this tenant")
+	}
+
+	existing, err := s.migrations.ListByTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("tenant_id", req.TenantID)
+	}
+	for _, m := range existing {
+		if m.IsInProgress() {
+			return nil, resume.ErrMigrationAlreadyRunning().
+				WithDetail("migration_id", m.ID).
+				WithDetail("target_model", m.TargetModel)
+		}
+	}
+
+	// Pin the current model so the new vectors are not picked up before cutover
+	if err := s.migrations.EnsureActiveModel(ctx, req.TenantID, source); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("tenant_id", req.TenantID)
+	}
+
+	if err := s.migrations.EnsureVectorIndexes(ctx, target.Dimension()); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("dimension", target.Dimension())
+	}
+
+	total, err := s.migrations.CountPending(ctx, req.TenantID, target.Model())
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("tenant_id", req.TenantID)
+	}
+
+	migration := &resume.EmbeddingMigration{
+		ID:          kernel.NewEmbeddingMigrationID(uuid.NewString()),
+		TenantID:    req.TenantID,
+		SourceModel: source,
+		TargetModel: target.Model(),
+		TargetDim:   target.Dimension(),
+		Status:      resume.MigrationStatusPending,
+		Total:       total,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.migrations.Create(ctx, migration); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("tenant_id", req.TenantID).
+			WithDetail("target_model", target.Model())
+	}
+
+	logx.Infof("Scheduled embedding migration %s for tenant %s: %s -> %s (%d resumes)",
+		migration.ID, req.TenantID, source, target.Model(), total)
+
+	return resume.ToEmbeddingMigrationResponse(migration, source), nil
+}
+
+// GetEmbeddingMigration returns a migration of the tenant
+func (s *Service) GetEmbeddingMigration(ctx context.Context, tenantID kernel.TenantID, id kernel.EmbeddingMigrationID) (*resume.EmbeddingMigrationResponse, error) {
+	migration, err := s.getTenantMigration(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.activeModel(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resume.ToEmbeddingMigrationResponse(migration, active), nil
+}
+
+// ListEmbeddingMigrations returns the tenant's migrations, newest first
+func (s *Service) ListEmbeddingMigrations(ctx context.Context, tenantID kernel.TenantID) ([]*resume.EmbeddingMigrationResponse, error) {
+	if s.migrations == nil {
+		return []*resume.EmbeddingMigrationResponse{}, nil
+	}
+
+	migrations, err := s.migrations.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("tenant_id", tenantID)
+	}
+
+	active, err := s.activeModel(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*resume.EmbeddingMigrationResponse, len(migrations))
+	for i, m := range migrations {
+		responses[i] = resume.ToEmbeddingMigrationResponse(m, active)
+	}
+	return responses, nil
+}
+
+// ClaimEmbeddingMigration takes the next migration to run, or one abandoned for longer than staleAfter.
+// Returns nil when there is nothing to do.
+func (s *Service) ClaimEmbeddingMigration(ctx context.Context, staleAfter time.Duration) (*resume.EmbeddingMigration, error) {
+	if s.migrations == nil {
+		return nil, nil
+	}
+	return s.migrations.ClaimNext(ctx, staleAfter)
+}
+
+// RunEmbeddingMigration writes target vectors for every resume still missing them.
+// It is called by the re-index worker on a claimed migration.
+func (s *Service) RunEmbeddingMigration(ctx context.Context, migration *resume.EmbeddingMigration) error {
+	embedder, ok := s.embedders.Get(migration.TargetModel)
+	if !ok {
+		return s.failMigration(ctx, migration, fmt.Sprintf("embedding model %s is not configured", migration.TargetModel))
+	}
+
+	// Resumes that failed in this run are skipped so the loop terminates; cutover retries them
+	failed := []kernel.ResumeID{}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			// Left running; the stale heartbeat lets another worker resume it
+			return err
+		}
+
+		ids, err := s.migrations.ListPendingResumeIDs(ctx, migration.TenantID, migration.TargetModel, failed, ReindexBatchSize)
+		if err != nil {
+			return s.failMigration(ctx, migration, err.Error())
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		for _, id := range ids {
+			if err := s.reindexResume(ctx, embedder, id); err != nil {
+				logx.Warnf("Embedding migration %s: resume %s failed: %v", migration.ID, id, err)
+				failed = append(failed, id)
+				migration.FailedCount++
+				continue
+			}
+			migration.Processed++
+		}
+
+		// Resumes uploaded during the run grow the total
+		migration.Total = max(migration.Total, migration.Processed+migration.FailedCount)
+		if err := s.migrations.UpdateProgress(ctx, migration); err != nil {
+			logx.Errorf("Embedding migration %s: failed to save progress: %v", migration.ID, err)
+		}
+	}
+
+	if migration.Processed == 0 && migration.FailedCount > 0 {
+		return s.failMigration(ctx, migration, fmt.Sprintf("all %d resumes failed to re-embed", migration.FailedCount))
+	}
+
+	now := time.Now()
+	migration.Status = resume.MigrationStatusCompleted
+	migration.CompletedAt = &now
+	if migration.FailedCount > 0 {
+		migration.ErrorMessage = fmt.Sprintf("%d resumes failed and will be retried at cutover", migration.FailedCount)
+	}
+
+	if err := s.migrations.UpdateStatus(ctx, migration); err != nil {
+		return resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("migration_id", migration.ID)
+	}
+
+	logx.Infof("Embedding migration %s completed: %d processed, %d failed",
+		migration.ID, migration.Processed, migration.FailedCount)
+	return nil
+}
+
+// CutoverEmbeddingMigration makes the migration target the tenant's active model.
+// Resumes created or updated since the migration finished are re-embedded first.
+func (s *Service) CutoverEmbeddingMigration(ctx context.Context, tenantID kernel.TenantID, id kernel.EmbeddingMigrationID) (*resume.EmbeddingMigrationResponse, error) {
+	migration, err := s.getTenantMigration(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !migration.CanCutover() {
+		return nil, resume.ErrInvalidMigrationState().
+			WithDetail("migration_id", id).
+			WithDetail("status", migration.Status).
+			WithDetail("reason", "only completed migrations can be cut over")
+	}
+
+	embedder, ok := s.embedders.Get(migration.TargetModel)
+	if !ok {
+		return nil, resume.ErrEmbeddingModelUnavailable().
+			WithDetail("model", migration.TargetModel)
+	}
+
+	// Catch up so no resume disappears from search after the switch
+	ids, err := s.migrations.ListPendingResumeIDs(ctx, tenantID, migration.TargetModel, nil, maxCutoverCatchUp+1)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("migration_id", id)
+	}
+	if len(ids) > maxCutoverCatchUp {
+		return nil, resume.ErrInvalidMigrationState().
+			WithDetail("migration_id", id).
+			WithDetail("reason", "too many resumes changed since the migration completed; start a new migration")
+	}
+
+	for _, resumeID := range ids {
+		if err := s.reindexResume(ctx, embedder, resumeID); err != nil {
+			return nil, resume.ErrMigrationFailed().
+				WithDetail("migration_id", id).
+				WithDetail("resume_id", resumeID).
+				WithDetail("reason", "resume could not be re-embedded; cutover aborted").
+				WithDetails(map[string]any{
+					"error": err.Error(),
+				})
+		}
+	}
+
+	if err := s.migrations.Cutover(ctx, migration); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	migration.Status = resume.MigrationStatusCutover
+	migration.CutoverAt = &now
+
+	logx.Infof("Embedding migration %s cut over: tenant %s now searches %s", id, tenantID, migration.TargetModel)
+	return resume.ToEmbeddingMigrationResponse(migration, migration.TargetModel), nil
+}
+
+// CleanupEmbeddingMigration deletes the source model vectors of a cut over migration
+func (s *Service) CleanupEmbeddingMigration(ctx context.Context, tenantID kernel.TenantID, id kernel.EmbeddingMigrationID) (*resume.EmbeddingMigrationResponse, error) {
+	migration, err := s.getTenantMigration(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !migration.CanCleanup() {
+		return nil, resume.ErrInvalidMigrationState().
+			WithDetail("migration_id", id).
+			WithDetail("status", migration.Status).
+			WithDetail("reason", "only cut over migrations can be cleaned up")
+	}
+
+	active, err := s.activeModel(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if active == migration.SourceModel {
+		return nil, resume.ErrInvalidMigrationState().
+			WithDetail("migration_id", id).
+			WithDetail("reason", "source model is active again; its vectors are still in use")
+	}
+
+	deleted, err := s.migrations.DeleteModelEmbeddings(ctx, tenantID, migration.SourceModel)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("migration_id", id)
+	}
+
+	now := time.Now()
+	migration.Status = resume.MigrationStatusCleaned
+	migration.CleanedAt = &now
+	if err := s.migrations.UpdateStatus(ctx, migration); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+			WithDetail("migration_id", id)
+	}
+
+	logx.Infof("Embedding migration %s cleaned up: deleted %d %s vectors", id, deleted, migration.SourceModel)
+	return resume.ToEmbeddingMigrationResponse(migration, active), nil
+}
+
+// getTenantMigration loads a migration and checks it belongs to the tenant
+func (s *Service) getTenantMigration(ctx context.Context, tenantID kernel.TenantID, id kernel.EmbeddingMigrationID) (*resume.EmbeddingMigration, error) {
+	if s.migrations == nil {
+		return nil, resume.ErrMigrationNotFound().WithDetail("migration_id", id)
+	}
+
+	migration, err := s.migrations.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if migration.TenantID != tenantID {
+		return nil, resume.ErrMigrationNotFound().WithDetail("migration_id", id)
+	}
+
+	return migration, nil
+}
+
+// reindexResume writes vectors of the embedder's model for one resume, next to any existing ones
+func (s *Service) reindexResume(ctx context.Context, embedder embeddings.Embedder, id kernel.ResumeID) error {
+	resumeModel, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.embedResume(ctx, embedder, resumeModel, false)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateEmbeddings(ctx, id, *result)
+}
+
+// failMigration marks a migration as failed and returns the reason as an error
+func (s *Service) failMigration(ctx context.Context, migration *resume.EmbeddingMigration, reason string) error {
+	migration.Status = resume.MigrationStatusFailed
+	migration.ErrorMessage = reason
+
+	if err := s.migrations.UpdateStatus(ctx, migration); err != nil {
+		logx.Errorf("Embedding migration %s: failed to mark as failed: %v", migration.ID, err)
+	}
+
+	return resume.ErrMigrationFailed().
+		WithDetail("migration_id", migration.ID).
+		WithDetail("reason", reason)
+}
@@ -0,0 +1,233 @@
+package resumesrv
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/internal/ai/reranker"
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+const (
+	// rerankTimeout bounds the reranker call; slower answers keep the first-pass order
+	rerankTimeout = 20 * time.Second
+
+	// rerankCandidateChars caps the resume text sent per candidate
+	rerankCandidateChars = 2000
+)
+
+// ============================================================================
+// Re-ranking
+// ============================================================================
+
+// rerankResults re-orders the top hits with the reranker when the tenant enabled it.
+// Re-ranking only refines the order, so any failure keeps the first-pass results.
+// The returned summary is nil when re-ranking is off for the tenant and not requested.
+func (s *Service) rerankResults(ctx context.Context, req resume.SearchResumesRequest, query string, matches []resume.ResumeMatchResult) ([]resume.ResumeMatchResult, *resume.RerankSummary) {
+	requested := req.Rerank != nil && *req.Rerank
+	if req.Rerank != nil && !*req.Rerank {
+		return matches, nil
+	}
+
+	settings := resume.DefaultRerankSettings()
+	if req.TenantID != nil {
+		tenantSettings, err := s.GetRerankSettings(ctx, *req.TenantID)
+		if err != nil {
+			logx.Warnf("Skipping re-ranking for tenant %s: %v", req.TenantID, err)
+		} else {
+			settings = *tenantSettings
+		}
+	}
+
+	skipped := func(reason string) ([]resume.ResumeMatchResult, *resume.RerankSummary) {
+		if !settings.Enabled && !requested {
+			return matches, nil
+		}
+		return matches, &resume.RerankSummary{SkippedReason: reason}
+	}
+
+	switch {
+	case !settings.Enabled:
+		return skipped("re-ranking is disabled for this tenant")
+	case s.reranker == nil:
+		return skipped("no reranker is configured")
+	case len(matches) == 0:
+		return skipped("no results to re-rank")
+	}
+
+	if strings.TrimSpace(req.JobDescription) != "" {
+		query = req.JobDescription
+	}
+
+	candidates, err := s.rerankCandidates(ctx, matches[:min(settings.TopN, len(matches))])
+	if err != nil {
+		logx.Warnf("Failed to load resumes for re-ranking: %v", err)
+		return skipped("the results could not be loaded")
+	}
+
+	// Drop the lowest-ranked candidates until the estimate fits the cost cap
+	cost := s.reranker.EstimateCost(query, candidates)
+	for len(candidates) > 0 && cost > settings.MaxCostPerQuery {
+		candidates = candidates[:len(candidates)-1]
+		cost = s.reranker.EstimateCost(query, candidates)
+	}
+	if len(candidates) == 0 {
+		return skipped("the cost cap does not allow re-ranking a single result")
+	}
+
+	summary := &resume.RerankSummary{
+		Reranker:      s.reranker.Name(),
+		Candidates:    len(candidates),
+		EstimatedCost: cost,
+	}
+
+	rerankCtx, cancel := context.WithTimeout(ctx, rerankTimeout)
+	defer cancel()
+
+	results, err := s.reranker.Rerank(rerankCtx, query, candidates)
+	if err != nil {
+		logx.Warnf("Re-ranking failed, keeping first-pass order: %v", err)
+		summary.SkippedReason = "the reranker failed"
+		return matches, summary
+	}
+
+	scores := make([]resume.RerankScore, len(results))
+	for i, result := range results {
+		scores[i] = resume.RerankScore{
+			ResumeID:      kernel.ResumeID(result.ID),
+			Score:         result.Score,
+			Justification: result.Justification,
+		}
+	}
+
+	summary.Applied = true
+	return resume.ApplyRerankScores(matches, scores), summary
+}
+
+// rerankCandidates describes each hit for the reranker, in the order of the hits.
+// Contact details are left out so they never reach the model and cannot sway the score.
+func (s *Service) rerankCandidates(ctx context.Context, matches []resume.ResumeMatchResult) ([]reranker.Candidate, error) {
+	ids := make([]kernel.ResumeID, len(matches))
+	for i, match := range matches {
+		ids[i] = match.Resume.ID
+	}
+	resumes, err := s.repo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[kernel.ResumeID]*resume.Resume, len(resumes))
+	for _, r := range resumes {
+		byID[r.ID] = r
+	}
+
+	candidates := make([]reranker.Candidate, 0, len(matches))
+	for _, match := range matches {
+		r, ok := byID[match.Resume.ID]
+		if !ok {
+			continue // Deleted since the search ran
+		}
+
+		parts := []string{}
+		for _, part := range []string{
+			r.Title,
+			r.ProfessionalSummary,
+			s.formatExperienceForEmbedding(r),
+			s.formatSkillsForEmbedding(r),
+			s.formatEducationForEmbedding(r),
+			s.formatLanguagesForEmbedding(r),
+		} {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+
+		text := strings.Join(parts, "\n")
+		if len(text) > rerankCandidateChars {
+			text = strings.ToValidUTF8(text[:rerankCandidateChars], "")
+		}
+
+		candidates = append(candidates, reranker.Candidate{ID: r.ID.String(), Text: text})
+	}
+	return candidates, nil
+}
+
+// GetRerankSettings returns the tenant's re-ranking settings, or the defaults when none are stored
+func (s *Service) GetRerankSettings(ctx context.Context, tenantID kernel.TenantID) (*resume.RerankSettings, error) {
+	settings := resume.DefaultRerankSettings()
+	if s.tenantConfig == nil {
+		return &settings, nil
+	}
+
+	config, err := s.tenantConfig.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := config[resume.RerankConfigKey]
+	if !ok || raw == "" {
+		return &settings, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		return nil, resume.ErrInvalidSearchRequest().
+			WithDetail("tenant_id", tenantID).
+			WithDetail("config_key", resume.RerankConfigKey).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SetRerankSettings validates and stores the tenant's re-ranking settings
+func (s *Service) SetRerankSettings(ctx context.Context, tenantID kernel.TenantID, settings resume.RerankSettings) (*resume.RerankSettings, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	if s.tenantConfig == nil {
+		return nil, resume.ErrSearchFailed().
+			WithDetail("reason", "tenant configuration is not available")
+	}
+
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tenantConfig.SaveSetting(ctx, tenantID, resume.RerankConfigKey, string(raw)); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// ResetRerankSettings removes the tenant's re-ranking settings, which disables re-ranking
+func (s *Service) ResetRerankSettings(ctx context.Context, tenantID kernel.TenantID) (*resume.RerankSettings, error) {
+	settings := resume.DefaultRerankSettings()
+	if s.tenantConfig == nil {
+		return &settings, nil
+	}
+
+	config, err := s.tenantConfig.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := config[resume.RerankConfigKey]; ok {
+		if err := s.tenantConfig.DeleteSetting(ctx, tenantID, resume.RerankConfigKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,278 @@
+package resumesrv
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+// ============================================================================
+// Review of Parsed Resumes
+// ============================================================================
+
+// requiresReview reports whether the tenant wants a parsed resume reviewed before it is created
+func (s *Service) requiresReview(ctx context.Context, tenantID kernel.TenantID, r *resume.Resume) (bool, error) {
+	settings, err := s.GetParseReviewSettings(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+	return settings.Requires(r), nil
+}
+
+// holdForReview ends a job with its parsed resume stored as a draft. Embeddings are only
+// generated once the draft is approved, since the reviewer may still change it.
+func (s *Service) holdForReview(ctx context.Context, job *resume.ResumeProcessingJob, draft *resume.Resume) error {
+	step := resume.StepReview
+	job.Status = resume.JobStatusAwaitingReview
+	job.CurrentStep = &step
+	job.ProgressPercentage = 90
+	job.Draft = draft
+	job.ErrorMessage = ""
+	job.ErrorDetails = nil
+	job.NextRetryAt = nil
+
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		return s.handleJobError(ctx, job, "draft_save_failed", err)
+	}
+
+	logx.Infof("Job awaiting review: JobID=%s, TenantID=%s", job.ID, job.TenantID)
+	return nil
+}
+
+// getJobAwaitingReview loads a tenant's job and checks that its draft can still be reviewed
+func (s *Service) getJobAwaitingReview(ctx context.Context, jobID kernel.JobID, tenantID kernel.TenantID) (*resume.ResumeProcessingJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, resume.ErrJobNotFound().
+			WithDetail("job_id", jobID)
+	}
+
+	// Verify tenant ownership
+	if job.TenantID != tenantID {
+		return nil, resume.ErrTenantMismatch().
+			WithDetail("job_id", jobID).
+			WithDetail("job_tenant_id", job.TenantID).
+			WithDetail("requested_tenant_id", tenantID)
+	}
+
+	if job.Status != resume.JobStatusAwaitingReview || job.Draft == nil {
+		return nil, errNotAwaitingReview(jobID, job.Status)
+	}
+
+	return job, nil
+}
+
+// errNotAwaitingReview reports a job that was reviewed or never needed review. Status is
+// empty when the job was reviewed concurrently and its new status is unknown.
+func errNotAwaitingReview(jobID kernel.JobID, status resume.JobStatus) error {
+	err := resume.ErrInvalidJobStatus().
+		WithDetail("job_id", jobID).
+		WithDetail("required_status", resume.JobStatusAwaitingReview)
+	if status != "" {
+		err = err.WithDetail("current_status", status)
+	}
+	return err
+}
+
+// UpdateJobDraft applies a reviewer's corrections to a draft awaiting review
+func (s *Service) UpdateJobDraft(ctx context.Context, jobID kernel.JobID, tenantID kernel.TenantID, req resume.UpdateResumeRequest) (*resume.ResumeResponse, error) {
+	job, err := s.getJobAwaitingReview(ctx, jobID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyResumeUpdate(ctx, job.Draft, req)
+	issues := normalizeResume(job.Draft)
+	job.Draft.LastUpdatedAt = time.Now()
+
+	updated, err := s.jobRepo.UpdateAwaitingReview(ctx, job)
+	if err != nil {
+		return nil, resume.ErrJobUpdateFailed().
+			WithDetail("job_id", jobID).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+	if !updated {
+		return nil, errNotAwaitingReview(jobID, "")
+	}
+
+	response := resume.ToResumeResponse(job.Draft)
+	response.NormalizationIssues = issues
+	return response, nil
+}
+
+// ApproveJob creates the resume from a reviewed draft, with its embeddings, and completes
+// the job. The job is claimed and the resume created in one transaction, so a draft
+// approved twice, or approved while being rejected, creates at most one resume.
+func (s *Service) ApproveJob(ctx context.Context, jobID kernel.JobID, tenantID kernel.TenantID, reviewer *kernel.UserID) (*resume.ResumeResponse, error) {
+	job, err := s.getJobAwaitingReview(ctx, jobID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The limit was checked when the job was queued, but other resumes may have been added since
+	count, err := s.repo.CountByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
+			WithDetail("tenant_id", tenantID)
+	}
+	if count >= MaxResumesPerTenant {
+		return nil, resume.ErrMaxResumesExceeded().
+			WithDetail("tenant_id", tenantID).
+			WithDetail("current_count", count).
+			WithDetail("max_allowed", MaxResumesPerTenant)
+	}
+
+	resumeModel := job.Draft
+	resumeModel.NeedsReview = false // A reviewer has checked it
+
+	embeddings, err := s.generateResumeEmbeddings(ctx, resumeModel)
+	if err != nil {
+		return nil, resume.ErrEmbeddingGenerationFailed().
+			WithDetail("job_id", jobID).
+			WithDetail("tenant_id", tenantID).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+	resumeModel.Embeddings = *embeddings
+
+	now := time.Now()
+	step := resume.StepSaving
+	job.Status = resume.JobStatusCompleted
+	job.ResumeID = &resumeModel.ID
+	job.CurrentStep = &step
+	job.ProgressPercentage = 100
+	job.CompletedAt = &now
+	job.ReviewedBy = reviewer
+	job.ReviewedAt = &now
+	job.Draft = nil
+
+	approved, err := s.jobRepo.ApproveDraft(ctx, job, resumeModel)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
+			WithDetail("job_id", jobID).
+			WithDetail("tenant_id", tenantID)
+	}
+	if !approved {
+		return nil, errNotAwaitingReview(jobID, "")
+	}
+
+	logx.Infof("Draft approved: JobID=%s, ResumeID=%s", jobID, resumeModel.ID)
+
+	s.notifyProcessed(resumeModel)
+	return resume.ToResumeResponse(resumeModel), nil
+}
+
+// RejectJob discards a draft awaiting review; no resume is created
+func (s *Service) RejectJob(ctx context.Context, jobID kernel.JobID, tenantID kernel.TenantID, reviewer *kernel.UserID, reason string) (*resume.JobStatusResponse, error) {
+	job, err := s.getJobAwaitingReview(ctx, jobID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job.Status = resume.JobStatusRejected
+	job.ReviewedBy = reviewer
+	job.ReviewedAt = &now
+	job.ReviewNote = reason
+	job.Draft = nil
+
+	rejected, err := s.jobRepo.UpdateAwaitingReview(ctx, job)
+	if err != nil {
+		return nil, resume.ErrJobUpdateFailed().
+			WithDetail("job_id", jobID).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+	if !rejected {
+		return nil, errNotAwaitingReview(jobID, "")
+	}
+
+	logx.Infof("Draft rejected: JobID=%s, TenantID=%s", jobID, tenantID)
+
+	return s.GetJobStatus(ctx, jobID)
+}
+
+// GetParseReviewSettings returns the tenant's parse review settings, or the defaults when none are stored
+func (s *Service) GetParseReviewSettings(ctx context.Context, tenantID kernel.TenantID) (*resume.ParseReviewSettings, error) {
+	settings := resume.DefaultParseReviewSettings()
+	if s.tenantConfig == nil {
+		return &settings, nil
+	}
+
+	config, err := s.tenantConfig.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := config[resume.ParseReviewConfigKey]
+	if !ok || raw == "" {
+		return &settings, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		return nil, resume.ErrInvalidReviewConfig().
+			WithDetail("tenant_id", tenantID).
+			WithDetail("config_key", resume.ParseReviewConfigKey).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SetParseReviewSettings validates and stores the tenant's parse review settings
+func (s *Service) SetParseReviewSettings(ctx context.Context, tenantID kernel.TenantID, settings resume.ParseReviewSettings) (*resume.ParseReviewSettings, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	if s.tenantConfig == nil {
+		return nil, resume.ErrInvalidReviewConfig().
+			WithDetail("reason", "tenant configuration is not available")
+	}
+
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tenantConfig.SaveSetting(ctx, tenantID, resume.ParseReviewConfigKey, string(raw)); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// ResetParseReviewSettings removes the tenant's parse review settings, which turns review off
+func (s *Service) ResetParseReviewSettings(ctx context.Context, tenantID kernel.TenantID) (*resume.ParseReviewSettings, error) {
+	settings := resume.DefaultParseReviewSettings()
+	if s.tenantConfig == nil {
+		return &settings, nil
+	}
+
+	config, err := s.tenantConfig.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := config[resume.ParseReviewConfigKey]; ok {
+		if err := s.tenantConfig.DeleteSetting(ctx, tenantID, resume.ParseReviewConfigKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &settings, nil
+}
@@ -2,14 +2,23 @@ package resumesrv
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Abraxas-365/relay/internal/ai/embeddings"
+	"github.com/Abraxas-365/relay/internal/ai/queryparser"
+	"github.com/Abraxas-365/relay/internal/ai/reranker"
 	"github.com/Abraxas-365/relay/internal/ai/resumeparser"
+	"github.com/Abraxas-365/relay/internal/document"
+	"github.com/Abraxas-365/relay/internal/normalize"
 	"github.com/Abraxas-365/relay/internal/pdf"
 	"github.com/Abraxas-365/relay/pkg/fsx"
+	"github.com/Abraxas-365/relay/pkg/iam/tenant"
 	"github.com/Abraxas-365/relay/pkg/kernel"
 	"github.com/Abraxas-365/relay/pkg/logx"
 	"github.com/Abraxas-365/relay/recruitment/resume"
@@ -18,35 +27,60 @@ import (
 
 const (
 	MaxResumesPerTenant = 20
-	EmbeddingModel      = "text-embedding-3-small"
-	EmbeddingDimension  = 1536
 )
 
 type Service struct {
-	repo       resume.Repository
-	jobRepo    resume.JobRepository
-	parser     *resumeparser.ResumeParser
-	embedGen   *embeddings.EmbeddingsGenerator
-	fileReader fsx.FileReader
-	queue      resume.JobQueue
+	repo         resume.Repository
+	jobRepo      resume.JobRepository
+	parser       *resumeparser.ResumeParser
+	embedders    *embeddings.Registry
+	fileReader   fsx.FileReader
+	queue        resume.JobQueue
+	tenantConfig tenant.TenantConfigRepository
+	migrations   resume.EmbeddingMigrationRepository
+	skills       resume.SkillTaxonomy
+	duplicates   resume.DuplicateRepository
+	queryParser  *queryparser.QueryParser
+	reranker     reranker.Reranker
+	searches     resume.SearchResultCache
+	listeners    []resume.ProcessedListener
+	listenerRuns sync.WaitGroup
+
+	indexSettingsMu        sync.Mutex
+	indexSettings          *resume.VectorIndexSettings
+	indexSettingsExpiresAt time.Time
 }
 
 // NewService creates a new resume service
 func NewService(
 	repo resume.Repository,
 	parser *resumeparser.ResumeParser,
-	embedGen *embeddings.EmbeddingsGenerator,
+	embedders *embeddings.Registry,
 	jobRepo resume.JobRepository,
 	fileReader fsx.FileReader,
 	queue resume.JobQueue,
+	tenantConfig tenant.TenantConfigRepository,
+	migrations resume.EmbeddingMigrationRepository,
+	skills resume.SkillTaxonomy,
+	duplicates resume.DuplicateRepository,
+	queryParser *queryparser.QueryParser,
+	reranker reranker.Reranker,
+	searches resume.SearchResultCache,
 ) *Service {
 	return &Service{
-		repo:       repo,
-		parser:     parser,
-		embedGen:   embedGen,
-		jobRepo:    jobRepo,
-		fileReader: fileReader,
-		queue:      queue,
+		repo:         repo,
+		parser:       parser,
+		embedders:    embedders,
+		jobRepo:      jobRepo,
+		fileReader:   fileReader,
+		queue:        queue,
+		tenantConfig: tenantConfig,
+		migrations:   migrations,
+		skills:       skills,
+		duplicates:   duplicates,
+		queryParser:  queryParser,
+		reranker:     reranker,
+		searches:     searches,
 	}
 }
 
@@ -54,9 +88,23 @@ func NewService(
 // Upload & Parse Resume
 // ============================================================================
 
-// ParseAndCreateResume uploads, parses, and creates a resume with embeddings
+// ParseAndCreateResume uploads, parses, and creates a resume with embeddings. Tenants
+// that review parsed resumes have to parse them as jobs, which hold the draft for review.
 func (s *Service) ParseAndCreateResume(ctx context.Context, req resume.ParseResumeRequest) (*resume.ResumeResponse, error) {
 	logx.Infof("Starting ParseAndCreateResume for TenantID: %s, FilePath: %s", req.TenantID, req.FilePath)
+
+	// Checked before parsing: whether a low-confidence resume needs review is only known
+	// afterwards, and there is no job to hold it
+	review, err := s.GetParseReviewSettings(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Mode != resume.ReviewModeOff {
+		return nil, resume.ErrReviewRequired().
+			WithDetail("tenant_id", req.TenantID).
+			WithDetail("review_mode", review.Mode)
+	}
+
 	// Check if tenant has reached max resumes limit
 	count, err := s.repo.CountByTenantID(ctx, req.TenantID)
 	if err != nil {
@@ -84,29 +132,41 @@ func (s *Service) ParseAndCreateResume(ctx context.Context, req resume.ParseResu
 	logx.Infof("File read successfully for TenantID: %s, FilePath: %s", req.TenantID, req.FilePath)
 	// Parse resume based on file type
 	var parsedData *resumeparser.ResumeData
+	var parseReport *resume.ParseReport
 	switch strings.ToLower(req.FileType) {
 	case "pdf":
-		parsedData, err = s.parsePDFResume(ctx, fileData)
+		parsedData, parseReport, err = s.parsePagedResume(ctx, fileData)
 	case "jpg", "jpeg", "png":
-		parsedData, err = s.parseImageResume(ctx, fileData)
+		parsedData, parseReport, err = s.parseImageResume(ctx, fileData)
+	case "docx", "doc", "odt", "rtf", "txt", "html":
+		parsedData, parseReport, err = s.parseDocumentResume(ctx, strings.ToLower(req.FileType), fileData)
 	default:
 		return nil, resume.ErrInvalidFileFormat().
 			WithDetail("file_type", req.FileType).
-			WithDetail("supported_formats", []string{"pdf", "jpg", "jpeg", "png"})
+			WithDetail("supported_formats", resume.SupportedFileTypes)
 	}
 
 	if err != nil {
+		details := map[string]interface{}{
+			"tenant_id": req.TenantID,
+			"error":     err.Error(),
+		}
+		var parseErr *resumeparser.ParseError
+		if errors.As(err, &parseErr) {
+			details["failure_reason"] = parseErr.Reason
+			details["transient"] = parseErr.Transient()
+		}
 		return nil, resume.ErrResumeParseFailed().
 			WithDetail("file_path", req.FilePath).
 			WithDetail("file_type", req.FileType).
-			WithDetails(map[string]interface{}{
-				"tenant_id": req.TenantID,
-				"error":     err.Error(),
-			})
+			WithDetails(details)
 	}
 
 	// Convert parsed data to domain model
 	resumeModel := s.convertParsedDataToDomain(parsedData, req)
+	attachParseReport(resumeModel, parseReport, parsedData)
+	normalizeResume(resumeModel)
+	s.canonicalizeSkills(ctx, resumeModel)
 
 	logx.Infof("Resume parsed successfully for TenantID: %s, FilePath: %s", req.TenantID, req.FilePath)
 	logx.Infof("Resume Title: %s, Parsed Name: %s, Parsed Email: %s", resumeModel.Title, resumeModel.PersonalInfo.FullName, resumeModel.PersonalInfo.Email)
@@ -141,42 +201,123 @@ func (s *Service) ParseAndCreateResume(ctx context.Context, req resume.ParseResu
 	return resume.ToResumeResponse(resumeModel), nil
 }
 
-// parsePDFResume converts PDF to images and parses
-func (s *Service) parsePDFResume(ctx context.Context, pdfData []byte) (*resumeparser.ResumeData, error) {
-	// Convert PDF pages to images
-	images, err := pdf.ConvertPDFToImages(pdfData)
+// parsePagedResume reads the pages of a PDF, or of a document MuPDF lays out into
+// pages such as DOCX, from their text layer and renders only the pages without a
+// usable one, such as scanned pages, for the vision model
+func (s *Service) parsePagedResume(ctx context.Context, fileData []byte) (*resumeparser.ResumeData, *resume.ParseReport, error) {
+	pages, err := pdf.ExtractPages(fileData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read pages: %w", err)
+	}
+
+	if len(pages) == 0 {
+		return nil, nil, fmt.Errorf("document contains no pages")
+	}
+
+	contents := make([]resumeparser.PageContent, len(pages))
+	images := [][]byte{}
+	visionPages := []int{}
+	for i, page := range pages {
+		contents[i] = resumeparser.PageContent{Text: page.Text, Image: page.Image}
+		if page.IsScanned() {
+			images = append(images, page.Image)
+			visionPages = append(visionPages, page.Number)
+		}
+	}
+	report := resume.NewParseReport(len(pages), visionPages)
+
+	var parsed *resumeparser.ResumeData
+	switch {
+	case report.Method == resume.ParseMethodVision && len(images) > 1:
+		parsed, err = s.parser.ParseResumeFromMultiplePages(ctx, images)
+	case report.Method == resume.ParseMethodVision:
+		parsed, err = s.parser.ParseResumeFromImage(ctx, images[0])
+	default:
+		parsed, err = s.parser.ParseResumeFromPages(ctx, contents)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logx.Infof("Pages parsed from %s (%d pages, vision pages: %v)", report.Method, report.Pages, report.VisionPages)
+	return parsed, report, nil
+}
+
+// attachParseReport records how the file was parsed, with the parser's confidence in
+// each field, and flags the resume for review when the parser was unsure of any or
+// gave no confidence for a value it extracted
+func attachParseReport(resumeModel *resume.Resume, report *resume.ParseReport, parsed *resumeparser.ResumeData) {
+	if report != nil {
+		fields := make(map[string]resume.FieldSource, len(parsed.Fields))
+		for path, source := range parsed.Fields {
+			fields[path] = resume.FieldSource{Confidence: source.Confidence, Page: source.Page}
+		}
+		// A value the parser did not say how sure it was of is not trusted either
+		for _, path := range parsed.UnreportedFields() {
+			fields[path] = resume.FieldSource{Confidence: 0}
+		}
+		report.SetFields(fields)
+	}
+
+	resumeModel.ParseReport = report
+	resumeModel.NeedsReview = report.NeedsReview()
+	if resumeModel.NeedsReview {
+		logx.Infof("Resume %s needs review, low confidence in: %v", resumeModel.ID, report.LowConfidence)
+	}
+}
+
+// parseDocumentResume parses word-processor, rich text, plain text and HTML resumes
+// from their extracted text. Layout-heavy DOCX templates, and DOCX files whose text
+// sits where extraction cannot reach, are laid out into pages instead.
+func (s *Service) parseDocumentResume(ctx context.Context, fileType string, fileData []byte) (*resumeparser.ResumeData, *resume.ParseReport, error) {
+	fileType = document.DetectType(fileType, fileData)
+
+	text, err := document.ExtractText(fileType, fileData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert PDF: %w", err)
+		return nil, nil, fmt.Errorf("failed to read %s: %w", fileType, err)
 	}
 
-	if len(images) == 0 {
-		return nil, fmt.Errorf("PDF contains no pages")
+	usable := pdf.HasUsableText(text)
+	if document.CanRender(fileType) && (!usable || document.HasComplexLayout(fileType, fileData)) {
+		parsed, report, err := s.parsePagedResume(ctx, fileData)
+		if err == nil || !usable {
+			return parsed, report, err
+		}
+		logx.Warnf("Failed to lay out %s resume, parsing extracted text instead: %v", fileType, err)
+	}
+
+	if !usable {
+		return nil, nil, fmt.Errorf("%s contains no readable text", fileType)
 	}
 
-	// Parse using multi-page or single-page parser
-	if len(images) > 1 {
-		return s.parser.ParseResumeFromMultiplePages(ctx, images)
+	parsed, err := s.parser.ParseResumeFromPages(ctx, []resumeparser.PageContent{{Text: text}})
+	if err != nil {
+		return nil, nil, err
 	}
-	return s.parser.ParseResumeFromImage(ctx, images[0])
+	return parsed, resume.NewParseReport(1, nil), nil
 }
 
 // parseImageResume parses a single image resume
-func (s *Service) parseImageResume(ctx context.Context, imageData []byte) (*resumeparser.ResumeData, error) {
+func (s *Service) parseImageResume(ctx context.Context, imageData []byte) (*resumeparser.ResumeData, *resume.ParseReport, error) {
 	// Detect and convert image format if needed
 	format, err := pdf.DetectImageFormat(imageData)
 	if err != nil {
-		return nil, fmt.Errorf("invalid image format: %w", err)
+		return nil, nil, fmt.Errorf("invalid image format: %w", err)
 	}
 
 	// Convert to JPEG if not already
 	if format != "jpeg" && format != "jpg" {
 		imageData, err = pdf.ConvertImageToJPEG(imageData)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert image: %w", err)
+			return nil, nil, fmt.Errorf("failed to convert image: %w", err)
 		}
 	}
 
-	return s.parser.ParseResumeFromImage(ctx, imageData)
+	parsed, err := s.parser.ParseResumeFromImage(ctx, imageData)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parsed, resume.NewParseReport(1, []int{1}), nil
 }
 
 // ============================================================================
@@ -225,6 +366,8 @@ func (s *Service) CreateResume(ctx context.Context, req resume.CreateResumeReque
 	if req.PersonalStatement != nil {
 		resumeModel.PersonalStatement = *req.PersonalStatement
 	}
+	issues := normalizeResume(resumeModel)
+	s.canonicalizeSkills(ctx, resumeModel)
 
 	// Validate completeness
 	if !resumeModel.IsComplete() {
@@ -265,7 +408,9 @@ func (s *Service) CreateResume(ctx context.Context, req resume.CreateResumeReque
 			WithDetail("title", req.Title)
 	}
 
-	return resume.ToResumeResponse(resumeModel), nil
+	response := resume.ToResumeResponse(resumeModel)
+	response.NormalizationIssues = issues
+	return response, nil
 }
 
 // GetResume retrieves a resume by ID
@@ -289,217 +434,826 @@ func (s *Service) UpdateResume(ctx context.Context, id kernel.ResumeID, req resu
 	}
 
 	// Apply updates
+	needsEmbeddingUpdate := s.applyResumeUpdate(ctx, existing, req)
+	issues := normalizeResume(existing)
+
+	existing.Version++
+	existing.LastUpdatedAt = time.Now()
+
+	// Regenerate embeddings if content changed
+	if needsEmbeddingUpdate {
+		embeddings, err := s.generateResumeEmbeddings(ctx, existing)
+		if err != nil {
+			return nil, resume.ErrEmbeddingGenerationFailed().
+				WithDetail("resume_id", id).
+				WithDetail("tenant_id", existing.TenantID).
+				WithDetails(map[string]interface{}{
+					"error": err.Error(),
+				})
+		}
+		existing.Embeddings = *embeddings
+	}
+
+	// Update
+	if err := s.repo.Update(ctx, id, existing); err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
+			WithDetail("resume_id", id).
+			WithDetail("tenant_id", existing.TenantID)
+	}
+
+	response := resume.ToResumeResponse(existing)
+	response.NormalizationIssues = issues
+	return response, nil
+}
+
+// applyResumeUpdate copies the fields set in an update onto a resume and reports
+// whether its embeddings have to be regenerated
+func (s *Service) applyResumeUpdate(ctx context.Context, r *resume.Resume, req resume.UpdateResumeRequest) bool {
 	needsEmbeddingUpdate := false
 	if req.Title != nil {
-		existing.Title = *req.Title
+		r.Title = *req.Title
 	}
 	if req.PersonalInfo != nil {
-		existing.PersonalInfo = *req.PersonalInfo
+		r.PersonalInfo = *req.PersonalInfo
 	}
 	if req.WorkExperience != nil {
-		existing.WorkExperience = *req.WorkExperience
+		r.WorkExperience = *req.WorkExperience
 		needsEmbeddingUpdate = true
 	}
 	if req.Education != nil {
-		existing.Education = *req.Education
+		r.Education = *req.Education
 		needsEmbeddingUpdate = true
 	}
 	if req.Skills != nil {
-		existing.Skills = *req.Skills
+		r.Skills = *req.Skills
 		needsEmbeddingUpdate = true
 	}
 	if req.Languages != nil {
-		existing.Languages = *req.Languages
+		r.Languages = *req.Languages
 		needsEmbeddingUpdate = true
 	}
 	if req.Certifications != nil {
-		existing.Certifications = *req.Certifications
+		r.Certifications = *req.Certifications
 	}
 	if req.Projects != nil {
-		existing.Projects = *req.Projects
+		r.Projects = *req.Projects
+		needsEmbeddingUpdate = true
 	}
 	if req.Achievements != nil {
-		existing.Achievements = *req.Achievements
+		r.Achievements = *req.Achievements
 	}
 	if req.VolunteerWork != nil {
-		existing.VolunteerWork = *req.VolunteerWork
+		r.VolunteerWork = *req.VolunteerWork
 	}
 	if req.ProfessionalSummary != nil {
-		existing.ProfessionalSummary = *req.ProfessionalSummary
+		r.ProfessionalSummary = *req.ProfessionalSummary
 	}
 	if req.PersonalStatement != nil {
-		existing.PersonalStatement = *req.PersonalStatement
+		r.PersonalStatement = *req.PersonalStatement
 		needsEmbeddingUpdate = true
 	}
+	if req.NeedsReview != nil {
+		r.NeedsReview = *req.NeedsReview
+	}
+
+	if req.Skills != nil || req.WorkExperience != nil {
+		s.canonicalizeSkills(ctx, r)
+	}
+
+	return needsEmbeddingUpdate
+}
+
+// DeleteResume deletes a resume
+func (s *Service) DeleteResume(ctx context.Context, id kernel.ResumeID) error {
+	// Get resume to check if it's default
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return resume.ErrResumeNotFound().
+			WithDetail("resume_id", id)
+	}
+
+	// Check if there are other resumes
+	count, err := s.repo.CountByTenantID(ctx, existing.TenantID)
+	if err != nil {
+		return resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
+			WithDetail("tenant_id", existing.TenantID)
+	}
+
+	// If this is the default and there are other resumes, prevent deletion
+	if existing.IsDefault && count > 1 {
+		return resume.ErrDefaultResumeRequired().
+			WithDetail("resume_id", id).
+			WithDetail("tenant_id", existing.TenantID).
+			WithDetail("message", "set another resume as default before deleting")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
+			WithDetail("resume_id", id)
+	}
+
+	return nil
+}
+
+// ============================================================================
+// List & Search Operations
+// ============================================================================
+
+// ListResumes lists all resumes for a tenant
+func (s *Service) ListResumes(ctx context.Context, req resume.ListResumesRequest) (*resume.ListResumesResponse, error) {
+	if err := validateFacets(req.Facets); err != nil {
+		return nil, err
+	}
+	if err := validatePagination(req.Pagination); err != nil {
+		return nil, err
+	}
+
+	facets, err := s.searchFacets(ctx, resume.SearchResumesRequest{
+		TenantID:   &req.TenantID,
+		OnlyActive: req.OnlyActive,
+		Facets:     req.Facets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get paginated results
+	filter := resume.ResumeListFilter{
+		OnlyActive:  req.OnlyActive,
+		NeedsReview: req.NeedsReview,
+	}
+	paginated, err := s.repo.ListByTenantIDWithPagination(ctx, req.TenantID, filter, req.Pagination)
+	if err != nil {
+		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
+			WithDetail("tenant_id", req.TenantID)
+	}
+
+	if req.OnlyActive {
+		// Every listed resume is active, so the total is the active count
+		var defaultResumeID *kernel.ResumeID
+		if def, err := s.repo.GetDefaultByTenantID(ctx, req.TenantID); err == nil && def.IsActive &&
+			(req.NeedsReview == nil || def.NeedsReview == *req.NeedsReview) {
+			defaultResumeID = &def.ID
+		}
+
+		response := resume.ToListResumesResponse(
+			kernel.MapPaginated(*paginated, func(r resume.Resume) *resume.Resume {
+				return &r
+			}),
+			paginated.Page.Total,
+			0,
+			defaultResumeID,
+		)
+		response.Facets = facets
+
+		return response, nil
+	}
+
+	// Calculate counts
+	activeCount := 0
+	inactiveCount := 0
+	var defaultResumeID *kernel.ResumeID
+
+	for _, r := range paginated.Items {
+		if r.IsActive {
+			activeCount++
+		} else {
+			inactiveCount++
+		}
+		if r.IsDefault {
+			defaultResumeID = &r.ID
+		}
+	}
+
+	response := resume.ToListResumesResponse(
+		kernel.MapPaginated(*paginated, func(r resume.Resume) *resume.Resume {
+			return &r
+		}),
+		activeCount,
+		inactiveCount,
+		defaultResumeID,
+	)
+	response.Facets = facets
+
+	return response, nil
+}
+
+// SearchResumes performs semantic search on resumes
+func (s *Service) SearchResumes(ctx context.Context, req resume.SearchResumesRequest) (*resume.SearchResumesResponse, error) {
+	startTime := time.Now()
+
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		return nil, resume.ErrInvalidSearchRequest().
+			WithDetail("field", "query").
+			WithDetail("reason", "search query is required")
+	}
+	if req.Mode == "" {
+		req.Mode = resume.SearchModeSemantic
+	}
+
+	// Pages after the first are cut from the search their cursor was issued by
+	if token := searchToken(req.Pagination); token != "" {
+		return s.searchPage(ctx, token, req.TenantID, nil, req.Pagination, startTime)
+	}
+
+	// The reranker reads the query as the recruiter wrote it, before interpretation
+	originalQuery := req.Query
+
+	// Filters understood from the query complement the explicit ones
+	var interpretation *resume.QueryInterpretation
+	if req.Interpret {
+		interpretation = s.interpretQuery(ctx, req.TenantID, req.Query)
+		interpretation.ApplyTo(&req)
+	}
+
+	if err := validateSearchFilters(req); err != nil {
+		return nil, err
+	}
+
+	expansions, err := s.expandSkills(ctx, req.TenantID, req)
+	if err != nil {
+		return nil, err
+	}
+	req.SkillExpansions = expansions
+
+	weights, err := s.resolveSearchWeights(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	req.Weights = &weights
+
+	// Embed the query in the same space as the stored section vectors
+	var queryEmbedding []float32
+	if req.Mode != resume.SearchModeKeyword {
+		embedder, err := s.embedderFor(ctx, req.TenantID, req.EmbeddingModel)
+		if err != nil {
+			return nil, err
+		}
+
+		queryEmbedding, err = embedder.GenerateEmbedding(ctx, req.Query)
+		if err != nil {
+			return nil, resume.ErrEmbeddingGenerationFailed().
+				WithDetail("query", req.Query).
+				WithDetails(map[string]any{
+					"error": err.Error(),
+				})
+		}
+
+		if len(queryEmbedding) != embedder.Dimension() {
+			return nil, resume.ErrEmbeddingDimMismatch().
+				WithDetail("model", embedder.Model()).
+				WithDetail("expected", embedder.Dimension()).
+				WithDetail("actual", len(queryEmbedding))
+		}
+
+		// Only compare against vectors produced by the same model
+		req.EmbeddingModel = embedder.Model()
+		req.EmbeddingDim = embedder.Dimension()
+
+		if err := s.applyVectorIndexSettings(ctx, &req); err != nil {
+			return nil, err
+		}
+	}
+
+	// Search
+	matches, err := s.runSearch(ctx, queryEmbedding, req)
+	if err != nil {
+		return nil, resume.ErrSearchFailed().
+			WithDetail("query", req.Query).
+			WithDetails(map[string]interface{}{
+				"error":   err.Error(),
+				"top_k":   req.TopK,
+				"mode":    req.Mode,
+				"filters": req,
+			})
+	}
+
+	matches, rerank := s.rerankResults(ctx, req, originalQuery, matches)
+
+	facets, err := s.searchFacets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build filters
+	filters := resume.SearchFilters{
+		MinYearsExperience: req.MinYearsExperience,
+		MaxYearsExperience: req.MaxYearsExperience,
+		RequiredSkills:     req.RequiredSkills,
+		PreferredSkills:    req.PreferredSkills,
+		Locations:          req.Locations,
+		EducationLevel:     req.EducationLevel,
+		Languages:          req.Languages,
+		Industries:         req.Industries,
+		OnlyActive:         req.OnlyActive,
+	}
+
+	response := resume.ToSearchResumesResponse(
+		kernel.Paginated[resume.ResumeMatchResult]{},
+		req.Query,
+		filters,
+		"",
+	)
+	response.Mode = req.Mode
+	response.AppliedWeights = weights
+	response.Interpretation = interpretation
+	response.Rerank = rerank
+	response.Facets = facets
+
+	// Pages are cut from the ranked hits so they follow the final order; the response
+	// must be complete here, it is kept as is for the cursor pages
+	if req.Pagination.PageSize == 0 {
+		req.Pagination.PageSize = req.TopK
+	}
+	if err := s.paginateSearch(ctx, response, req.TenantID, matches, req.Pagination, !req.FirstPageOnly); err != nil {
+		return nil, err
+	}
+	response.ExecutionTime = time.Since(startTime).String()
+
+	return response, nil
+}
+
+// FindSimilarResumes returns the resumes most similar to an existing one.
+// It reuses the stored section embeddings, so no embedding provider call is made.
+func (s *Service) FindSimilarResumes(ctx context.Context, id kernel.ResumeID, req resume.SearchResumesRequest) (*resume.SearchResumesResponse, error) {
+	startTime := time.Now()
+
+	if token := searchToken(req.Pagination); token != "" {
+		return s.searchPage(ctx, token, req.TenantID, &id, req.Pagination, startTime)
+	}
+
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, resume.ErrResumeNotFound().
+			WithDetail("resume_id", id)
+	}
+
+	if req.TenantID != nil && source.TenantID != *req.TenantID {
+		return nil, resume.ErrTenantMismatch().
+			WithDetail("resume_id", id)
+	}
+
+	if !source.HasEmbeddings() {
+		return nil, resume.ErrInvalidSearchRequest().
+			WithDetail("resume_id", id).
+			WithDetail("reason", "resume has no embeddings; update its embeddings first")
+	}
+
+	req.Mode = resume.SearchModeSemantic
+	if err := validateSearchFilters(req); err != nil {
+		return nil, err
+	}
+
+	expansions, err := s.expandSkills(ctx, &source.TenantID, req)
+	if err != nil {
+		return nil, err
+	}
+	req.SkillExpansions = expansions
+
+	weights, err := s.resolveSearchWeights(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	req.Weights = &weights
+
+	// Compare the vectors of the tenant's active model unless another one is requested
+	if req.EmbeddingModel == "" {
+		req.EmbeddingModel, err = s.activeModel(ctx, source.TenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if embedder, ok := s.embedders.Get(req.EmbeddingModel); ok {
+		req.EmbeddingDim = embedder.Dimension()
+	}
+	if err := s.applyVectorIndexSettings(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	matches, err := s.repo.FindSimilar(ctx, id, req)
+	if err != nil {
+		return nil, resume.ErrSearchFailed().
+			WithDetail("resume_id", id).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+				"top_k": req.TopK,
+			})
+	}
+
+	facets, err := s.searchFacets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := resume.SearchFilters{
+		MinYearsExperience: req.MinYearsExperience,
+		MaxYearsExperience: req.MaxYearsExperience,
+		RequiredSkills:     req.RequiredSkills,
+		PreferredSkills:    req.PreferredSkills,
+		Locations:          req.Locations,
+		EducationLevel:     req.EducationLevel,
+		Languages:          req.Languages,
+		Industries:         req.Industries,
+		OnlyActive:         req.OnlyActive,
+	}
+
+	response := resume.ToSearchResumesResponse(
+		kernel.Paginated[resume.ResumeMatchResult]{},
+		"",
+		filters,
+		"",
+	)
+	response.Mode = req.Mode
+	response.SimilarTo = &id
+	response.AppliedWeights = weights
+	response.Facets = facets
+
+	if req.Pagination.PageSize == 0 {
+		req.Pagination.PageSize = req.TopK
+	}
+	if err := s.paginateSearch(ctx, response, &source.TenantID, matches, req.Pagination, !req.FirstPageOnly); err != nil {
+		return nil, err
+	}
+	response.ExecutionTime = time.Since(startTime).String()
+
+	return response, nil
+}
+
+// paginateSearch sets the requested page of ranked hits on a search response and, when
+// keep is set, keeps the hits for the cursors of the other pages. When they are not kept
+// the cursors carry no token and the next pages run the search again.
+func (s *Service) paginateSearch(ctx context.Context, response *resume.SearchResumesResponse, tenantID *kernel.TenantID, matches []resume.ResumeMatchResult, pagination kernel.PaginationOptions, keep bool) error {
+	if keep {
+		token := uuid.NewString()
+		err := s.searches.Save(ctx, token, &resume.SearchSnapshot{
+			TenantID: tenantID,
+			Matches:  matches,
+			PageSize: pagination.PageSize,
+			Response: *response,
+		})
+		if err != nil {
+			logx.Warnf("Failed to keep search results for paging: %v", err)
+			token = ""
+		}
+		pagination.Token = token
+	}
+
+	results, err := resume.PaginateMatches(matches, pagination)
+	if err != nil {
+		return resume.ErrInvalidPagination().
+			WithDetail("cursor", pagination.Cursor)
+	}
+	response.Results = results
+	return nil
+}
+
+// searchToken returns the kept search a cursor points into, if any
+func searchToken(pagination kernel.PaginationOptions) string {
+	cursor, err := pagination.DecodeCursor()
+	if err != nil || cursor == nil {
+		return ""
+	}
+	return cursor.Token
+}
+
+// searchPage cuts a page out of a kept search. Searches of another tenant or resume
+// are treated as expired.
+func (s *Service) searchPage(ctx context.Context, token string, tenantID *kernel.TenantID, similarTo *kernel.ResumeID, pagination kernel.PaginationOptions, startTime time.Time) (*resume.SearchResumesResponse, error) {
+	snapshot, err := s.searches.Get(ctx, token)
+	if err != nil {
+		return nil, resume.ErrSearchFailed().
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+
+	sameTenant := func(a, b *kernel.TenantID) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+	}
+	sameSource := func(a, b *kernel.ResumeID) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+	}
+	if snapshot == nil || !sameTenant(snapshot.TenantID, tenantID) || !sameSource(snapshot.Response.SimilarTo, similarTo) {
+		return nil, resume.ErrSearchExpired().
+			WithDetail("cursor", pagination.Cursor)
+	}
+
+	if pagination.PageSize == 0 {
+		pagination.PageSize = snapshot.PageSize
+	}
+	pagination.Token = token
+
+	results, err := resume.PaginateMatches(snapshot.Matches, pagination)
+	if err != nil {
+		return nil, resume.ErrInvalidPagination().
+			WithDetail("cursor", pagination.Cursor)
+	}
+
+	response := snapshot.Response
+	response.Results = results
+	response.ExecutionTime = time.Since(startTime).String()
+	return &response, nil
+}
+
+// runSearch retrieves matches according to the search mode
+func (s *Service) runSearch(ctx context.Context, queryEmbedding []float32, req resume.SearchResumesRequest) ([]resume.ResumeMatchResult, error) {
+	switch req.Mode {
+	case resume.SearchModeKeyword:
+		return s.repo.KeywordSearch(ctx, req)
+
+	case resume.SearchModeHybrid:
+		// Each ranking contributes a wider pool so fusion can promote hits found by only one of them
+		pool := req
+		pool.TopK = resume.HybridCandidatePool(req.TopK)
+
+		semantic, err := s.repo.SemanticSearch(ctx, queryEmbedding, pool)
+		if err != nil {
+			return nil, err
+		}
+
+		keyword, err := s.repo.KeywordSearch(ctx, pool)
+		if err != nil {
+			return nil, err
+		}
+
+		return resume.FuseReciprocalRank(semantic, keyword, req.TopK), nil
+
+	default:
+		return s.repo.SemanticSearch(ctx, queryEmbedding, req)
+	}
+}
+
+// searchFacets counts the resumes matching the search filters by the requested facets
+func (s *Service) searchFacets(ctx context.Context, req resume.SearchResumesRequest) (resume.SearchFacets, error) {
+	if len(req.Facets) == 0 {
+		return nil, nil
+	}
+
+	facets, err := s.repo.Facets(ctx, req)
+	if err != nil {
+		return nil, resume.ErrSearchFailed().
+			WithDetail("facets", req.Facets).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+	return facets, nil
+}
+
+// expandSkills resolves the required and preferred skills of a search through the skill taxonomy
+func (s *Service) expandSkills(ctx context.Context, tenantID *kernel.TenantID, req resume.SearchResumesRequest) (resume.SkillExpansions, error) {
+	names := append(append([]string{}, req.RequiredSkills...), req.PreferredSkills...)
+	if s.skills == nil || len(names) == 0 {
+		return nil, nil
+	}
+
+	expansions, err := s.skills.Expand(ctx, tenantID, names)
+	if err != nil {
+		return nil, resume.ErrSearchFailed().
+			WithDetail("reason", "failed to resolve skills").
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+	return expansions, nil
+}
+
+// canonicalizeSkills renames the resume's skills to their canonical taxonomy names.
+// The taxonomy only improves matching, so a failure keeps the skills as written.
+func (s *Service) canonicalizeSkills(ctx context.Context, r *resume.Resume) {
+	if s.skills == nil {
+		return
+	}
+
+	canonical, err := s.skills.Canonicalize(ctx, r.TenantID, r.AllSkillNames())
+	if err != nil {
+		logx.Warnf("Failed to canonicalize skills for resume %s: %v", r.ID, err)
+		return
+	}
+	r.CanonicalizeSkills(canonical)
+}
+
+// activeModel returns the tenant's active embedding model, or the default model
+func (s *Service) activeModel(ctx context.Context, tenantID kernel.TenantID) (string, error) {
+	if s.migrations != nil {
+		model, err := s.migrations.GetActiveModel(ctx, tenantID)
+		if err != nil {
+			return "", resume.ErrRegistry.NewWithCause(resume.CodeMigrationFailed, err).
+				WithDetail("tenant_id", tenantID).
+				WithDetail("operation", "get_active_model")
+		}
+		if model != "" {
+			return model, nil
+		}
+	}
 
-	existing.Version++
-	existing.LastUpdatedAt = time.Now()
+	if embedder := s.embedders.Default(); embedder != nil {
+		return embedder.Model(), nil
+	}
+	return "", nil
+}
 
-	// Regenerate embeddings if content changed
-	if needsEmbeddingUpdate {
-		embeddings, err := s.generateResumeEmbeddings(ctx, existing)
+// embedderFor returns the embedder for model, or for the tenant's active model when model is empty
+func (s *Service) embedderFor(ctx context.Context, tenantID *kernel.TenantID, model string) (embeddings.Embedder, error) {
+	if model == "" && tenantID != nil {
+		active, err := s.activeModel(ctx, *tenantID)
 		if err != nil {
-			return nil, resume.ErrEmbeddingGenerationFailed().
-				WithDetail("resume_id", id).
-				WithDetail("tenant_id", existing.TenantID).
-				WithDetails(map[string]interface{}{
-					"error": err.Error(),
-				})
+			return nil, err
 		}
-		existing.Embeddings = *embeddings
+		model = active
 	}
 
-	// Update
-	if err := s.repo.Update(ctx, id, existing); err != nil {
-		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
-			WithDetail("resume_id", id).
-			WithDetail("tenant_id", existing.TenantID)
+	if model == "" {
+		if embedder := s.embedders.Default(); embedder != nil {
+			return embedder, nil
+		}
+		return nil, resume.ErrEmbeddingGenerationFailed().
+			WithDetail("reason", "embeddings provider is not configured")
 	}
 
-	return resume.ToResumeResponse(existing), nil
+	embedder, ok := s.embedders.Get(model)
+	if !ok {
+		return nil, resume.ErrEmbeddingModelUnavailable().
+			WithDetail("model", model).
+			WithDetail("available", s.embedders.Models())
+	}
+	return embedder, nil
 }
 
-// DeleteResume deletes a resume
-func (s *Service) DeleteResume(ctx context.Context, id kernel.ResumeID) error {
-	// Get resume to check if it's default
-	existing, err := s.repo.GetByID(ctx, id)
+// vectorIndexSettingsTTL is how long the vector index settings are reused before
+// being read again; they only change through configure_resume_vector_indexes
+const vectorIndexSettingsTTL = time.Minute
+
+// vectorIndexSettings returns the HNSW parameters and vector storage in use
+func (s *Service) vectorIndexSettings(ctx context.Context) (resume.VectorIndexSettings, error) {
+	if s.migrations == nil {
+		return resume.DefaultVectorIndexSettings(), nil
+	}
+
+	s.indexSettingsMu.Lock()
+	defer s.indexSettingsMu.Unlock()
+
+	if s.indexSettings != nil && time.Now().Before(s.indexSettingsExpiresAt) {
+		return *s.indexSettings, nil
+	}
+
+	settings, err := s.migrations.GetVectorIndexSettings(ctx)
 	if err != nil {
-		return resume.ErrResumeNotFound().
-			WithDetail("resume_id", id)
+		return resume.VectorIndexSettings{}, resume.ErrRegistry.NewWithCause(resume.CodeSearchFailed, err).
+			WithDetail("operation", "get_vector_index_settings")
 	}
+	s.indexSettings = settings
+	s.indexSettingsExpiresAt = time.Now().Add(vectorIndexSettingsTTL)
+	return *settings, nil
+}
 
-	// Check if there are other resumes
-	count, err := s.repo.CountByTenantID(ctx, existing.TenantID)
+// applyVectorIndexSettings sets the vector storage to query and the HNSW candidate
+// list size, which defaults to the configured one. The repository only changes the
+// list size of a query when it differs from the pgvector default.
+func (s *Service) applyVectorIndexSettings(ctx context.Context, req *resume.SearchResumesRequest) error {
+	settings, err := s.vectorIndexSettings(ctx)
 	if err != nil {
-		return resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
-			WithDetail("tenant_id", existing.TenantID)
+		return err
 	}
 
-	// If this is the default and there are other resumes, prevent deletion
-	if existing.IsDefault && count > 1 {
-		return resume.ErrDefaultResumeRequired().
-			WithDetail("resume_id", id).
-			WithDetail("tenant_id", existing.TenantID).
-			WithDetail("message", "set another resume as default before deleting")
+	req.VectorStorage = settings.Storage
+	if req.EfSearch == nil {
+		efSearch := settings.EfSearch
+		req.EfSearch = &efSearch
 	}
+	return nil
+}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
-			WithDetail("resume_id", id)
+// resolveSearchWeights picks the request weights, then the tenant default, then the global default
+func (s *Service) resolveSearchWeights(ctx context.Context, req resume.SearchResumesRequest) (resume.SectionWeights, error) {
+	if req.Weights != nil {
+		if err := req.Weights.Validate(); err != nil {
+			return resume.SectionWeights{}, err
+		}
+		return *req.Weights, nil
 	}
 
-	return nil
+	if req.TenantID == nil {
+		return resume.DefaultSectionWeights(), nil
+	}
+
+	weights, err := s.GetSearchWeights(ctx, *req.TenantID)
+	if err != nil {
+		// A broken tenant setting should not take search down
+		logx.Warnf("Using default search weights for tenant %s: %v", req.TenantID, err)
+		return resume.DefaultSectionWeights(), nil
+	}
+
+	return *weights, nil
 }
 
-// ============================================================================
-// List & Search Operations
-// ============================================================================
+// ValidateSearchRequest checks a search request without running it, e.g. before it is saved
+func (s *Service) ValidateSearchRequest(req resume.SearchResumesRequest) error {
+	if strings.TrimSpace(req.Query) == "" {
+		return resume.ErrInvalidSearchRequest().
+			WithDetail("field", "query").
+			WithDetail("reason", "search query is required")
+	}
+	if req.Mode == "" {
+		req.Mode = resume.SearchModeSemantic
+	}
+	return validateSearchFilters(req)
+}
 
-// ListResumes lists all resumes for a tenant
-func (s *Service) ListResumes(ctx context.Context, req resume.ListResumesRequest) (*resume.ListResumesResponse, error) {
-	if req.OnlyActive {
-		resumes, err := s.repo.GetActiveByTenantID(ctx, req.TenantID)
-		if err != nil {
-			return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
-				WithDetail("tenant_id", req.TenantID)
-		}
+// validateSearchFilters rejects filters the repository could not apply faithfully
+func validateSearchFilters(req resume.SearchResumesRequest) error {
+	if req.TopK < 1 || req.TopK > 100 {
+		return resume.ErrInvalidSearchRequest().
+			WithDetail("field", "top_k").
+			WithDetail("value", req.TopK).
+			WithDetail("reason", "top_k must be between 1 and 100")
+	}
 
-		// Calculate stats
-		activeCount := len(resumes)
-		var defaultResumeID *kernel.ResumeID
+	if !req.Mode.IsValid() {
+		return resume.ErrInvalidSearchRequest().
+			WithDetail("field", "mode").
+			WithDetail("value", req.Mode).
+			WithDetail("reason", "mode must be semantic, keyword or hybrid")
+	}
 
-		for _, r := range resumes {
-			if r.IsDefault {
-				defaultResumeID = &r.ID
-				break
-			}
-		}
+	if req.EfSearch != nil && (*req.EfSearch < 1 || *req.EfSearch > resume.MaxEfSearch) {
+		return resume.ErrInvalidSearchRequest().
+			WithDetail("field", "ef_search").
+			WithDetail("value", *req.EfSearch).
+			WithDetail("reason", fmt.Sprintf("ef_search must be between 1 and %d", resume.MaxEfSearch))
+	}
 
-		return resume.ToListResumesResponse(
-			resumes,
-			req.Pagination.Page,
-			req.Pagination.PageSize,
-			len(resumes),
-			activeCount,
-			0,
-			defaultResumeID,
-		), nil
+	if req.EducationLevel != nil && *req.EducationLevel != "" &&
+		resume.EducationLevelRank(*req.EducationLevel) == resume.EducationUnknown {
+		return resume.ErrInvalidSearchRequest().
+			WithDetail("field", "education_level").
+			WithDetail("value", *req.EducationLevel).
+			WithDetail("reason", "unknown education level; use high_school, associate, bachelor, master or phd")
 	}
 
-	// Get paginated results
-	paginated, err := s.repo.ListByTenantIDWithPagination(ctx, req.TenantID, req.Pagination)
-	if err != nil {
-		return nil, resume.ErrRegistry.NewWithCause(resume.CodeResumeNotFound, err).
-			WithDetail("tenant_id", req.TenantID)
+	if err := validateFacets(req.Facets); err != nil {
+		return err
 	}
 
-	// Calculate counts
-	activeCount := 0
-	inactiveCount := 0
-	var defaultResumeID *kernel.ResumeID
+	if err := validatePagination(req.Pagination); err != nil {
+		return err
+	}
 
-	for _, r := range paginated.Items {
-		if r.IsActive {
-			activeCount++
-		} else {
-			inactiveCount++
+	for _, lang := range req.Languages {
+		if strings.TrimSpace(lang.Language) == "" {
+			return resume.ErrInvalidSearchRequest().
+				WithDetail("field", "languages").
+				WithDetail("reason", "language name is required")
 		}
-		if r.IsDefault {
-			defaultResumeID = &r.ID
+		if lang.MinProficiency != "" && resume.LanguageProficiencyRank(lang.MinProficiency) == resume.ProficiencyUnknown {
+			return resume.ErrInvalidSearchRequest().
+				WithDetail("field", "languages").
+				WithDetail("value", lang.MinProficiency).
+				WithDetail("reason", "unknown proficiency; use basic, intermediate, professional, fluent or native")
 		}
 	}
 
-	return resume.ToListResumesResponse(
-		convertSliceToPointers(paginated.Items),
-		paginated.Page.Number,
-		paginated.Page.Size,
-		paginated.Page.Total,
-		activeCount,
-		inactiveCount,
-		defaultResumeID,
-	), nil
+	return nil
 }
 
-// SearchResumes performs semantic search on resumes
-func (s *Service) SearchResumes(ctx context.Context, req resume.SearchResumesRequest) (*resume.SearchResumesResponse, error) {
-	startTime := time.Now()
+// validateFacets checks that every requested facet is supported
+func validateFacets(facets []resume.Facet) error {
+	for _, facet := range facets {
+		if !facet.IsValid() {
+			return resume.ErrInvalidSearchRequest().
+				WithDetail("field", "facets").
+				WithDetail("value", facet).
+				WithDetail("allowed_facets", resume.Facets)
+		}
+	}
+	return nil
+}
 
-	// Search
-	matches, err := s.repo.SemanticSearch(ctx, req)
-	if err != nil {
-		return nil, resume.ErrSearchFailed().
-			WithDetail("query", req.Query).
-			WithDetails(map[string]interface{}{
-				"error":   err.Error(),
-				"top_k":   req.TopK,
-				"filters": req,
-			})
+// validatePagination checks the sort direction and that the cursor was issued by a
+// previous page
+func validatePagination(pagination kernel.PaginationOptions) error {
+	if pagination.PageSize < 0 || pagination.PageSize > 100 {
+		return resume.ErrInvalidPagination().
+			WithDetail("field", "page_size").
+			WithDetail("value", pagination.PageSize).
+			WithDetail("reason", "page_size must be between 1 and 100")
 	}
 
-	executionTime := time.Since(startTime).String()
+	if pagination.Direction != "" && !pagination.Direction.IsValid() {
+		return resume.ErrInvalidPagination().
+			WithDetail("field", "direction").
+			WithDetail("value", pagination.Direction).
+			WithDetail("reason", "direction must be asc or desc")
+	}
 
-	// Build filters
-	filters := resume.SearchFilters{
-		MinYearsExperience: req.MinYearsExperience,
-		MaxYearsExperience: req.MaxYearsExperience,
-		RequiredSkills:     req.RequiredSkills,
-		PreferredSkills:    req.PreferredSkills,
-		Locations:          req.Locations,
-		EducationLevel:     req.EducationLevel,
-		Languages:          req.Languages,
-		Industries:         req.Industries,
-		OnlyActive:         req.OnlyActive,
+	if _, err := pagination.DecodeCursor(); err != nil {
+		return resume.ErrInvalidPagination().
+			WithDetail("field", "cursor").
+			WithDetail("reason", "cursor is malformed or was not issued by this endpoint")
 	}
 
-	return resume.ToSearchResumesResponse(
-		matches,
-		req.Pagination.Page,
-		req.Pagination.PageSize,
-		len(matches),
-		req.Query,
-		filters,
-		executionTime,
-	), nil
+	return nil
 }
 
 // ============================================================================
@@ -626,6 +1380,87 @@ func (s *Service) GetResumeStats(ctx context.Context, tenantID kernel.TenantID)
 	return stats, nil
 }
 
+// ============================================================================
+// Search Settings
+// ============================================================================
+
+// GetSearchWeights returns the tenant's default section weights, or the global default if unset
+func (s *Service) GetSearchWeights(ctx context.Context, tenantID kernel.TenantID) (*resume.SectionWeights, error) {
+	weights := resume.DefaultSectionWeights()
+	if s.tenantConfig == nil {
+		return &weights, nil
+	}
+
+	config, err := s.tenantConfig.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := config[resume.SearchWeightsConfigKey]
+	if !ok || raw == "" {
+		return &weights, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &weights); err != nil {
+		return nil, resume.ErrInvalidSearchRequest().
+			WithDetail("tenant_id", tenantID).
+			WithDetail("config_key", resume.SearchWeightsConfigKey).
+			WithDetails(map[string]any{
+				"error": err.Error(),
+			})
+	}
+
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &weights, nil
+}
+
+// SetSearchWeights validates and stores the tenant's default section weights
+func (s *Service) SetSearchWeights(ctx context.Context, tenantID kernel.TenantID, weights resume.SectionWeights) (*resume.SectionWeights, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+
+	if s.tenantConfig == nil {
+		return nil, resume.ErrSearchFailed().
+			WithDetail("reason", "tenant configuration is not available")
+	}
+
+	raw, err := json.Marshal(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tenantConfig.SaveSetting(ctx, tenantID, resume.SearchWeightsConfigKey, string(raw)); err != nil {
+		return nil, err
+	}
+
+	return &weights, nil
+}
+
+// ResetSearchWeights removes the tenant's default section weights so the global default applies
+func (s *Service) ResetSearchWeights(ctx context.Context, tenantID kernel.TenantID) (*resume.SectionWeights, error) {
+	weights := resume.DefaultSectionWeights()
+	if s.tenantConfig == nil {
+		return &weights, nil
+	}
+
+	config, err := s.tenantConfig.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := config[resume.SearchWeightsConfigKey]; ok {
+		if err := s.tenantConfig.DeleteSetting(ctx, tenantID, resume.SearchWeightsConfigKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &weights, nil
+}
+
 // ============================================================================
 // Embeddings Management
 // ============================================================================
@@ -665,6 +1500,15 @@ func (s *Service) BulkUpdateEmbeddings(ctx context.Context, req resume.BulkUpdat
 			WithDetail("tenant_id", req.TenantID)
 	}
 
+	// Forced runs embed every text again, bypassing stored and cached vectors
+	embedder, err := s.embedderFor(ctx, &req.TenantID, "")
+	if err != nil {
+		return nil, err
+	}
+	if req.Force {
+		embedder = embeddings.Refreshing(embedder)
+	}
+
 	response := &resume.BulkOperationResponse{
 		TotalProcessed: len(resumes),
 		SuccessCount:   0,
@@ -678,14 +1522,14 @@ func (s *Service) BulkUpdateEmbeddings(ctx context.Context, req resume.BulkUpdat
 			continue
 		}
 
-		embeddings, err := s.generateResumeEmbeddings(ctx, r)
+		generated, err := s.embedResume(ctx, embedder, r, req.Force)
 		if err != nil {
 			response.FailureCount++
 			response.Errors = append(response.Errors, fmt.Sprintf("Resume %s: %v", r.ID, err))
 			continue
 		}
 
-		if err := s.repo.UpdateEmbeddings(ctx, r.ID, *embeddings); err != nil {
+		if err := s.repo.UpdateEmbeddings(ctx, r.ID, *generated); err != nil {
 			response.FailureCount++
 			response.Errors = append(response.Errors, fmt.Sprintf("Resume %s: %v", r.ID, err))
 			continue
@@ -702,93 +1546,159 @@ func (s *Service) BulkUpdateEmbeddings(ctx context.Context, req resume.BulkUpdat
 // Private Helper Methods
 // ============================================================================
 
-// generateResumeEmbeddings generates all embeddings for a resume
+// generateResumeEmbeddings generates all embeddings for a resume with the tenant's active model
 func (s *Service) generateResumeEmbeddings(ctx context.Context, r *resume.Resume) (*resume.ResumeEmbeddings, error) {
+	embedder, err := s.embedderFor(ctx, &r.TenantID, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.embedResume(ctx, embedder, r, false)
+}
+
+// embedResume generates all section embeddings for a resume with the given embedder.
+// Unless regenerate is set, sections whose text is unchanged since the stored vectors
+// were generated by the same model are reused; only the others are sent to the provider.
+func (s *Service) embedResume(ctx context.Context, embedder embeddings.Embedder, r *resume.Resume, regenerate bool) (*resume.ResumeEmbeddings, error) {
 	now := time.Now()
 
 	// Prepare texts for embedding with tracking
 	type embeddingRequest struct {
-		text     string
-		field    string
-		position int
+		text  string
+		field string
+		hash  string
 	}
 
 	requests := []embeddingRequest{
-		{text: s.formatExperienceForEmbedding(r), field: "experience", position: 0},
-		{text: s.formatEducationForEmbedding(r), field: "education", position: 1},
-		{text: s.formatSkillsForEmbedding(r), field: "skills", position: 2},
-		{text: s.formatLanguagesForEmbedding(r), field: "languages", position: 3},
+		{text: s.formatExperienceForEmbedding(r), field: resume.SectionExperience},
+		{text: s.formatEducationForEmbedding(r), field: resume.SectionEducation},
+		{text: s.formatSkillsForEmbedding(r), field: resume.SectionSkills},
+		{text: s.formatLanguagesForEmbedding(r), field: resume.SectionLanguages},
 	}
 
 	// Add personal statement if exists
 	if r.HasPersonalStatement() {
 		requests = append(requests, embeddingRequest{
-			text:     s.formatPersonalStatementForEmbedding(r),
-			field:    "personal_statement",
-			position: 4,
+			text:  s.formatPersonalStatementForEmbedding(r),
+			field: resume.SectionPersonalStatement,
 		})
 	}
 
-	// Filter out empty texts and build texts array
+	result := &resume.ResumeEmbeddings{
+		ModelUsed:     embedder.Model(),
+		EmbeddingDim:  embedder.Dimension(),
+		GeneratedAt:   now,
+		SectionHashes: map[string]string{},
+	}
+
+	// Reuse unchanged sections; collect the rest, skipping empty texts
 	var texts []string
-	var validRequests []embeddingRequest
+	var pending []embeddingRequest
 	for _, req := range requests {
 		trimmed := strings.TrimSpace(req.text)
-		if trimmed != "" {
-			texts = append(texts, trimmed)
-			validRequests = append(validRequests, req)
+		if trimmed == "" {
+			continue
+		}
+		req.text = trimmed
+		req.hash = embeddings.ContentHash(trimmed)
+		result.SectionHashes[req.field] = req.hash
+
+		if vector, ok := r.Embeddings.ReusableSection(req.field, embedder.Model(), embedder.Dimension(), req.hash); ok && !regenerate {
+			result.SetSection(req.field, vector)
+			continue
+		}
+		texts = append(texts, trimmed)
+		pending = append(pending, req)
+	}
+
+	// One vector per work experience and project, for max-sim search over positions
+	var pendingEntries []int
+	for _, entry := range s.resumeEntries(r) {
+		if vector, ok := r.Embeddings.ReusableEntry(entry.Type, embedder.Model(), embedder.Dimension(), entry.ContentHash); ok && !regenerate {
+			entry.Embedding = vector
+		} else {
+			texts = append(texts, entry.text)
+			pendingEntries = append(pendingEntries, len(result.Entries))
 		}
+		result.Entries = append(result.Entries, entry.EntryEmbedding)
 	}
 
 	// Check if we have any text to embed
-	if len(texts) == 0 {
+	if len(result.SectionHashes) == 0 && len(result.Entries) == 0 {
 		logx.Warn("No text content available for embedding generation")
-		return &resume.ResumeEmbeddings{
-			ModelUsed:    EmbeddingModel,
-			EmbeddingDim: EmbeddingDimension,
-			GeneratedAt:  now,
-		}, nil
+		return result, nil
+	}
+
+	if len(texts) == 0 {
+		logx.Debugf("Embeddings unchanged for resume %s, skipping provider call", r.ID)
+		return result, nil
 	}
 
-	logx.Debugf("Generating embeddings for %d text chunks", len(texts))
+	logx.Debugf("Generating embeddings for %d of %d text chunks", len(texts), len(result.SectionHashes)+len(result.Entries))
 
 	// Generate embeddings in batch
-	embeddings, err := s.embedGen.GenerateBatchEmbeddings(ctx, texts)
+	vectors, err := embedder.GenerateBatchEmbeddings(ctx, texts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify we got the expected number of embeddings
-	if len(embeddings) != len(texts) {
-		return nil, fmt.Errorf("embedding count mismatch: expected %d, got %d", len(texts), len(embeddings))
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("embedding count mismatch: expected %d, got %d", len(texts), len(vectors))
 	}
 
-	// Map embeddings back to their fields
-	result := &resume.ResumeEmbeddings{
-		ModelUsed:    EmbeddingModel,
-		EmbeddingDim: EmbeddingDimension,
-		GeneratedAt:  now,
-	}
-
-	for i, req := range validRequests {
-		switch req.field {
-		case "experience":
-			result.ExperienceEmbedding = embeddings[i]
-		case "education":
-			result.EducationEmbedding = embeddings[i]
-		case "skills":
-			result.SkillsEmbedding = embeddings[i]
-		case "languages":
-			result.LanguagesEmbedding = embeddings[i]
-		case "personal_statement":
-			result.PersonalStatementEmbedding = embeddings[i]
-		}
+	// Map embeddings back to their fields, then to the entries that follow them
+	for i, req := range pending {
+		result.SetSection(req.field, vectors[i])
+	}
+	for i, entryIndex := range pendingEntries {
+		result.Entries[entryIndex].Embedding = vectors[len(pending)+i]
+	}
+
+	// Reject vectors that do not match the model dimension or cannot be indexed
+	if err := result.CheckDimension(); err != nil {
+		return nil, err
 	}
 
 	logx.Debugf("Successfully generated embeddings for resume")
 	return result, nil
 }
 
+// entryText is an entry embedding to generate together with its source text
+type entryText struct {
+	resume.EntryEmbedding
+	text string
+}
+
+// resumeEntries returns the non-empty work experiences and projects of a resume, hashed
+func (s *Service) resumeEntries(r *resume.Resume) []entryText {
+	entries := []entryText{}
+	add := func(entryType resume.EntryType, index int, title, organization, text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		entries = append(entries, entryText{
+			EntryEmbedding: resume.EntryEmbedding{
+				Type:         entryType,
+				Index:        index,
+				Title:        title,
+				Organization: organization,
+				ContentHash:  embeddings.ContentHash(text),
+			},
+			text: text,
+		})
+	}
+
+	for i, exp := range r.WorkExperience {
+		add(resume.EntryTypeWorkExperience, i, exp.Title, exp.Company, s.formatWorkExperienceEntryForEmbedding(exp))
+	}
+	for i, project := range r.Projects {
+		add(resume.EntryTypeProject, i, project.Title, "", s.formatProjectForEmbedding(project))
+	}
+
+	return entries
+}
+
 // formatExperienceForEmbedding formats work experience for embedding
 func (s *Service) formatExperienceForEmbedding(r *resume.Resume) string {
 	if !r.HasWorkExperience() {
@@ -797,19 +1707,40 @@ func (s *Service) formatExperienceForEmbedding(r *resume.Resume) string {
 
 	var parts []string
 	for _, exp := range r.WorkExperience {
-		text := fmt.Sprintf("%s at %s (%s to %s). ", exp.Title, exp.Company, exp.StartDate, exp.EndDate)
-		text += exp.DescriptionNormalized + " "
-		if len(exp.Achievements) > 0 {
-			text += "Achievements: " + strings.Join(exp.Achievements, ". ") + " "
-		}
-		if len(exp.SkillsUsed) > 0 {
-			text += "Skills: " + strings.Join(exp.SkillsUsed, ", ")
-		}
-		parts = append(parts, text)
+		parts = append(parts, s.formatWorkExperienceEntryForEmbedding(exp))
 	}
 	return strings.Join(parts, "\n")
 }
 
+// formatWorkExperienceEntryForEmbedding formats a single work experience for embedding
+func (s *Service) formatWorkExperienceEntryForEmbedding(exp resume.WorkExperience) string {
+	text := fmt.Sprintf("%s at %s (%s to %s). ", exp.Title, exp.Company, exp.StartDate, exp.EndDate)
+	text += exp.DescriptionNormalized + " "
+	if len(exp.Achievements) > 0 {
+		text += "Achievements: " + strings.Join(exp.Achievements, ". ") + " "
+	}
+	if len(exp.SkillsUsed) > 0 {
+		text += "Skills: " + strings.Join(exp.SkillsUsed, ", ")
+	}
+	return text
+}
+
+// formatProjectForEmbedding formats a single project for embedding
+func (s *Service) formatProjectForEmbedding(project resume.Project) string {
+	text := "Project: " + project.Title
+	if project.Role != "" {
+		text += " (" + project.Role + ")"
+	}
+	text += ". " + project.Description + " "
+	if len(project.Outcomes) > 0 {
+		text += "Outcomes: " + strings.Join(project.Outcomes, ". ") + " "
+	}
+	if len(project.Technologies) > 0 {
+		text += "Technologies: " + strings.Join(project.Technologies, ", ")
+	}
+	return text
+}
+
 // formatEducationForEmbedding formats education for embedding
 func (s *Service) formatEducationForEmbedding(r *resume.Resume) string {
 	if !r.HasEducation() {
@@ -897,18 +1828,19 @@ func (s *Service) convertParsedDataToDomain(parsed *resumeparser.ResumeData, req
 
 	// Convert personal info
 	personalInfo := resume.PersonalInfo{
-		FullName: parsed.PersonalInfo.Name,
-		Email:    parsed.PersonalInfo.Email,
-		Phone:    parsed.PersonalInfo.Phone,
-		LinkedIn: parsed.PersonalInfo.LinkedIn,
-	}
-
-	// Parse location
-	if parsed.PersonalInfo.Location != "" {
-		personalInfo.Location = resume.Location{
-			City:    parsed.PersonalInfo.Location,
-			Country: "",
-		}
+		FullName:  parsed.PersonalInfo.Name,
+		Email:     parsed.PersonalInfo.Email,
+		Phone:     parsed.PersonalInfo.Phone,
+		LinkedIn:  parsed.PersonalInfo.LinkedIn,
+		GitHub:    parsed.PersonalInfo.GitHub,
+		Portfolio: parsed.PersonalInfo.Portfolio,
+		Website:   parsed.PersonalInfo.Website,
+		Location: resume.Location{
+			City:    parsed.PersonalInfo.Location.City,
+			State:   parsed.PersonalInfo.Location.State,
+			Country: parsed.PersonalInfo.Location.Country,
+			ZipCode: parsed.PersonalInfo.Location.ZipCode,
+		},
 	}
 
 	// Convert work experience
@@ -922,17 +1854,26 @@ func (s *Service) convertParsedDataToDomain(parsed *resumeparser.ResumeData, req
 			DurationMonths:        calculateDurationMonths(exp.StartDate, exp.EndDate),
 			DescriptionNormalized: strings.Join(exp.Responsibilities, ". "),
 			Achievements:          exp.Responsibilities,
+			SkillsUsed:            exp.SkillsUsed,
+			Industry:              exp.Industry,
+			Location:              exp.Location,
+			EmploymentType:        exp.EmploymentType,
 		}
 	}
 
 	// Convert education
 	education := make([]resume.Education, len(parsed.Education))
 	for i, edu := range parsed.Education {
+		gpa, scale := parseGPA(edu.GPA)
 		education[i] = resume.Education{
 			Institution:           edu.Institution,
 			Degree:                edu.Degree,
 			Field:                 edu.Field,
 			GraduationDate:        edu.GraduationDate,
+			GPA:                   gpa,
+			GPAScale:              scale,
+			Honors:                edu.Honors,
+			Coursework:            edu.Coursework,
 			DescriptionNormalized: fmt.Sprintf("%s in %s", edu.Degree, edu.Field),
 		}
 	}
@@ -943,6 +1884,7 @@ func (s *Service) convertParsedDataToDomain(parsed *resumeparser.ResumeData, req
 		hardSkills[i] = resume.Skill{
 			Name:             skill.Name,
 			ProficiencyLevel: skill.ProficiencyLevel,
+			YearsExperience:  skill.YearsExperience,
 		}
 	}
 
@@ -951,6 +1893,7 @@ func (s *Service) convertParsedDataToDomain(parsed *resumeparser.ResumeData, req
 		softSkills[i] = resume.Skill{
 			Name:             skill.Name,
 			ProficiencyLevel: skill.ProficiencyLevel,
+			YearsExperience:  skill.YearsExperience,
 		}
 	}
 
@@ -972,8 +1915,39 @@ func (s *Service) convertParsedDataToDomain(parsed *resumeparser.ResumeData, req
 	certifications := make([]resume.Certification, len(parsed.Certifications))
 	for i, cert := range parsed.Certifications {
 		certifications[i] = resume.Certification{
-			Name:   cert,
-			Issuer: "",
+			Name:           cert.Name,
+			Issuer:         cert.Issuer,
+			IssueDate:      cert.IssueDate,
+			ExpirationDate: cert.ExpirationDate,
+			CredentialID:   cert.CredentialID,
+			CredentialURL:  cert.CredentialURL,
+		}
+	}
+
+	// Convert projects
+	projects := make([]resume.Project, len(parsed.Projects))
+	for i, project := range parsed.Projects {
+		projects[i] = resume.Project{
+			Title:        project.Title,
+			Description:  project.Description,
+			Technologies: project.Technologies,
+			Duration:     project.Duration,
+			URL:          project.URL,
+			Outcomes:     project.Outcomes,
+			Role:         project.Role,
+		}
+	}
+
+	// Convert volunteer work
+	volunteerWork := make([]resume.VolunteerExperience, len(parsed.VolunteerWork))
+	for i, volunteer := range parsed.VolunteerWork {
+		volunteerWork[i] = resume.VolunteerExperience{
+			Organization: volunteer.Organization,
+			Role:         volunteer.Role,
+			StartDate:    volunteer.StartDate,
+			EndDate:      volunteer.EndDate,
+			Description:  volunteer.Description,
+			Achievements: volunteer.Achievements,
 		}
 	}
 
@@ -999,6 +1973,9 @@ func (s *Service) convertParsedDataToDomain(parsed *resumeparser.ResumeData, req
 		Skills:              skills,
 		Languages:           languages,
 		Certifications:      certifications,
+		Projects:            projects,
+		Achievements:        parsed.Achievements,
+		VolunteerWork:       volunteerWork,
 		ProfessionalSummary: parsed.Summary,
 		PersonalStatement:   personalStatement, // ✅ Now correctly mapped
 		FileURL:             req.FilePath,
@@ -1010,6 +1987,29 @@ func (s *Service) convertParsedDataToDomain(parsed *resumeparser.ResumeData, req
 	}
 }
 
+// parseGPA reads the grade and scale of a GPA as written, e.g. "3.8", "3,8", "18/20" or
+// "3.8 out of 4"; the grade is nil when it is not a number, the scale when not written
+func parseGPA(gpa string) (grade, scale *float64) {
+	number := func(s string) *float64 {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", "."), 64)
+		if err != nil {
+			return nil
+		}
+		return &value
+	}
+
+	value, max, found := strings.Cut(gpa, "/")
+	if !found {
+		value, max, found = strings.Cut(strings.ToLower(gpa), "out of")
+	}
+
+	grade = number(value)
+	if grade != nil && found {
+		scale = number(max)
+	}
+	return grade, scale
+}
+
 // unsetOtherDefaults unsets default flag on other resumes
 func (s *Service) unsetOtherDefaults(ctx context.Context, tenantID kernel.TenantID) error {
 	existing, err := s.repo.GetDefaultByTenantID(ctx, tenantID)
@@ -1020,27 +2020,8 @@ func (s *Service) unsetOtherDefaults(ctx context.Context, tenantID kernel.Tenant
 	return nil
 }
 
-// calculateDurationMonths calculates months between two dates
+// calculateDurationMonths calculates months between two dates, 0 when either cannot be read
 func calculateDurationMonths(startDate, endDate string) int {
-	// Simple calculation - you might want to use a proper date parser
-	if endDate == "Present" {
-		endDate = time.Now().Format("2006-01")
-	}
-
-	start, _ := time.Parse("2006-01", startDate)
-	end, _ := time.Parse("2006-01", endDate)
-
-	years := end.Year() - start.Year()
-	months := int(end.Month() - start.Month())
-
-	return years*12 + months
-}
-
-// convertSliceToPointers converts slice of values to slice of pointers
-func convertSliceToPointers(items []resume.Resume) []*resume.Resume {
-	result := make([]*resume.Resume, len(items))
-	for i := range items {
-		result[i] = &items[i]
-	}
-	return result
+	months, _ := normalize.MonthsBetween(startDate, endDate, time.Now())
+	return months
 }
@@ -0,0 +1,51 @@
+package resume
+
+// ParseReviewConfigKey is the tenant config key holding the tenant's parse review settings (JSON)
+const ParseReviewConfigKey = "resume.parse.review"
+
+// ReviewMode - When parsed resumes wait for a reviewer before they are created
+type ReviewMode string
+
+const (
+	ReviewModeOff           ReviewMode = "off"            // Parsed resumes are created right away
+	ReviewModeAlways        ReviewMode = "always"         // Every parsed resume waits for review
+	ReviewModeLowConfidence ReviewMode = "low_confidence" // Only resumes with low-confidence fields wait
+)
+
+// ParseReviewSettings - Per-tenant human review of parsed resumes
+type ParseReviewSettings struct {
+	Mode ReviewMode `json:"mode"`
+}
+
+// DefaultParseReviewSettings returns the settings of tenants that have not configured review
+func DefaultParseReviewSettings() ParseReviewSettings {
+	return ParseReviewSettings{Mode: ReviewModeOff}
+}
+
+// Validate checks the review mode
+func (s ParseReviewSettings) Validate() error {
+	switch s.Mode {
+	case ReviewModeOff, ReviewModeAlways, ReviewModeLowConfidence:
+		return nil
+	}
+	return ErrInvalidReviewConfig().
+		WithDetail("field", "mode").
+		WithDetail("value", s.Mode).
+		WithDetail("reason", "mode must be one of off, always, low_confidence")
+}
+
+// Requires reports whether a parsed resume has to be reviewed before it is created
+func (s ParseReviewSettings) Requires(r *Resume) bool {
+	switch s.Mode {
+	case ReviewModeAlways:
+		return true
+	case ReviewModeLowConfidence:
+		return r != nil && r.NeedsReview
+	}
+	return false
+}
+
+// RejectDraftRequest - Rejecting a parsed draft
+type RejectDraftRequest struct {
+	Reason string `json:"reason" validate:"max=1000"`
+}
@@ -0,0 +1,171 @@
+package resume
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/skill"
+)
+
+// SearchWeightsConfigKey is the tenant config key holding the tenant's default section weights (JSON)
+const SearchWeightsConfigKey = "resume.search.weights"
+
+// weightsTolerance is the allowed deviation from 1 when checking that weights are normalized
+const weightsTolerance = 0.001
+
+// SearchMode selects how resumes are retrieved
+type SearchMode string
+
+const (
+	SearchModeSemantic SearchMode = "semantic" // Vector similarity over section embeddings
+	SearchModeKeyword  SearchMode = "keyword"  // Postgres full-text search
+	SearchModeHybrid   SearchMode = "hybrid"   // Both, fused with reciprocal rank fusion
+)
+
+// IsValid checks if the search mode is supported
+func (m SearchMode) IsValid() bool {
+	switch m {
+	case SearchModeSemantic, SearchModeKeyword, SearchModeHybrid:
+		return true
+	}
+	return false
+}
+
+// RRFConstant is k in reciprocal rank fusion, score = sum of 1 / (k + rank)
+const RRFConstant = 60
+
+// HybridCandidatePool returns how many candidates each ranking contributes before fusion
+func HybridCandidatePool(topK int) int {
+	return min(max(topK*4, 50), 200)
+}
+
+// FuseReciprocalRank merges the semantic and keyword rankings with reciprocal rank fusion.
+// The fused score becomes SimilarityScore; the component scores are kept on the result.
+func FuseReciprocalRank(semantic, keyword []ResumeMatchResult, topK int) []ResumeMatchResult {
+	fused := make(map[kernel.ResumeID]*ResumeMatchResult)
+	order := []kernel.ResumeID{}
+
+	add := func(results []ResumeMatchResult, apply func(target, hit *ResumeMatchResult)) {
+		for rank, hit := range results {
+			target, ok := fused[hit.Resume.ID]
+			if !ok {
+				copied := hit
+				copied.SimilarityScore = 0
+				target = &copied
+				fused[hit.Resume.ID] = target
+				order = append(order, hit.Resume.ID)
+			}
+			apply(target, &hit)
+			target.SimilarityScore += 1 / float64(RRFConstant+rank+1)
+		}
+	}
+
+	add(semantic, func(target, hit *ResumeMatchResult) {
+		target.SemanticScore = hit.SemanticScore
+		target.MatchedSections = hit.MatchedSections
+		target.BestMatchingEntry = hit.BestMatchingEntry
+	})
+	add(keyword, func(target, hit *ResumeMatchResult) {
+		target.KeywordScore = hit.KeywordScore
+	})
+
+	results := make([]ResumeMatchResult, 0, len(order))
+	for _, id := range order {
+		results = append(results, *fused[id])
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].SimilarityScore != results[j].SimilarityScore {
+			return results[i].SimilarityScore > results[j].SimilarityScore
+		}
+		return results[i].SemanticScore > results[j].SemanticScore
+	})
+
+	if topK > 0 && len(results) > topK {
+		results = results[:topK]
+	}
+	return results
+}
+
+// SkillExpansions maps each requested skill to the normalized names that satisfy it,
+// as resolved by the skill taxonomy: its aliases, its sub-skills and their aliases
+type SkillExpansions map[string][]string
+
+// Accepted returns the normalized names that satisfy a requested skill.
+// Skills without an expansion only accept themselves.
+func (e SkillExpansions) Accepted(requested string) []string {
+	if accepted, ok := e[requested]; ok && len(accepted) > 0 {
+		return accepted
+	}
+	if key := skill.Normalize(requested); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
+// SectionWeights - Relative weight of each resume section in the combined search score.
+// Weights must be non-negative and sum to 1.
+type SectionWeights struct {
+	Experience        float64 `json:"experience"`
+	Education         float64 `json:"education"`
+	Skills            float64 `json:"skills"`
+	Languages         float64 `json:"languages"`
+	PersonalStatement float64 `json:"personal_statement"`
+}
+
+// DefaultSectionWeights returns the weights used when neither the request nor the tenant
+// sets them. They are the weights search has always used; the personal statement only
+// counts when a request or tenant gives it a weight.
+func DefaultSectionWeights() SectionWeights {
+	return SectionWeights{
+		Experience:        0.4,
+		Education:         0.2,
+		Skills:            0.3,
+		Languages:         0.1,
+		PersonalStatement: 0,
+	}
+}
+
+// Sum returns the total of all section weights
+func (w SectionWeights) Sum() float64 {
+	return w.Experience + w.Education + w.Skills + w.Languages + w.PersonalStatement
+}
+
+// Validate checks that weights are non-negative and normalized
+func (w SectionWeights) Validate() error {
+	values := map[string]float64{
+		"experience":         w.Experience,
+		"education":          w.Education,
+		"skills":             w.Skills,
+		"languages":          w.Languages,
+		"personal_statement": w.PersonalStatement,
+	}
+	for section, value := range values {
+		if value < 0 || value > 1 || math.IsNaN(value) {
+			return ErrInvalidSearchRequest().
+				WithDetail("field", "weights."+section).
+				WithDetail("value", value).
+				WithDetail("reason", "weights must be between 0 and 1")
+		}
+	}
+
+	if sum := w.Sum(); math.Abs(sum-1) > weightsTolerance {
+		return ErrInvalidSearchRequest().
+			WithDetail("field", "weights").
+			WithDetail("sum", sum).
+			WithDetail("reason", "weights must sum to 1")
+	}
+
+	return nil
+}
+
+// SearchSnapshot is a ranked search kept between its pages. Later pages are cut from
+// the same hits, so they follow the order of the first page even when re-ranking would
+// order a new run differently, and they cost no embedding, re-ranking or facet queries.
+type SearchSnapshot struct {
+	TenantID *kernel.TenantID      `json:"tenant_id,omitempty"`
+	Matches  []ResumeMatchResult   `json:"matches"`
+	PageSize int                   `json:"page_size"`
+	Response SearchResumesResponse `json:"response"` // First response without its results, facets and timing
+}
@@ -0,0 +1,60 @@
+package resume
+
+import "fmt"
+
+// VectorStorage is the Postgres type section vectors are stored and indexed as
+type VectorStorage string
+
+const (
+	VectorStorageFull VectorStorage = "vector"  // 4 bytes per dimension
+	VectorStorageHalf VectorStorage = "halfvec" // 2 bytes per dimension, at a small recall cost
+)
+
+// IsValid checks if the storage is supported
+func (s VectorStorage) IsValid() bool {
+	return s == VectorStorageFull || s == VectorStorageHalf
+}
+
+// MaxDimension returns the largest vector size HNSW can index with this storage
+func (s VectorStorage) MaxDimension() int {
+	if s == VectorStorageHalf {
+		return MaxEmbeddingDimension
+	}
+	return MaxFullEmbeddingDimension
+}
+
+// Cast returns the SQL cast to a fixed-size vector of this storage, which lets the
+// planner match the per-dimension HNSW indexes
+func (s VectorStorage) Cast(dim int) string {
+	if s == VectorStorageHalf {
+		return fmt.Sprintf("::halfvec(%d)", dim)
+	}
+	return fmt.Sprintf("::vector(%d)", dim)
+}
+
+const (
+	// DefaultEfSearch is the pgvector default size of the HNSW candidate list
+	DefaultEfSearch = 40
+
+	// MaxEfSearch is the largest candidate list pgvector accepts
+	MaxEfSearch = 1000
+)
+
+// VectorIndexSettings - HNSW parameters and vector storage, chosen when the
+// database is migrated (see configure_resume_vector_indexes)
+type VectorIndexSettings struct {
+	Storage        VectorStorage `db:"storage" json:"storage"`
+	M              int           `db:"m" json:"m"`
+	EfConstruction int           `db:"ef_construction" json:"ef_construction"`
+	EfSearch       int           `db:"ef_search" json:"ef_search"` // Used by searches that do not set their own
+}
+
+// DefaultVectorIndexSettings returns the pgvector defaults
+func DefaultVectorIndexSettings() VectorIndexSettings {
+	return VectorIndexSettings{
+		Storage:        VectorStorageFull,
+		M:              16,
+		EfConstruction: 64,
+		EfSearch:       DefaultEfSearch,
+	}
+}
@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume/resumesrv"
+)
+
+// ReindexWorker runs embedding migrations in the background
+type ReindexWorker struct {
+	service      *resumesrv.Service
+	pollInterval time.Duration
+	staleAfter   time.Duration
+}
+
+func NewReindexWorker(service *resumesrv.Service, pollInterval, staleAfter time.Duration) *ReindexWorker {
+	return &ReindexWorker{
+		service:      service,
+		pollInterval: pollInterval,
+		staleAfter:   staleAfter,
+	}
+}
+
+func (w *ReindexWorker) Start(ctx context.Context) {
+	logx.Infof("Starting embedding re-index worker (poll every %s)", w.pollInterval)
+	go w.run(ctx)
+}
+
+func (w *ReindexWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logx.Info("Re-index worker stopping")
+			return
+		case <-ticker.C:
+			w.runPending(ctx)
+		}
+	}
+}
+
+// runPending runs claimed migrations until none is left
+func (w *ReindexWorker) runPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		migration, err := w.service.ClaimEmbeddingMigration(ctx, w.staleAfter)
+		if err != nil {
+			logx.Errorf("Failed to claim embedding migration: %v", err)
+			return
+		}
+		if migration == nil {
+			return
+		}
+
+		logx.Infof("Running embedding migration %s for tenant %s (%s -> %s)",
+			migration.ID, migration.TenantID, migration.SourceModel, migration.TargetModel)
+		if err := w.service.RunEmbeddingMigration(ctx, migration); err != nil {
+			logx.Errorf("Embedding migration %s failed: %v", migration.ID, err)
+		}
+	}
+}
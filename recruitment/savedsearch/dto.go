@@ -0,0 +1,70 @@
+package savedsearch
+
+import (
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+// CreateSavedSearchRequest - Save a search for the current user
+type CreateSavedSearchRequest struct {
+	TenantID      kernel.TenantID             `json:"-"`
+	UserID        kernel.UserID               `json:"-"`
+	Name          string                      `json:"name" validate:"required"`
+	Request       resume.SearchResumesRequest `json:"request" validate:"required"`
+	MinScore      *float64                    `json:"min_score,omitempty"`      // Optional: defaults to DefaultMinScore
+	AlertsEnabled *bool                       `json:"alerts_enabled,omitempty"` // Optional: defaults to true
+	EmailAlerts   bool                        `json:"email_alerts"`
+}
+
+// UpdateSavedSearchRequest - Update a saved search
+type UpdateSavedSearchRequest struct {
+	TenantID      kernel.TenantID              `json:"-"`
+	UserID        kernel.UserID                `json:"-"`
+	Name          *string                      `json:"name,omitempty"`
+	Request       *resume.SearchResumesRequest `json:"request,omitempty"`
+	MinScore      *float64                     `json:"min_score,omitempty"`
+	AlertsEnabled *bool                        `json:"alerts_enabled,omitempty"`
+	EmailAlerts   *bool                        `json:"email_alerts,omitempty"`
+}
+
+// ListSavedSearchesResponse - A user's saved searches
+type ListSavedSearchesResponse struct {
+	SavedSearches []SavedSearch `json:"saved_searches"`
+	Total         int           `json:"total"`
+}
+
+// ListNotificationsRequest - Page through a user's notifications
+type ListNotificationsRequest struct {
+	TenantID   kernel.TenantID          `json:"-"`
+	UserID     kernel.UserID            `json:"-"`
+	UnreadOnly bool                     `json:"unread_only"`
+	Pagination kernel.PaginationOptions `json:"pagination"`
+}
+
+// ListNotificationsResponse - A page of notifications and the unread total
+type ListNotificationsResponse struct {
+	Notifications kernel.Paginated[Notification] `json:"notifications"`
+	UnreadCount   int                            `json:"unread_count"`
+}
+
+// UnreadCountResponse - Number of unread notifications, for polling
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+// MarkAllReadResponse - Result of marking every notification as read
+type MarkAllReadResponse struct {
+	Updated int `json:"updated"`
+}
+
+// ToListSavedSearchesResponse lists saved searches
+func ToListSavedSearchesResponse(searches []*SavedSearch) *ListSavedSearchesResponse {
+	items := make([]SavedSearch, len(searches))
+	for i, s := range searches {
+		items[i] = *s
+	}
+	return &ListSavedSearchesResponse{
+		SavedSearches: items,
+		Total:         len(items),
+	}
+}
@@ -0,0 +1,44 @@
+package savedsearch
+
+import (
+	"net/http"
+
+	"github.com/Abraxas-365/relay/pkg/errx"
+)
+
+var ErrRegistry = errx.NewRegistry("SAVED_SEARCH")
+
+// Error codes - Saved Searches
+var (
+	CodeSavedSearchNotFound     = ErrRegistry.Register("NOT_FOUND", errx.TypeNotFound, http.StatusNotFound, "Saved search not found")
+	CodeInvalidSavedSearch      = ErrRegistry.Register("INVALID_DATA", errx.TypeValidation, http.StatusBadRequest, "Invalid saved search data")
+	CodeMaxSavedSearchesReached = ErrRegistry.Register("MAX_REACHED", errx.TypeBusiness, http.StatusUnprocessableEntity, "Maximum number of saved searches reached")
+	CodeUserRequired            = ErrRegistry.Register("USER_REQUIRED", errx.TypeAuthorization, http.StatusForbidden, "Saved searches and notifications belong to a user; API keys cannot use them")
+	CodeNotificationNotFound    = ErrRegistry.Register("NOTIFICATION_NOT_FOUND", errx.TypeNotFound, http.StatusNotFound, "Notification not found")
+	CodeSavedSearchFailed       = ErrRegistry.Register("FAILED", errx.TypeInternal, http.StatusInternalServerError, "Saved search operation failed")
+)
+
+// Helper functions - Saved Searches
+func ErrSavedSearchNotFound() *errx.Error {
+	return ErrRegistry.New(CodeSavedSearchNotFound)
+}
+
+func ErrInvalidSavedSearch() *errx.Error {
+	return ErrRegistry.New(CodeInvalidSavedSearch)
+}
+
+func ErrMaxSavedSearchesReached() *errx.Error {
+	return ErrRegistry.New(CodeMaxSavedSearchesReached)
+}
+
+func ErrUserRequired() *errx.Error {
+	return ErrRegistry.New(CodeUserRequired)
+}
+
+func ErrNotificationNotFound() *errx.Error {
+	return ErrRegistry.New(CodeNotificationNotFound)
+}
+
+func ErrSavedSearchFailed() *errx.Error {
+	return ErrRegistry.New(CodeSavedSearchFailed)
+}
@@ -0,0 +1,71 @@
+package savedsearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+type Repository interface {
+	// ListByUser retrieves a user's saved searches, newest first
+	ListByUser(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) ([]*SavedSearch, error)
+
+	// ListAlerting retrieves the tenant's saved searches with alerts enabled
+	ListAlerting(ctx context.Context, tenantID kernel.TenantID) ([]*SavedSearch, error)
+
+	// CountByUser counts a user's saved searches
+	CountByUser(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (int, error)
+
+	// GetByID retrieves a saved search by ID
+	GetByID(ctx context.Context, id kernel.SavedSearchID) (*SavedSearch, error)
+
+	// Create creates a saved search
+	Create(ctx context.Context, s *SavedSearch) error
+
+	// Update updates a saved search
+	Update(ctx context.Context, s *SavedSearch) error
+
+	// TouchLastMatch records when the search last matched a new resume
+	TouchLastMatch(ctx context.Context, id kernel.SavedSearchID, at time.Time) error
+
+	// Delete deletes a saved search and its notifications
+	Delete(ctx context.Context, id kernel.SavedSearchID) error
+}
+
+type NotificationRepository interface {
+	// Create records a match. It reports false, without error, when the search
+	// already matched the resume, so a resume never alerts the same search twice.
+	Create(ctx context.Context, n *Notification) (bool, error)
+
+	// ListByUser retrieves a user's notifications, newest first
+	ListByUser(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID, unreadOnly bool, pagination kernel.PaginationOptions) (*kernel.Paginated[Notification], error)
+
+	// CountUnread counts a user's unread notifications
+	CountUnread(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (int, error)
+
+	// MarkAsRead marks one of the user's notifications as read
+	MarkAsRead(ctx context.Context, id kernel.NotificationID, tenantID kernel.TenantID, userID kernel.UserID) error
+
+	// MarkAllAsRead marks all of the user's notifications as read and returns how many changed
+	MarkAllAsRead(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (int, error)
+}
+
+// Notifier delivers new-match alerts outside the inbox (e.g. by email)
+type Notifier interface {
+	NotifyMatch(ctx context.Context, recipient Recipient, search *SavedSearch, n *Notification) error
+}
+
+// Recipient is the user an alert is delivered to
+type Recipient struct {
+	Email string
+	Name  string
+}
+
+// Searcher runs resume searches; the resume service implements it
+type Searcher interface {
+	SearchResumes(ctx context.Context, req resume.SearchResumesRequest) (*resume.SearchResumesResponse, error)
+	ValidateSearchRequest(req resume.SearchResumesRequest) error
+	InterpretQuery(ctx context.Context, req resume.InterpretQueryRequest) (*resume.QueryInterpretation, error)
+}
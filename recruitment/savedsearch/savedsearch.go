@@ -0,0 +1,104 @@
+package savedsearch
+
+import (
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+)
+
+const (
+	// DefaultMinScore is the match score a new resume needs to raise an alert
+	DefaultMinScore = 0.75
+
+	// MaxSavedSearchesPerUser caps the searches a user keeps, and so the work done per new resume
+	MaxSavedSearchesPerUser = 50
+
+	// MaxNameLength matches the name column of saved_searches
+	MaxNameLength = 255
+)
+
+// SavedSearch is a search request a recruiter keeps to re-run. With alerts on, every
+// resume of the tenant that finishes processing is scored against it, and matches at
+// or above MinScore land in the owner's notification inbox (and email, when enabled).
+type SavedSearch struct {
+	ID            kernel.SavedSearchID        `json:"id"`
+	TenantID      kernel.TenantID             `json:"tenant_id"`
+	UserID        kernel.UserID               `json:"user_id"`
+	Name          string                      `json:"name"`
+	Request       resume.SearchResumesRequest `json:"request"`
+	MinScore      float64                     `json:"min_score"` // 0-1, compared to MatchScore
+	AlertsEnabled bool                        `json:"alerts_enabled"`
+	EmailAlerts   bool                        `json:"email_alerts"`
+	LastMatchAt   *time.Time                  `json:"last_match_at,omitempty"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+}
+
+// BelongsTo reports whether the user owns the search
+func (s *SavedSearch) BelongsTo(tenantID kernel.TenantID, userID kernel.UserID) bool {
+	return s.TenantID == tenantID && s.UserID == userID
+}
+
+// SearchRequest returns the stored request scoped to the search's tenant
+func (s *SavedSearch) SearchRequest() resume.SearchResumesRequest {
+	req := s.Request
+	req.TenantID = &s.TenantID
+	if req.TopK == 0 {
+		req.TopK = 10
+	}
+	if req.Pagination.Page == 0 {
+		req.Pagination.Page = 1
+	}
+	if req.Pagination.PageSize == 0 {
+		req.Pagination.PageSize = 20
+	}
+	req.Pagination.Cursor = "" // Runs always start from the first page
+	return req
+}
+
+// AlertRequest returns the request that scores a single resume against the search.
+// Re-ranking, facets and the hits kept for paging are left out: alerts only need the
+// first-pass score.
+func (s *SavedSearch) AlertRequest(resumeID kernel.ResumeID) resume.SearchResumesRequest {
+	rerank := false
+	req := s.SearchRequest()
+	req.ResumeIDs = []kernel.ResumeID{resumeID}
+	req.TopK = 1
+	req.Pagination = kernel.PaginationOptions{Page: 1, PageSize: 1}
+	req.Rerank = &rerank
+	req.Facets = nil
+	req.FirstPageOnly = true
+	return req
+}
+
+// MatchScore returns the 0-1 score compared to a search's MinScore. Hybrid ranking
+// scores depend on the other hits, so hybrid searches use the semantic score.
+func MatchScore(mode resume.SearchMode, match resume.ResumeMatchResult) float64 {
+	if mode == resume.SearchModeKeyword {
+		return match.KeywordScore
+	}
+	return match.SemanticScore
+}
+
+// Notification tells a user that a new resume matched one of their saved searches.
+// The search name and resume details are kept as they were when the match was found.
+type Notification struct {
+	ID              kernel.NotificationID `json:"id"`
+	TenantID        kernel.TenantID       `json:"tenant_id"`
+	UserID          kernel.UserID         `json:"user_id"`
+	SavedSearchID   kernel.SavedSearchID  `json:"saved_search_id"`
+	SavedSearchName string                `json:"saved_search_name"`
+	ResumeID        kernel.ResumeID       `json:"resume_id"`
+	CandidateName   string                `json:"candidate_name"`
+	ResumeTitle     string                `json:"resume_title"`
+	Score           float64               `json:"score"`
+	MatchedSkills   []string              `json:"matched_skills"`
+	ReadAt          *time.Time            `json:"read_at,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+}
+
+// IsRead reports whether the user has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
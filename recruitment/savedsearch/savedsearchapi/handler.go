@@ -0,0 +1,238 @@
+package savedsearchapi
+
+import (
+	"github.com/Abraxas-365/relay/pkg/iam/auth"
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch/savedsearchsrv"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SavedSearchHandlers struct {
+	service *savedsearchsrv.Service
+}
+
+func NewSavedSearchHandlers(service *savedsearchsrv.Service) *SavedSearchHandlers {
+	return &SavedSearchHandlers{service: service}
+}
+
+func (h *SavedSearchHandlers) RegisterRoutes(app *fiber.App, authMiddleware *auth.UnifiedAuthMiddleware) {
+	searches := app.Group("/api/v1/saved-searches", authMiddleware.Authenticate())
+
+	searches.Get("/", h.ListSavedSearches)       // The user's saved searches
+	searches.Post("/", h.CreateSavedSearch)      // Save a search
+	searches.Get("/:id", h.GetSavedSearch)       // Get a saved search
+	searches.Put("/:id", h.UpdateSavedSearch)    // Update a saved search
+	searches.Delete("/:id", h.DeleteSavedSearch) // Delete a saved search and its notifications
+	searches.Post("/:id/run", h.RunSavedSearch)  // Run a saved search now
+
+	notifications := app.Group("/api/v1/notifications", authMiddleware.Authenticate())
+
+	notifications.Get("/", h.ListNotifications)                 // New-match inbox
+	notifications.Get("/unread-count", h.CountUnread)           // Unread total, for polling
+	notifications.Post("/read-all", h.MarkAllNotificationsRead) // Mark every notification as read
+	notifications.Post("/:id/read", h.MarkNotificationRead)     // Mark a notification as read
+}
+
+// owner returns the tenant and user the request acts for. Saved searches belong to
+// a user, so API key requests without one are rejected.
+func owner(c *fiber.Ctx) (*kernel.AuthContext, kernel.UserID, error) {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return nil, "", c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+	if authCtx.UserID == nil {
+		return nil, "", savedsearch.ErrUserRequired()
+	}
+	return authCtx, *authCtx.UserID, nil
+}
+
+// ListSavedSearches lists the user's saved searches
+// GET /api/v1/saved-searches
+func (h *SavedSearchHandlers) ListSavedSearches(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	response, err := h.service.ListSavedSearches(c.Context(), authCtx.TenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// CreateSavedSearch saves a search for the user
+// POST /api/v1/saved-searches
+func (h *SavedSearchHandlers) CreateSavedSearch(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	var req savedsearch.CreateSavedSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	req.TenantID = authCtx.TenantID
+	req.UserID = userID
+
+	response, err := h.service.CreateSavedSearch(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetSavedSearch returns one of the user's saved searches
+// GET /api/v1/saved-searches/:id
+func (h *SavedSearchHandlers) GetSavedSearch(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	response, err := h.service.GetSavedSearch(c.Context(), kernel.NewSavedSearchID(c.Params("id")), authCtx.TenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// UpdateSavedSearch updates one of the user's saved searches
+// PUT /api/v1/saved-searches/:id
+func (h *SavedSearchHandlers) UpdateSavedSearch(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	var req savedsearch.UpdateSavedSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	req.TenantID = authCtx.TenantID
+	req.UserID = userID
+
+	response, err := h.service.UpdateSavedSearch(c.Context(), kernel.NewSavedSearchID(c.Params("id")), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// DeleteSavedSearch deletes one of the user's saved searches
+// DELETE /api/v1/saved-searches/:id
+func (h *SavedSearchHandlers) DeleteSavedSearch(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	if err := h.service.DeleteSavedSearch(c.Context(), kernel.NewSavedSearchID(c.Params("id")), authCtx.TenantID, userID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// RunSavedSearch runs one of the user's saved searches now
+// POST /api/v1/saved-searches/:id/run
+func (h *SavedSearchHandlers) RunSavedSearch(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	response, err := h.service.RunSavedSearch(c.Context(), kernel.NewSavedSearchID(c.Params("id")), authCtx.TenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// ListNotifications lists the user's new-match notifications
+// GET /api/v1/notifications?unread=true&page=1&page_size=20
+func (h *SavedSearchHandlers) ListNotifications(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	req := savedsearch.ListNotificationsRequest{
+		TenantID:   authCtx.TenantID,
+		UserID:     userID,
+		UnreadOnly: c.QueryBool("unread", false),
+		Pagination: kernel.PaginationOptions{
+			Page:     c.QueryInt("page", 1),
+			PageSize: c.QueryInt("page_size", 20),
+		},
+	}
+
+	response, err := h.service.ListNotifications(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// CountUnread returns the number of unread notifications
+// GET /api/v1/notifications/unread-count
+func (h *SavedSearchHandlers) CountUnread(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	response, err := h.service.CountUnreadNotifications(c.Context(), authCtx.TenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// MarkNotificationRead marks a notification as read
+// POST /api/v1/notifications/:id/read
+func (h *SavedSearchHandlers) MarkNotificationRead(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	if err := h.service.MarkNotificationRead(c.Context(), kernel.NewNotificationID(c.Params("id")), authCtx.TenantID, userID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// MarkAllNotificationsRead marks every notification as read
+// POST /api/v1/notifications/read-all
+func (h *SavedSearchHandlers) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	authCtx, userID, err := owner(c)
+	if authCtx == nil {
+		return err
+	}
+
+	response, err := h.service.MarkAllNotificationsRead(c.Context(), authCtx.TenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,108 @@
+package savedsearchinfra
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch"
+)
+
+// ============================================================================
+// Log notifier (development)
+// ============================================================================
+
+// LogNotifier writes alerts to the log instead of sending them
+type LogNotifier struct{}
+
+func NewLogNotifier() savedsearch.Notifier {
+	return &LogNotifier{}
+}
+
+// NotifyMatch logs the alert
+func (n *LogNotifier) NotifyMatch(ctx context.Context, recipient savedsearch.Recipient, search *savedsearch.SavedSearch, match *savedsearch.Notification) error {
+	logx.Infof("Saved search alert for %s: %q matched resume %s (%s, score %.2f)",
+		recipient.Email, search.Name, match.ResumeID, match.CandidateName, match.Score)
+	return nil
+}
+
+// ============================================================================
+// SMTP notifier
+// ============================================================================
+
+// SMTPConfig configures email delivery
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // Optional: PLAIN auth is used when set
+	Password string
+	From     string
+	AppURL   string // Optional: base URL linked from the email
+}
+
+// SMTPNotifier emails alerts through an SMTP server
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) savedsearch.Notifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+// NotifyMatch emails the alert to the search owner
+func (n *SMTPNotifier) NotifyMatch(ctx context.Context, recipient savedsearch.Recipient, search *savedsearch.SavedSearch, match *savedsearch.Notification) error {
+	if recipient.Email == "" {
+		return fmt.Errorf("recipient has no email address")
+	}
+
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(n.cfg.Host, n.cfg.Port)
+	if err := smtp.SendMail(addr, auth, n.cfg.From, []string{recipient.Email}, n.buildMessage(recipient, search, match)); err != nil {
+		return fmt.Errorf("send alert email: %w", err)
+	}
+	return nil
+}
+
+// buildMessage renders a plain-text email
+func (n *SMTPNotifier) buildMessage(recipient savedsearch.Recipient, search *savedsearch.SavedSearch, match *savedsearch.Notification) []byte {
+	candidate := match.CandidateName
+	if candidate == "" {
+		candidate = match.ResumeTitle
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", recipient.Email)
+	fmt.Fprintf(&b, "Subject: New match for %q\r\n", stripNewlines(search.Name))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+
+	if recipient.Name != "" {
+		fmt.Fprintf(&b, "Hi %s,\r\n\r\n", recipient.Name)
+	}
+	fmt.Fprintf(&b, "A new resume matches your saved search %q.\r\n\r\n", search.Name)
+	fmt.Fprintf(&b, "Candidate: %s\r\n", candidate)
+	if match.ResumeTitle != "" && match.ResumeTitle != candidate {
+		fmt.Fprintf(&b, "Resume: %s\r\n", match.ResumeTitle)
+	}
+	fmt.Fprintf(&b, "Score: %.0f%%\r\n", match.Score*100)
+	if len(match.MatchedSkills) > 0 {
+		fmt.Fprintf(&b, "Matched skills: %s\r\n", strings.Join(match.MatchedSkills, ", "))
+	}
+	if n.cfg.AppURL != "" {
+		fmt.Fprintf(&b, "\r\n%s/resumes/%s\r\n", strings.TrimRight(n.cfg.AppURL, "/"), match.ResumeID)
+	}
+	return []byte(b.String())
+}
+
+// stripNewlines keeps user text from injecting email headers
+func stripNewlines(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,323 @@
+package savedsearchinfra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Saved searches
+// ============================================================================
+
+type PostgresSavedSearchRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresSavedSearchRepository(db *sqlx.DB) savedsearch.Repository {
+	return &PostgresSavedSearchRepository{db: db}
+}
+
+const savedSearchColumns = `id, tenant_id, user_id, name, request, min_score, alerts_enabled, email_alerts, last_match_at, created_at, updated_at`
+
+// savedSearchRow is the database representation of a saved search
+type savedSearchRow struct {
+	ID            string       `db:"id"`
+	TenantID      string       `db:"tenant_id"`
+	UserID        string       `db:"user_id"`
+	Name          string       `db:"name"`
+	Request       []byte       `db:"request"`
+	MinScore      float64      `db:"min_score"`
+	AlertsEnabled bool         `db:"alerts_enabled"`
+	EmailAlerts   bool         `db:"email_alerts"`
+	LastMatchAt   sql.NullTime `db:"last_match_at"`
+	CreatedAt     time.Time    `db:"created_at"`
+	UpdatedAt     time.Time    `db:"updated_at"`
+}
+
+func (r savedSearchRow) toDomain() (*savedsearch.SavedSearch, error) {
+	s := &savedsearch.SavedSearch{
+		ID:            kernel.NewSavedSearchID(r.ID),
+		TenantID:      kernel.TenantID(r.TenantID),
+		UserID:        kernel.UserID(r.UserID),
+		Name:          r.Name,
+		MinScore:      r.MinScore,
+		AlertsEnabled: r.AlertsEnabled,
+		EmailAlerts:   r.EmailAlerts,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+	if err := json.Unmarshal(r.Request, &s.Request); err != nil {
+		return nil, fmt.Errorf("unmarshal saved search request %s: %w", r.ID, err)
+	}
+	if r.LastMatchAt.Valid {
+		s.LastMatchAt = &r.LastMatchAt.Time
+	}
+	return s, nil
+}
+
+func (r *PostgresSavedSearchRepository) list(ctx context.Context, query string, args ...any) ([]*savedsearch.SavedSearch, error) {
+	rows := []savedSearchRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("list saved searches: %w", err)
+	}
+
+	searches := make([]*savedsearch.SavedSearch, 0, len(rows))
+	for _, row := range rows {
+		s, err := row.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, s)
+	}
+	return searches, nil
+}
+
+// ListByUser retrieves a user's saved searches, newest first
+func (r *PostgresSavedSearchRepository) ListByUser(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) ([]*savedsearch.SavedSearch, error) {
+	return r.list(ctx,
+		`SELECT `+savedSearchColumns+` FROM saved_searches WHERE tenant_id = $1 AND user_id = $2 ORDER BY created_at DESC`,
+		tenantID, userID)
+}
+
+// ListAlerting retrieves the tenant's saved searches with alerts enabled
+func (r *PostgresSavedSearchRepository) ListAlerting(ctx context.Context, tenantID kernel.TenantID) ([]*savedsearch.SavedSearch, error) {
+	return r.list(ctx,
+		`SELECT `+savedSearchColumns+` FROM saved_searches WHERE tenant_id = $1 AND alerts_enabled ORDER BY created_at`,
+		tenantID)
+}
+
+// CountByUser counts a user's saved searches
+func (r *PostgresSavedSearchRepository) CountByUser(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count,
+		`SELECT COUNT(*) FROM saved_searches WHERE tenant_id = $1 AND user_id = $2`, tenantID, userID); err != nil {
+		return 0, fmt.Errorf("count saved searches: %w", err)
+	}
+	return count, nil
+}
+
+// GetByID retrieves a saved search by ID
+func (r *PostgresSavedSearchRepository) GetByID(ctx context.Context, id kernel.SavedSearchID) (*savedsearch.SavedSearch, error) {
+	row := savedSearchRow{}
+	if err := r.db.GetContext(ctx, &row, `SELECT `+savedSearchColumns+` FROM saved_searches WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, savedsearch.ErrSavedSearchNotFound().WithDetail("saved_search_id", id)
+		}
+		return nil, fmt.Errorf("get saved search: %w", err)
+	}
+	return row.toDomain()
+}
+
+// Create creates a saved search
+func (r *PostgresSavedSearchRepository) Create(ctx context.Context, s *savedsearch.SavedSearch) error {
+	request, err := json.Marshal(s.Request)
+	if err != nil {
+		return fmt.Errorf("marshal saved search request: %w", err)
+	}
+
+	query := `
+		INSERT INTO saved_searches (id, tenant_id, user_id, name, request, min_score, alerts_enabled, email_alerts, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		s.ID, s.TenantID, s.UserID, s.Name, request, s.MinScore, s.AlertsEnabled, s.EmailAlerts, s.CreatedAt, s.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("insert saved search: %w", err)
+	}
+	return nil
+}
+
+// Update updates a saved search
+func (r *PostgresSavedSearchRepository) Update(ctx context.Context, s *savedsearch.SavedSearch) error {
+	request, err := json.Marshal(s.Request)
+	if err != nil {
+		return fmt.Errorf("marshal saved search request: %w", err)
+	}
+
+	query := `
+		UPDATE saved_searches SET
+			name = $2, request = $3, min_score = $4, alerts_enabled = $5, email_alerts = $6, updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		s.ID, s.Name, request, s.MinScore, s.AlertsEnabled, s.EmailAlerts, s.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update saved search: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return savedsearch.ErrSavedSearchNotFound().WithDetail("saved_search_id", s.ID)
+	}
+	return nil
+}
+
+// TouchLastMatch records when the search last matched a new resume
+func (r *PostgresSavedSearchRepository) TouchLastMatch(ctx context.Context, id kernel.SavedSearchID, at time.Time) error {
+	// updated_at tracks edits by the owner, so it is left alone here
+	if _, err := r.db.ExecContext(ctx, `UPDATE saved_searches SET last_match_at = $2 WHERE id = $1`, id, at); err != nil {
+		return fmt.Errorf("update saved search last match: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a saved search and its notifications
+func (r *PostgresSavedSearchRepository) Delete(ctx context.Context, id kernel.SavedSearchID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM saved_searches WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete saved search: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return savedsearch.ErrSavedSearchNotFound().WithDetail("saved_search_id", id)
+	}
+	return nil
+}
+
+// ============================================================================
+// Notifications
+// ============================================================================
+
+type PostgresNotificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresNotificationRepository(db *sqlx.DB) savedsearch.NotificationRepository {
+	return &PostgresNotificationRepository{db: db}
+}
+
+const notificationColumns = `id, tenant_id, user_id, saved_search_id, saved_search_name, resume_id,
+	candidate_name, resume_title, score, matched_skills, read_at, created_at`
+
+// notificationRow is the database representation of a notification
+type notificationRow struct {
+	ID              string         `db:"id"`
+	TenantID        string         `db:"tenant_id"`
+	UserID          string         `db:"user_id"`
+	SavedSearchID   string         `db:"saved_search_id"`
+	SavedSearchName string         `db:"saved_search_name"`
+	ResumeID        string         `db:"resume_id"`
+	CandidateName   string         `db:"candidate_name"`
+	ResumeTitle     string         `db:"resume_title"`
+	Score           float64        `db:"score"`
+	MatchedSkills   pq.StringArray `db:"matched_skills"`
+	ReadAt          sql.NullTime   `db:"read_at"`
+	CreatedAt       time.Time      `db:"created_at"`
+}
+
+func (r notificationRow) toDomain() savedsearch.Notification {
+	n := savedsearch.Notification{
+		ID:              kernel.NewNotificationID(r.ID),
+		TenantID:        kernel.TenantID(r.TenantID),
+		UserID:          kernel.UserID(r.UserID),
+		SavedSearchID:   kernel.NewSavedSearchID(r.SavedSearchID),
+		SavedSearchName: r.SavedSearchName,
+		ResumeID:        kernel.NewResumeID(r.ResumeID),
+		CandidateName:   r.CandidateName,
+		ResumeTitle:     r.ResumeTitle,
+		Score:           r.Score,
+		MatchedSkills:   []string(r.MatchedSkills),
+		CreatedAt:       r.CreatedAt,
+	}
+	if n.MatchedSkills == nil {
+		n.MatchedSkills = []string{}
+	}
+	if r.ReadAt.Valid {
+		n.ReadAt = &r.ReadAt.Time
+	}
+	return n
+}
+
+// Create records a match, or reports false when the search already matched the resume
+func (r *PostgresNotificationRepository) Create(ctx context.Context, n *savedsearch.Notification) (bool, error) {
+	query := `
+		INSERT INTO saved_search_notifications (
+			id, tenant_id, user_id, saved_search_id, saved_search_name, resume_id,
+			candidate_name, resume_title, score, matched_skills, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (saved_search_id, resume_id) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query,
+		n.ID, n.TenantID, n.UserID, n.SavedSearchID, n.SavedSearchName, n.ResumeID,
+		n.CandidateName, n.ResumeTitle, n.Score, pq.Array(n.MatchedSkills), n.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("insert notification: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ListByUser retrieves a user's notifications, newest first
+func (r *PostgresNotificationRepository) ListByUser(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID, unreadOnly bool, pagination kernel.PaginationOptions) (*kernel.Paginated[savedsearch.Notification], error) {
+	where := `WHERE tenant_id = $1 AND user_id = $2`
+	if unreadOnly {
+		where += ` AND read_at IS NULL`
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM saved_search_notifications `+where, tenantID, userID); err != nil {
+		return nil, fmt.Errorf("count notifications: %w", err)
+	}
+
+	offset := (pagination.Page - 1) * pagination.PageSize
+	query := `SELECT ` + notificationColumns + ` FROM saved_search_notifications ` + where + `
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows := []notificationRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, tenantID, userID, pagination.PageSize, offset); err != nil {
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+
+	notifications := make([]savedsearch.Notification, len(rows))
+	for i, row := range rows {
+		notifications[i] = row.toDomain()
+	}
+
+	paginated := kernel.NewPaginated(notifications, pagination.Page, pagination.PageSize, total)
+	return &paginated, nil
+}
+
+// CountUnread counts a user's unread notifications
+func (r *PostgresNotificationRepository) CountUnread(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count,
+		`SELECT COUNT(*) FROM saved_search_notifications WHERE tenant_id = $1 AND user_id = $2 AND read_at IS NULL`,
+		tenantID, userID); err != nil {
+		return 0, fmt.Errorf("count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (r *PostgresNotificationRepository) MarkAsRead(ctx context.Context, id kernel.NotificationID, tenantID kernel.TenantID, userID kernel.UserID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE saved_search_notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND tenant_id = $2 AND user_id = $3`, id, tenantID, userID)
+	if err != nil {
+		return fmt.Errorf("mark notification read: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return savedsearch.ErrNotificationNotFound().WithDetail("notification_id", id)
+	}
+	return nil
+}
+
+// MarkAllAsRead marks all of the user's notifications as read and returns how many changed
+func (r *PostgresNotificationRepository) MarkAllAsRead(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE saved_search_notifications SET read_at = CURRENT_TIMESTAMP
+		WHERE tenant_id = $1 AND user_id = $2 AND read_at IS NULL`, tenantID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("mark notifications read: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
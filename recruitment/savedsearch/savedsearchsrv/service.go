@@ -0,0 +1,357 @@
+package savedsearchsrv
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/iam/user"
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/pkg/logx"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/Abraxas-365/relay/recruitment/savedsearch"
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	repo          savedsearch.Repository
+	notifications savedsearch.NotificationRepository
+	searcher      savedsearch.Searcher
+	users         user.UserRepository
+	notifier      savedsearch.Notifier
+}
+
+// NewService creates a new saved search service. The notifier may be nil, which turns email alerts off.
+func NewService(
+	repo savedsearch.Repository,
+	notifications savedsearch.NotificationRepository,
+	searcher savedsearch.Searcher,
+	users user.UserRepository,
+	notifier savedsearch.Notifier,
+) *Service {
+	return &Service{
+		repo:          repo,
+		notifications: notifications,
+		searcher:      searcher,
+		users:         users,
+		notifier:      notifier,
+	}
+}
+
+// ============================================================================
+// Saved searches
+// ============================================================================
+
+// ListSavedSearches returns the user's saved searches
+func (s *Service) ListSavedSearches(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (*savedsearch.ListSavedSearchesResponse, error) {
+	searches, err := s.repo.ListByUser(ctx, tenantID, userID)
+	if err != nil {
+		return nil, savedsearch.ErrRegistry.NewWithCause(savedsearch.CodeSavedSearchFailed, err)
+	}
+	return savedsearch.ToListSavedSearchesResponse(searches), nil
+}
+
+// GetSavedSearch returns one of the user's saved searches
+func (s *Service) GetSavedSearch(ctx context.Context, id kernel.SavedSearchID, tenantID kernel.TenantID, userID kernel.UserID) (*savedsearch.SavedSearch, error) {
+	search, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' searches are reported as missing rather than forbidden
+	if !search.BelongsTo(tenantID, userID) {
+		return nil, savedsearch.ErrSavedSearchNotFound().WithDetail("saved_search_id", id)
+	}
+	return search, nil
+}
+
+// CreateSavedSearch saves a search for the user
+func (s *Service) CreateSavedSearch(ctx context.Context, req savedsearch.CreateSavedSearchRequest) (*savedsearch.SavedSearch, error) {
+	count, err := s.repo.CountByUser(ctx, req.TenantID, req.UserID)
+	if err != nil {
+		return nil, savedsearch.ErrRegistry.NewWithCause(savedsearch.CodeSavedSearchFailed, err)
+	}
+	if count >= savedsearch.MaxSavedSearchesPerUser {
+		return nil, savedsearch.ErrMaxSavedSearchesReached().
+			WithDetail("current_count", count).
+			WithDetail("max_allowed", savedsearch.MaxSavedSearchesPerUser)
+	}
+
+	now := time.Now()
+	search := &savedsearch.SavedSearch{
+		ID:            kernel.NewSavedSearchID(uuid.NewString()),
+		TenantID:      req.TenantID,
+		UserID:        req.UserID,
+		Name:          strings.TrimSpace(req.Name),
+		MinScore:      savedsearch.DefaultMinScore,
+		AlertsEnabled: true,
+		EmailAlerts:   req.EmailAlerts,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if req.MinScore != nil {
+		search.MinScore = *req.MinScore
+	}
+	if req.AlertsEnabled != nil {
+		search.AlertsEnabled = *req.AlertsEnabled
+	}
+
+	request, err := s.prepareRequest(ctx, req.TenantID, req.Request)
+	if err != nil {
+		return nil, err
+	}
+	search.Request = request
+
+	if err := validateSavedSearch(search); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// UpdateSavedSearch updates one of the user's saved searches
+func (s *Service) UpdateSavedSearch(ctx context.Context, id kernel.SavedSearchID, req savedsearch.UpdateSavedSearchRequest) (*savedsearch.SavedSearch, error) {
+	search, err := s.GetSavedSearch(ctx, id, req.TenantID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		search.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Request != nil {
+		request, err := s.prepareRequest(ctx, req.TenantID, *req.Request)
+		if err != nil {
+			return nil, err
+		}
+		search.Request = request
+	}
+	if req.MinScore != nil {
+		search.MinScore = *req.MinScore
+	}
+	if req.AlertsEnabled != nil {
+		search.AlertsEnabled = *req.AlertsEnabled
+	}
+	if req.EmailAlerts != nil {
+		search.EmailAlerts = *req.EmailAlerts
+	}
+	search.UpdatedAt = time.Now()
+
+	if err := validateSavedSearch(search); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// DeleteSavedSearch deletes one of the user's saved searches and its notifications
+func (s *Service) DeleteSavedSearch(ctx context.Context, id kernel.SavedSearchID, tenantID kernel.TenantID, userID kernel.UserID) error {
+	if _, err := s.GetSavedSearch(ctx, id, tenantID, userID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// RunSavedSearch runs one of the user's saved searches now
+func (s *Service) RunSavedSearch(ctx context.Context, id kernel.SavedSearchID, tenantID kernel.TenantID, userID kernel.UserID) (*resume.SearchResumesResponse, error) {
+	search, err := s.GetSavedSearch(ctx, id, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.searcher.SearchResumes(ctx, search.SearchRequest())
+}
+
+// prepareRequest validates a search request before it is stored. Query interpretation
+// runs once here, so alerts neither repeat the model call nor change between resumes.
+func (s *Service) prepareRequest(ctx context.Context, tenantID kernel.TenantID, req resume.SearchResumesRequest) (resume.SearchResumesRequest, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	req.TenantID = nil // Always scoped to the search's tenant when run
+	if req.TopK == 0 {
+		req.TopK = 10
+	}
+	if req.Mode == "" {
+		req.Mode = resume.SearchModeSemantic
+	}
+
+	if req.Interpret && req.Query != "" {
+		interpretation, err := s.searcher.InterpretQuery(ctx, resume.InterpretQueryRequest{
+			Query:    req.Query,
+			TenantID: tenantID,
+		})
+		if err != nil {
+			return req, err
+		}
+		interpretation.ApplyTo(&req)
+		req.Interpret = false
+	}
+
+	if err := s.searcher.ValidateSearchRequest(req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// validateSavedSearch checks the fields owned by the saved search itself
+func validateSavedSearch(search *savedsearch.SavedSearch) error {
+	if search.Name == "" || len(search.Name) > savedsearch.MaxNameLength {
+		return savedsearch.ErrInvalidSavedSearch().
+			WithDetail("field", "name").
+			WithDetail("reason", "name is required and must be at most 255 characters")
+	}
+	if search.MinScore < 0 || search.MinScore > 1 || math.IsNaN(search.MinScore) {
+		return savedsearch.ErrInvalidSavedSearch().
+			WithDetail("field", "min_score").
+			WithDetail("value", search.MinScore).
+			WithDetail("reason", "min_score must be between 0 and 1")
+	}
+	return nil
+}
+
+// ============================================================================
+// New-match alerts
+// ============================================================================
+
+// ResumeProcessed scores a newly processed resume against the tenant's alerting
+// searches and records a notification for each search it matches
+func (s *Service) ResumeProcessed(ctx context.Context, r *resume.Resume) {
+	searches, err := s.repo.ListAlerting(ctx, r.TenantID)
+	if err != nil {
+		logx.Errorf("Failed to load saved searches for tenant %s: %v", r.TenantID, err)
+		return
+	}
+
+	for _, search := range searches {
+		if ctx.Err() != nil {
+			logx.Warnf("Stopped evaluating saved searches for resume %s: %v", r.ID, ctx.Err())
+			return
+		}
+		if err := s.evaluate(ctx, search, r); err != nil {
+			logx.Warnf("Failed to evaluate saved search %s for resume %s: %v", search.ID, r.ID, err)
+		}
+	}
+}
+
+// evaluate records a notification when the resume scores at least the search's minimum
+func (s *Service) evaluate(ctx context.Context, search *savedsearch.SavedSearch, r *resume.Resume) error {
+	req := search.AlertRequest(r.ID)
+	response, err := s.searcher.SearchResumes(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(response.Results.Items) == 0 {
+		return nil // Filtered out
+	}
+
+	match := response.Results.Items[0]
+	score := savedsearch.MatchScore(response.Mode, match)
+	if score < search.MinScore {
+		return nil
+	}
+
+	now := time.Now()
+	notification := &savedsearch.Notification{
+		ID:              kernel.NewNotificationID(uuid.NewString()),
+		TenantID:        search.TenantID,
+		UserID:          search.UserID,
+		SavedSearchID:   search.ID,
+		SavedSearchName: search.Name,
+		ResumeID:        r.ID,
+		CandidateName:   r.PersonalInfo.FullName,
+		ResumeTitle:     r.Title,
+		Score:           score,
+		MatchedSkills:   match.MatchedSkills,
+		CreatedAt:       now,
+	}
+	if notification.MatchedSkills == nil {
+		notification.MatchedSkills = []string{}
+	}
+
+	created, err := s.notifications.Create(ctx, notification)
+	if err != nil || !created {
+		return err
+	}
+
+	if err := s.repo.TouchLastMatch(ctx, search.ID, now); err != nil {
+		logx.Warnf("Failed to record last match of saved search %s: %v", search.ID, err)
+	}
+
+	if search.EmailAlerts && s.notifier != nil {
+		s.deliver(ctx, search, notification)
+	}
+
+	return nil
+}
+
+// deliver sends the alert through the notifier; the inbox entry stays either way
+func (s *Service) deliver(ctx context.Context, search *savedsearch.SavedSearch, n *savedsearch.Notification) {
+	owner, err := s.users.FindByID(ctx, search.UserID, search.TenantID)
+	if err != nil {
+		logx.Warnf("Skipping email alert for saved search %s: %v", search.ID, err)
+		return
+	}
+
+	recipient := savedsearch.Recipient{Email: owner.Email, Name: owner.Name}
+	if err := s.notifier.NotifyMatch(ctx, recipient, search, n); err != nil {
+		logx.Warnf("Failed to send email alert for saved search %s: %v", search.ID, err)
+	}
+}
+
+// ============================================================================
+// Notifications
+// ============================================================================
+
+// ListNotifications returns a page of the user's notifications and the unread total
+func (s *Service) ListNotifications(ctx context.Context, req savedsearch.ListNotificationsRequest) (*savedsearch.ListNotificationsResponse, error) {
+	if req.Pagination.Page < 1 {
+		req.Pagination.Page = 1
+	}
+	if req.Pagination.PageSize < 1 || req.Pagination.PageSize > 100 {
+		req.Pagination.PageSize = 20
+	}
+
+	page, err := s.notifications.ListByUser(ctx, req.TenantID, req.UserID, req.UnreadOnly, req.Pagination)
+	if err != nil {
+		return nil, savedsearch.ErrRegistry.NewWithCause(savedsearch.CodeSavedSearchFailed, err)
+	}
+
+	unread, err := s.notifications.CountUnread(ctx, req.TenantID, req.UserID)
+	if err != nil {
+		return nil, savedsearch.ErrRegistry.NewWithCause(savedsearch.CodeSavedSearchFailed, err)
+	}
+
+	return &savedsearch.ListNotificationsResponse{
+		Notifications: *page,
+		UnreadCount:   unread,
+	}, nil
+}
+
+// CountUnreadNotifications returns the number of unread notifications
+func (s *Service) CountUnreadNotifications(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (*savedsearch.UnreadCountResponse, error) {
+	unread, err := s.notifications.CountUnread(ctx, tenantID, userID)
+	if err != nil {
+		return nil, savedsearch.ErrRegistry.NewWithCause(savedsearch.CodeSavedSearchFailed, err)
+	}
+	return &savedsearch.UnreadCountResponse{UnreadCount: unread}, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications as read
+func (s *Service) MarkNotificationRead(ctx context.Context, id kernel.NotificationID, tenantID kernel.TenantID, userID kernel.UserID) error {
+	return s.notifications.MarkAsRead(ctx, id, tenantID, userID)
+}
+
+// MarkAllNotificationsRead marks all of the user's notifications as read
+func (s *Service) MarkAllNotificationsRead(ctx context.Context, tenantID kernel.TenantID, userID kernel.UserID) (*savedsearch.MarkAllReadResponse, error) {
+	updated, err := s.notifications.MarkAllAsRead(ctx, tenantID, userID)
+	if err != nil {
+		return nil, savedsearch.ErrRegistry.NewWithCause(savedsearch.CodeSavedSearchFailed, err)
+	}
+	return &savedsearch.MarkAllReadResponse{Updated: updated}, nil
+}
@@ -0,0 +1,80 @@
+package skill
+
+import "github.com/Abraxas-365/relay/pkg/kernel"
+
+// CreateSkillRequest - Add a tenant skill, or override the global skill with the same name
+type CreateSkillRequest struct {
+	TenantID kernel.TenantID `json:"-"`
+	Name     string          `json:"name" validate:"required"`
+	Parent   string          `json:"parent,omitempty"`
+	Aliases  []string        `json:"aliases,omitempty"`
+}
+
+// UpdateSkillRequest - Update a tenant skill
+type UpdateSkillRequest struct {
+	TenantID kernel.TenantID `json:"-"`
+	Name     *string         `json:"name,omitempty"`
+	Parent   *string         `json:"parent,omitempty"` // Empty string removes the parent
+	Aliases  *[]string       `json:"aliases,omitempty"`
+}
+
+// SkillResponse - A taxonomy entry with its place in the hierarchy
+type SkillResponse struct {
+	Skill
+	Scope     string   `json:"scope"`     // global or tenant
+	Ancestors []string `json:"ancestors"` // Nearest parent first
+}
+
+// ListSkillsResponse - The effective taxonomy of a tenant
+type ListSkillsResponse struct {
+	Skills []SkillResponse `json:"skills"`
+	Total  int             `json:"total"`
+}
+
+// ResolvedSkill - How the taxonomy reads a skill name
+type ResolvedSkill struct {
+	Input     string   `json:"input"`
+	Canonical string   `json:"canonical"`
+	Known     bool     `json:"known"`
+	Ancestors []string `json:"ancestors"`
+	Accepts   []string `json:"accepts"` // Normalized names that satisfy the skill in search filters
+}
+
+// ResolveSkillsResponse - Resolution of several skill names
+type ResolveSkillsResponse struct {
+	Skills []ResolvedSkill `json:"skills"`
+}
+
+// ToSkillResponse places an entry in the taxonomy
+func ToSkillResponse(s *Skill, taxonomy *Taxonomy) SkillResponse {
+	return SkillResponse{
+		Skill:     *s,
+		Scope:     s.Scope(),
+		Ancestors: taxonomy.Ancestors(s.Name),
+	}
+}
+
+// ToListSkillsResponse lists the effective entries of a taxonomy
+func ToListSkillsResponse(taxonomy *Taxonomy) *ListSkillsResponse {
+	skills := taxonomy.Skills()
+	responses := make([]SkillResponse, len(skills))
+	for i, s := range skills {
+		responses[i] = ToSkillResponse(s, taxonomy)
+	}
+	return &ListSkillsResponse{
+		Skills: responses,
+		Total:  len(responses),
+	}
+}
+
+// ToResolvedSkill resolves a name against a taxonomy
+func ToResolvedSkill(name string, taxonomy *Taxonomy) ResolvedSkill {
+	_, known := taxonomy.Resolve(name)
+	return ResolvedSkill{
+		Input:     name,
+		Canonical: taxonomy.Canonical(name),
+		Known:     known,
+		Ancestors: taxonomy.Ancestors(name),
+		Accepts:   taxonomy.Accepts(name),
+	}
+}
@@ -0,0 +1,49 @@
+package skill
+
+import (
+	"net/http"
+
+	"github.com/Abraxas-365/relay/pkg/errx"
+)
+
+var ErrRegistry = errx.NewRegistry("SKILL")
+
+// Error codes - Skill Taxonomy
+var (
+	CodeSkillNotFound      = ErrRegistry.Register("NOT_FOUND", errx.TypeNotFound, http.StatusNotFound, "Skill not found")
+	CodeSkillAlreadyExists = ErrRegistry.Register("ALREADY_EXISTS", errx.TypeConflict, http.StatusConflict, "Skill already exists")
+	CodeInvalidSkillData   = ErrRegistry.Register("INVALID_DATA", errx.TypeValidation, http.StatusBadRequest, "Invalid skill data")
+	CodeInvalidParent      = ErrRegistry.Register("INVALID_PARENT", errx.TypeValidation, http.StatusBadRequest, "Invalid parent skill")
+	CodeAliasConflict      = ErrRegistry.Register("ALIAS_CONFLICT", errx.TypeConflict, http.StatusConflict, "Alias is the name of another skill")
+	CodeGlobalSkillLocked  = ErrRegistry.Register("GLOBAL_SKILL_LOCKED", errx.TypeAuthorization, http.StatusForbidden, "Global skills cannot be modified; create a tenant skill with the same name to override it")
+	CodeTaxonomyFailed     = ErrRegistry.Register("TAXONOMY_FAILED", errx.TypeInternal, http.StatusInternalServerError, "Skill taxonomy operation failed")
+)
+
+// Helper functions - Skill Taxonomy
+func ErrSkillNotFound() *errx.Error {
+	return ErrRegistry.New(CodeSkillNotFound)
+}
+
+func ErrSkillAlreadyExists() *errx.Error {
+	return ErrRegistry.New(CodeSkillAlreadyExists)
+}
+
+func ErrInvalidSkillData() *errx.Error {
+	return ErrRegistry.New(CodeInvalidSkillData)
+}
+
+func ErrInvalidParent() *errx.Error {
+	return ErrRegistry.New(CodeInvalidParent)
+}
+
+func ErrAliasConflict() *errx.Error {
+	return ErrRegistry.New(CodeAliasConflict)
+}
+
+func ErrGlobalSkillLocked() *errx.Error {
+	return ErrRegistry.New(CodeGlobalSkillLocked)
+}
+
+func ErrTaxonomyFailed() *errx.Error {
+	return ErrRegistry.New(CodeTaxonomyFailed)
+}
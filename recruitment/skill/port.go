@@ -0,0 +1,24 @@
+package skill
+
+import (
+	"context"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+)
+
+type Repository interface {
+	// ListForTenant retrieves the global entries plus the tenant's own entries (globals only when tenantID is nil)
+	ListForTenant(ctx context.Context, tenantID *kernel.TenantID) ([]*Skill, error)
+
+	// GetByID retrieves an entry by ID
+	GetByID(ctx context.Context, id kernel.SkillID) (*Skill, error)
+
+	// Create creates a tenant entry
+	Create(ctx context.Context, s *Skill) error
+
+	// Update updates a tenant entry
+	Update(ctx context.Context, s *Skill) error
+
+	// Delete deletes a tenant entry
+	Delete(ctx context.Context, id kernel.SkillID) error
+}
@@ -0,0 +1,69 @@
+package skill
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+)
+
+// Skill is an entry of the skill taxonomy: a canonical name, the aliases that
+// mean the same skill and the broader skill or category it belongs to
+// (e.g. React → JavaScript → Frontend).
+// Global entries (no tenant) ship with the seed dataset; tenants can add their
+// own entries or override a global one by reusing its name.
+type Skill struct {
+	ID        kernel.SkillID   `json:"id"`
+	TenantID  *kernel.TenantID `json:"tenant_id,omitempty"`
+	Name      string           `json:"name"`
+	Parent    string           `json:"parent,omitempty"` // Canonical name of the parent skill or category
+	Aliases   []string         `json:"aliases"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// Scope of a taxonomy entry
+const (
+	ScopeGlobal = "global"
+	ScopeTenant = "tenant"
+)
+
+// Normalize returns the comparison key of a skill name: lowercase with collapsed whitespace.
+// It must stay in sync with normalize_skill_name in the database.
+func Normalize(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// IsGlobal reports whether the entry belongs to the shared seed taxonomy
+func (s *Skill) IsGlobal() bool {
+	return s.TenantID == nil
+}
+
+// Scope returns whether the entry is global or tenant-specific
+func (s *Skill) Scope() string {
+	if s.IsGlobal() {
+		return ScopeGlobal
+	}
+	return ScopeTenant
+}
+
+// BelongsTo reports whether a tenant owns the entry
+func (s *Skill) BelongsTo(tenantID kernel.TenantID) bool {
+	return s.TenantID != nil && *s.TenantID == tenantID
+}
+
+// NormalizedName returns the comparison key of the canonical name
+func (s *Skill) NormalizedName() string {
+	return Normalize(s.Name)
+}
+
+// Names returns the normalized canonical name followed by the normalized aliases
+func (s *Skill) Names() []string {
+	names := []string{s.NormalizedName()}
+	for _, alias := range s.Aliases {
+		if key := Normalize(alias); key != "" {
+			names = append(names, key)
+		}
+	}
+	return names
+}
@@ -0,0 +1,148 @@
+package skillapi
+
+import (
+	"strings"
+
+	"github.com/Abraxas-365/relay/pkg/iam/auth"
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/skill"
+	"github.com/Abraxas-365/relay/recruitment/skill/skillsrv"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SkillHandlers struct {
+	service *skillsrv.Service
+}
+
+func NewSkillHandlers(service *skillsrv.Service) *SkillHandlers {
+	return &SkillHandlers{service: service}
+}
+
+func (h *SkillHandlers) RegisterRoutes(app *fiber.App, authMiddleware *auth.UnifiedAuthMiddleware) {
+	skills := app.Group("/api/v1/skills", authMiddleware.Authenticate())
+
+	skills.Get("/", h.ListSkills)           // Effective taxonomy (global + tenant)
+	skills.Get("/resolve", h.ResolveSkills) // Resolve names to canonical skills
+	skills.Post("/", h.CreateSkill)         // Add or override a skill for the tenant
+	skills.Put("/:id", h.UpdateSkill)       // Update a tenant skill
+	skills.Delete("/:id", h.DeleteSkill)    // Delete a tenant skill
+}
+
+// ListSkills lists the tenant's effective skill taxonomy
+// GET /api/v1/skills
+func (h *SkillHandlers) ListSkills(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	response, err := h.service.ListSkills(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// ResolveSkills shows the canonical name, ancestors and accepted names of each skill
+// GET /api/v1/skills/resolve?names=JS,reactjs
+func (h *SkillHandlers) ResolveSkills(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	names := []string{}
+	for _, name := range strings.Split(c.Query("names"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "names is required",
+		})
+	}
+
+	response, err := h.service.ResolveSkills(c.Context(), authCtx.TenantID, names)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// CreateSkill adds a tenant skill
+// POST /api/v1/skills
+func (h *SkillHandlers) CreateSkill(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	var req skill.CreateSkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	req.TenantID = authCtx.TenantID
+
+	response, err := h.service.CreateSkill(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// UpdateSkill updates a tenant skill
+// PUT /api/v1/skills/:id
+func (h *SkillHandlers) UpdateSkill(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	var req skill.UpdateSkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	req.TenantID = authCtx.TenantID
+
+	response, err := h.service.UpdateSkill(c.Context(), kernel.NewSkillID(c.Params("id")), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response)
+}
+
+// DeleteSkill deletes a tenant skill
+// DELETE /api/v1/skills/:id
+func (h *SkillHandlers) DeleteSkill(c *fiber.Ctx) error {
+	authCtx, ok := auth.GetAuthContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "authentication required",
+		})
+	}
+
+	if err := h.service.DeleteSkill(c.Context(), kernel.NewSkillID(c.Params("id")), authCtx.TenantID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
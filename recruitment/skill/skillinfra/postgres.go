@@ -0,0 +1,140 @@
+package skillinfra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/skill"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type PostgresSkillRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresSkillRepository(db *sqlx.DB) skill.Repository {
+	return &PostgresSkillRepository{db: db}
+}
+
+const skillColumns = `id, tenant_id, name, COALESCE(parent, '') AS parent, aliases, created_at, updated_at`
+
+// skillRow is the database representation of a taxonomy entry
+type skillRow struct {
+	ID        string         `db:"id"`
+	TenantID  sql.NullString `db:"tenant_id"`
+	Name      string         `db:"name"`
+	Parent    string         `db:"parent"`
+	Aliases   pq.StringArray `db:"aliases"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+func (r skillRow) toDomain() *skill.Skill {
+	s := &skill.Skill{
+		ID:        kernel.NewSkillID(r.ID),
+		Name:      r.Name,
+		Parent:    r.Parent,
+		Aliases:   []string(r.Aliases),
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if s.Aliases == nil {
+		s.Aliases = []string{}
+	}
+	if r.TenantID.Valid {
+		tenantID := kernel.TenantID(r.TenantID.String)
+		s.TenantID = &tenantID
+	}
+	return s
+}
+
+// ListForTenant retrieves the global entries plus the tenant's own entries
+func (r *PostgresSkillRepository) ListForTenant(ctx context.Context, tenantID *kernel.TenantID) ([]*skill.Skill, error) {
+	query := `SELECT ` + skillColumns + ` FROM skill_taxonomy WHERE tenant_id IS NULL`
+	args := []any{}
+	if tenantID != nil {
+		query += ` OR tenant_id = $1`
+		args = append(args, *tenantID)
+	}
+
+	rows := []skillRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("list skills: %w", err)
+	}
+
+	skills := make([]*skill.Skill, len(rows))
+	for i, row := range rows {
+		skills[i] = row.toDomain()
+	}
+	return skills, nil
+}
+
+// GetByID retrieves an entry by ID
+func (r *PostgresSkillRepository) GetByID(ctx context.Context, id kernel.SkillID) (*skill.Skill, error) {
+	row := skillRow{}
+	if err := r.db.GetContext(ctx, &row, `SELECT `+skillColumns+` FROM skill_taxonomy WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, skill.ErrSkillNotFound().WithDetail("skill_id", id)
+		}
+		return nil, fmt.Errorf("get skill: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// Create creates a tenant entry
+func (r *PostgresSkillRepository) Create(ctx context.Context, s *skill.Skill) error {
+	query := `
+		INSERT INTO skill_taxonomy (id, tenant_id, name, normalized_name, parent, aliases, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		s.ID, s.TenantID, s.Name, s.NormalizedName(), s.Parent, pq.Array(s.Aliases), s.CreatedAt, s.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return skill.ErrSkillAlreadyExists().WithDetail("name", s.Name)
+		}
+		return fmt.Errorf("insert skill: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates a tenant entry
+func (r *PostgresSkillRepository) Update(ctx context.Context, s *skill.Skill) error {
+	query := `
+		UPDATE skill_taxonomy SET
+			name = $2, normalized_name = $3, parent = NULLIF($4, ''), aliases = $5, updated_at = $6
+		WHERE id = $1 AND tenant_id IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query,
+		s.ID, s.Name, s.NormalizedName(), s.Parent, pq.Array(s.Aliases), s.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return skill.ErrSkillAlreadyExists().WithDetail("name", s.Name)
+		}
+		return fmt.Errorf("update skill: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return skill.ErrSkillNotFound().WithDetail("skill_id", s.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a tenant entry
+func (r *PostgresSkillRepository) Delete(ctx context.Context, id kernel.SkillID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM skill_taxonomy WHERE id = $1 AND tenant_id IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("delete skill: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return skill.ErrSkillNotFound().WithDetail("skill_id", id)
+	}
+	return nil
+}
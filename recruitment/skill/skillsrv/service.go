@@ -0,0 +1,302 @@
+package skillsrv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Abraxas-365/relay/pkg/kernel"
+	"github.com/Abraxas-365/relay/recruitment/resume"
+	"github.com/Abraxas-365/relay/recruitment/skill"
+	"github.com/google/uuid"
+)
+
+// maxSkillNameLength matches the name columns of skill_taxonomy
+const maxSkillNameLength = 100
+
+type Service struct {
+	repo skill.Repository
+}
+
+// NewService creates a new skill taxonomy service
+func NewService(repo skill.Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Taxonomy loads the effective taxonomy of a tenant (global entries only when tenantID is nil)
+func (s *Service) Taxonomy(ctx context.Context, tenantID *kernel.TenantID) (*skill.Taxonomy, error) {
+	skills, err := s.repo.ListForTenant(ctx, tenantID)
+	if err != nil {
+		return nil, skill.ErrRegistry.NewWithCause(skill.CodeTaxonomyFailed, err)
+	}
+	return skill.NewTaxonomy(skills), nil
+}
+
+// ============================================================================
+// Resume integration
+// ============================================================================
+
+// Canonicalize maps each name to its canonical skill name; names outside the taxonomy are left out
+func (s *Service) Canonicalize(ctx context.Context, tenantID kernel.TenantID, names []string) (map[string]string, error) {
+	taxonomy, err := s.Taxonomy(ctx, &tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := make(map[string]string)
+	for _, name := range names {
+		if entry, ok := taxonomy.Resolve(name); ok {
+			canonical[name] = entry.Name
+		}
+	}
+	return canonical, nil
+}
+
+// Expand resolves the names that satisfy each requested skill: its aliases, sub-skills and their aliases
+func (s *Service) Expand(ctx context.Context, tenantID *kernel.TenantID, names []string) (resume.SkillExpansions, error) {
+	expansions := make(resume.SkillExpansions)
+	if len(names) == 0 {
+		return expansions, nil
+	}
+
+	taxonomy, err := s.Taxonomy(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		expansions[name] = taxonomy.Accepts(name)
+	}
+	return expansions, nil
+}
+
+// ============================================================================
+// Taxonomy management
+// ============================================================================
+
+// ListSkills returns the effective taxonomy of a tenant
+func (s *Service) ListSkills(ctx context.Context, tenantID kernel.TenantID) (*skill.ListSkillsResponse, error) {
+	taxonomy, err := s.Taxonomy(ctx, &tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return skill.ToListSkillsResponse(taxonomy), nil
+}
+
+// ResolveSkills shows how the tenant's taxonomy reads each name
+func (s *Service) ResolveSkills(ctx context.Context, tenantID kernel.TenantID, names []string) (*skill.ResolveSkillsResponse, error) {
+	taxonomy, err := s.Taxonomy(ctx, &tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]skill.ResolvedSkill, len(names))
+	for i, name := range names {
+		resolved[i] = skill.ToResolvedSkill(name, taxonomy)
+	}
+	return &skill.ResolveSkillsResponse{Skills: resolved}, nil
+}
+
+// CreateSkill adds a tenant skill. Reusing the name of a global skill overrides it for the tenant.
+func (s *Service) CreateSkill(ctx context.Context, req skill.CreateSkillRequest) (*skill.SkillResponse, error) {
+	taxonomy, err := s.Taxonomy(ctx, &req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry := &skill.Skill{
+		ID:        kernel.NewSkillID(uuid.NewString()),
+		TenantID:  &req.TenantID,
+		Name:      cleanName(req.Name),
+		Parent:    req.Parent,
+		Aliases:   req.Aliases,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if existing, ok := taxonomy.Resolve(entry.Name); ok && existing.BelongsTo(req.TenantID) && existing.NormalizedName() == entry.NormalizedName() {
+		return nil, skill.ErrSkillAlreadyExists().
+			WithDetail("name", entry.Name).
+			WithDetail("skill_id", existing.ID)
+	}
+
+	if err := validateSkill(entry, taxonomy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	taxonomy, err = s.Taxonomy(ctx, &req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	response := skill.ToSkillResponse(entry, taxonomy)
+	return &response, nil
+}
+
+// UpdateSkill updates a tenant skill; global skills are read-only
+func (s *Service) UpdateSkill(ctx context.Context, id kernel.SkillID, req skill.UpdateSkillRequest) (*skill.SkillResponse, error) {
+	entry, err := s.getTenantSkill(ctx, id, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		entry.Name = cleanName(*req.Name)
+	}
+	if req.Parent != nil {
+		entry.Parent = *req.Parent
+	}
+	if req.Aliases != nil {
+		entry.Aliases = *req.Aliases
+	}
+	entry.UpdatedAt = time.Now()
+
+	// Validate against the taxonomy without the entry itself
+	skills, err := s.repo.ListForTenant(ctx, &req.TenantID)
+	if err != nil {
+		return nil, skill.ErrRegistry.NewWithCause(skill.CodeTaxonomyFailed, err)
+	}
+	others := make([]*skill.Skill, 0, len(skills))
+	for _, other := range skills {
+		if other.ID == entry.ID {
+			continue
+		}
+		if other.BelongsTo(req.TenantID) && other.NormalizedName() == entry.NormalizedName() {
+			return nil, skill.ErrSkillAlreadyExists().
+				WithDetail("name", entry.Name).
+				WithDetail("skill_id", other.ID)
+		}
+		others = append(others, other)
+	}
+
+	if err := validateSkill(entry, skill.NewTaxonomy(others)); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := s.Taxonomy(ctx, &req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	response := skill.ToSkillResponse(entry, taxonomy)
+	return &response, nil
+}
+
+// DeleteSkill deletes a tenant skill; an overridden global skill applies again
+func (s *Service) DeleteSkill(ctx context.Context, id kernel.SkillID, tenantID kernel.TenantID) error {
+	if _, err := s.getTenantSkill(ctx, id, tenantID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// getTenantSkill retrieves an entry the tenant may modify
+func (s *Service) getTenantSkill(ctx context.Context, id kernel.SkillID, tenantID kernel.TenantID) (*skill.Skill, error) {
+	entry, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.IsGlobal() {
+		return nil, skill.ErrGlobalSkillLocked().
+			WithDetail("skill_id", id).
+			WithDetail("name", entry.Name)
+	}
+	if !entry.BelongsTo(tenantID) {
+		return nil, skill.ErrSkillNotFound().WithDetail("skill_id", id)
+	}
+
+	return entry, nil
+}
+
+// validateSkill checks an entry against the rest of the taxonomy and normalizes
+// its parent to a canonical name and its aliases to a de-duplicated list
+func validateSkill(entry *skill.Skill, taxonomy *skill.Taxonomy) error {
+	key := entry.NormalizedName()
+	if key == "" {
+		return skill.ErrInvalidSkillData().
+			WithDetail("field", "name").
+			WithDetail("reason", "name is required")
+	}
+	if len(entry.Name) > maxSkillNameLength {
+		return skill.ErrInvalidSkillData().
+			WithDetail("field", "name").
+			WithDetail("reason", "name is too long").
+			WithDetail("max_length", maxSkillNameLength)
+	}
+
+	// An alias of another skill cannot become a skill of its own
+	if existing, ok := taxonomy.Resolve(entry.Name); ok && existing.NormalizedName() != key {
+		return skill.ErrAliasConflict().
+			WithDetail("name", entry.Name).
+			WithDetail("alias_of", existing.Name)
+	}
+
+	if parentName := cleanName(entry.Parent); parentName != "" {
+		parent, ok := taxonomy.Resolve(parentName)
+		if !ok {
+			return skill.ErrInvalidParent().
+				WithDetail("parent", parentName).
+				WithDetail("reason", "parent is not in the taxonomy")
+		}
+		if createsCycle(key, parent, taxonomy) {
+			return skill.ErrInvalidParent().
+				WithDetail("parent", parent.Name).
+				WithDetail("reason", "parent cannot be the skill itself or one of its sub-skills")
+		}
+		entry.Parent = parent.Name
+	} else {
+		entry.Parent = ""
+	}
+
+	aliases := make([]string, 0, len(entry.Aliases))
+	seen := map[string]bool{key: true}
+	for _, alias := range entry.Aliases {
+		alias = cleanName(alias)
+		aliasKey := skill.Normalize(alias)
+		if aliasKey == "" || seen[aliasKey] {
+			continue
+		}
+		if len(alias) > maxSkillNameLength {
+			return skill.ErrInvalidSkillData().
+				WithDetail("field", "aliases").
+				WithDetail("alias", alias).
+				WithDetail("reason", "alias is too long")
+		}
+		if existing, ok := taxonomy.Resolve(alias); ok && existing.NormalizedName() == aliasKey && aliasKey != key {
+			return skill.ErrAliasConflict().
+				WithDetail("alias", alias).
+				WithDetail("skill", existing.Name)
+		}
+		seen[aliasKey] = true
+		aliases = append(aliases, alias)
+	}
+	entry.Aliases = aliases
+
+	return nil
+}
+
+// createsCycle reports whether the skill with the given key is parent or one of its ancestors
+func createsCycle(key string, parent *skill.Skill, taxonomy *skill.Taxonomy) bool {
+	visited := make(map[string]bool)
+	for current := parent; current != nil && !visited[current.NormalizedName()]; {
+		if current.NormalizedName() == key || skill.Normalize(current.Parent) == key {
+			return true
+		}
+		visited[current.NormalizedName()] = true
+		current, _ = taxonomy.Resolve(current.Parent)
+	}
+	return false
+}
+
+// cleanName trims a name and collapses its whitespace, keeping its case
+func cleanName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
@@ -0,0 +1,154 @@
+package skill
+
+import (
+	"sort"
+	"strings"
+)
+
+// Taxonomy is the effective skill taxonomy of a tenant: the global entries
+// with the tenant's own entries added on top
+type Taxonomy struct {
+	skills   map[string]*Skill   // Keyed by normalized canonical name
+	lookup   map[string]string   // Normalized name or alias -> normalized canonical name
+	children map[string][]string // Normalized canonical name -> normalized canonical names of its children
+}
+
+// NewTaxonomy builds a taxonomy from global and tenant entries.
+// A tenant entry replaces the global entry with the same name, and canonical
+// names take precedence over aliases when both spell the same thing.
+func NewTaxonomy(skills []*Skill) *Taxonomy {
+	t := &Taxonomy{
+		skills:   make(map[string]*Skill),
+		lookup:   make(map[string]string),
+		children: make(map[string][]string),
+	}
+
+	// Global entries first so tenant entries win
+	ordered := make([]*Skill, 0, len(skills))
+	for _, s := range skills {
+		if s.IsGlobal() {
+			ordered = append(ordered, s)
+		}
+	}
+	for _, s := range skills {
+		if !s.IsGlobal() {
+			ordered = append(ordered, s)
+		}
+	}
+
+	for _, s := range ordered {
+		if key := s.NormalizedName(); key != "" {
+			t.skills[key] = s
+		}
+	}
+
+	for _, s := range ordered {
+		if t.skills[s.NormalizedName()] != s {
+			continue
+		}
+		for _, alias := range s.Names()[1:] {
+			t.lookup[alias] = s.NormalizedName()
+		}
+	}
+	for key := range t.skills {
+		t.lookup[key] = key
+	}
+
+	for key, s := range t.skills {
+		if parent := t.resolveKey(s.Parent); parent != "" && parent != key {
+			t.children[parent] = append(t.children[parent], key)
+		}
+	}
+
+	return t
+}
+
+func (t *Taxonomy) resolveKey(name string) string {
+	return t.lookup[Normalize(name)]
+}
+
+// Resolve returns the entry a name or alias refers to
+func (t *Taxonomy) Resolve(name string) (*Skill, bool) {
+	key := t.resolveKey(name)
+	if key == "" {
+		return nil, false
+	}
+	return t.skills[key], true
+}
+
+// Canonical returns the canonical spelling of a skill name.
+// Names outside the taxonomy are returned trimmed, with collapsed whitespace.
+func (t *Taxonomy) Canonical(name string) string {
+	if s, ok := t.Resolve(name); ok {
+		return s.Name
+	}
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Ancestors returns the canonical names of the parents of a skill, nearest first
+func (t *Taxonomy) Ancestors(name string) []string {
+	ancestors := []string{}
+	key := t.resolveKey(name)
+	visited := map[string]bool{key: true}
+
+	for key != "" {
+		parent := t.resolveKey(t.skills[key].Parent)
+		if parent == "" || visited[parent] {
+			break
+		}
+		visited[parent] = true
+		ancestors = append(ancestors, t.skills[parent].Name)
+		key = parent
+	}
+
+	return ancestors
+}
+
+// Accepts returns the normalized names that satisfy a requested skill: the
+// skill and its aliases plus every sub-skill and its aliases, so asking for
+// JavaScript accepts a candidate listing "React" or "JS".
+// Names outside the taxonomy only accept themselves.
+func (t *Taxonomy) Accepts(name string) []string {
+	root := t.resolveKey(name)
+	if root == "" {
+		if key := Normalize(name); key != "" {
+			return []string{key}
+		}
+		return []string{}
+	}
+
+	accepted := []string{}
+	seen := make(map[string]bool)
+	visited := make(map[string]bool)
+	queue := []string{root}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		for _, n := range t.skills[key].Names() {
+			if !seen[n] {
+				seen[n] = true
+				accepted = append(accepted, n)
+			}
+		}
+		queue = append(queue, t.children[key]...)
+	}
+
+	return accepted
+}
+
+// Skills returns the effective entries sorted by name
+func (t *Taxonomy) Skills() []*Skill {
+	skills := make([]*Skill, 0, len(t.skills))
+	for _, s := range t.skills {
+		skills = append(skills, s)
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].NormalizedName() < skills[j].NormalizedName()
+	})
+	return skills
+}